@@ -22,6 +22,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+//+kubebuilder:validation:XValidation:rule="[has(self.email) && self.email.address != '', has(self.opsgenie) && has(self.opsgenie.apisecret.name), has(self.slack), has(self.sms), has(self.call), has(self.webhook), has(self.pagerduty), has(self.telegram), has(self.msteams), has(self.incidentio)].filter(set, set).size() <= 1",message="only one alert channel type can be set"
+
 // AlertChannelSpec defines the desired state of AlertChannel
 type AlertChannelSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
@@ -33,11 +35,56 @@ type AlertChannelSpec struct {
 	// SendFailure determines if the Failure event should be sent to the alerting channel
 	SendFailure bool `json:"sendfailure,omitempty"`
 
+	// SendDegraded determines if the Degraded event should be sent to the alerting channel
+	SendDegraded bool `json:"senddegraded,omitempty"`
+
+	// SSLExpiry determines if an alert is sent before the SSL certificate of a checked endpoint expires
+	SSLExpiry bool `json:"sslexpiry,omitempty"`
+
+	// SSLExpiryThreshold determines how many days before the SSL certificate expires the alert is sent, default 30
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=30
+	SSLExpiryThreshold int `json:"sslexpirythreshold,omitempty"`
+
 	// OpsGenie holds information about the Opsgenie alert configuration
 	OpsGenie AlertChannelOpsGenie `json:"opsgenie,omitempty"`
 
 	// Email holds information about the Email alert configuration
 	Email checkly.AlertChannelEmail `json:"email,omitempty"`
+
+	// Slack holds information about the Slack alert configuration
+	Slack *AlertChannelSlack `json:"slack,omitempty"`
+
+	// SMS holds information about the SMS alert configuration
+	SMS *AlertChannelPhone `json:"sms,omitempty"`
+
+	// Call holds information about the phone call alert configuration
+	Call *AlertChannelPhone `json:"call,omitempty"`
+
+	// Webhook holds information about the webhook alert configuration
+	Webhook *AlertChannelWebhook `json:"webhook,omitempty"`
+
+	// Pagerduty holds information about the PagerDuty alert configuration
+	Pagerduty *AlertChannelPagerduty `json:"pagerduty,omitempty"`
+
+	// Telegram holds information about the Telegram alert configuration
+	Telegram *AlertChannelTelegram `json:"telegram,omitempty"`
+
+	// MSTeams holds information about the Microsoft Teams alert configuration
+	MSTeams *AlertChannelMSTeams `json:"msteams,omitempty"`
+
+	// IncidentIO holds information about the incident.io alert configuration
+	IncidentIO *AlertChannelIncidentIO `json:"incidentio,omitempty"`
+
+	// Adopt references an existing checklyhq.com alert channel which is managed by the resource instead of creating a new one
+	Adopt *AlertChannelAdoption `json:"adopt,omitempty"`
+
+	// Account is the name of the ChecklyAccount holding the credentials used for the alert channel, if empty the operator credentials are used
+	Account string `json:"account,omitempty"`
+
+	// DeletionPolicy determines if the Checkly alert channel is deleted together with the resource, default Delete
+	//+kubebuilder:validation:Enum=Delete;Retain
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
 }
 
 type AlertChannelOpsGenie struct {
@@ -51,14 +98,136 @@ type AlertChannelOpsGenie struct {
 	Priority string `json:"priority,omitempty"`
 }
 
+// AlertChannelSlack posts alerts to Slack through an incoming webhook
+type AlertChannelSlack struct {
+	// WebhookSecret determines where the secret ref is to pull the Slack incoming webhook URL from
+	WebhookSecret corev1.ObjectReference `json:"webhooksecret"`
+
+	// Channel is the Slack channel the alerts are posted to, ex. #alerts, defaults to the channel of the webhook
+	Channel string `json:"channel,omitempty"`
+}
+
+// AlertChannelPhone sends alerts to a phone number as text message or phone call
+type AlertChannelPhone struct {
+	// Number is the phone number in international format, ex. +31612345678
+	//+kubebuilder:validation:Pattern=`^\+[1-9][0-9]{6,14}$`
+	Number string `json:"number"`
+
+	// Name is the name of the recipient shown in checklyhq.com, defaults to the name of the resource
+	Name string `json:"name,omitempty"`
+}
+
+// AlertChannelWebhook sends alerts as HTTP requests, see https://www.checklyhq.com/docs/alerting-and-retries/webhooks/
+type AlertChannelWebhook struct {
+	// URL is called for every alert
+	URL string `json:"url"`
+
+	// Method is the HTTP method of the request, default POST
+	//+kubebuilder:validation:Enum=GET;POST;PUT;PATCH;HEAD;DELETE
+	Method string `json:"method,omitempty"`
+
+	// Headers are sent with the request
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// QueryParameters are added to the query string of the URL
+	QueryParameters map[string]string `json:"queryParameters,omitempty"`
+
+	// Template is the payload of the request, it can use the checklyhq.com variables, ex. {{ALERT_TITLE}}
+	Template string `json:"template,omitempty"`
+
+	// WebhookSecret determines where the secret ref is to pull the webhook secret from, checklyhq.com signs the requests with it
+	WebhookSecret *corev1.ObjectReference `json:"webhooksecret,omitempty"`
+
+	// WebhookType marks the webhook as one of the checklyhq.com integrations built on webhooks, ex. WEBHOOK_DISCORD or WEBHOOK_FIREHYDRANT
+	//+kubebuilder:validation:Pattern=`^WEBHOOK_[A-Z_]+$`
+	WebhookType string `json:"webhookType,omitempty"`
+}
+
+// AlertChannelPagerduty sends alerts to a PagerDuty service, see https://www.checklyhq.com/docs/integrations/pagerduty/
+type AlertChannelPagerduty struct {
+	// ServiceKeySecret determines where the secret ref is to pull the PagerDuty integration key of the service from
+	ServiceKeySecret corev1.ObjectReference `json:"servicekeysecret"`
+
+	// Account is the name of the PagerDuty account
+	Account string `json:"account,omitempty"`
+
+	// ServiceName is the name of the PagerDuty service
+	ServiceName string `json:"serviceName,omitempty"`
+}
+
+// AlertChannelTelegram posts alerts to a Telegram chat through a bot, see https://www.checklyhq.com/docs/integrations/telegram/
+type AlertChannelTelegram struct {
+	// BotTokenSecret determines where the secret ref is to pull the token of the Telegram bot from
+	BotTokenSecret corev1.ObjectReference `json:"bottokensecret"`
+
+	// ChatID is the ID of the chat the bot posts the alerts to
+	ChatID string `json:"chatId"`
+
+	// Message is the HTML formatted text of the alerts, it can use the checklyhq.com variables, ex. {{ALERT_TITLE}}
+	Message string `json:"message,omitempty"`
+}
+
+// AlertChannelMSTeams posts alerts to a Microsoft Teams channel through an incoming webhook, see https://www.checklyhq.com/docs/integrations/msteams/
+type AlertChannelMSTeams struct {
+	// WebhookSecret determines where the secret ref is to pull the Microsoft Teams incoming webhook URL from
+	WebhookSecret corev1.ObjectReference `json:"webhooksecret"`
+
+	// Template is the JSON payload of the alerts, it can use the checklyhq.com variables, ex. {{ALERT_TITLE}}, defaults to a message card
+	Template string `json:"template,omitempty"`
+}
+
+// AlertChannelIncidentIO sends alerts to an incident.io HTTP alert source, see https://www.checklyhq.com/docs/integrations/incidentio/
+type AlertChannelIncidentIO struct {
+	// URL is the URL of the HTTP alert source
+	URL string `json:"url"`
+
+	// APIKeySecret determines where the secret ref is to pull the API key of the alert source from
+	APIKeySecret corev1.ObjectReference `json:"apikeysecret"`
+
+	// Template is the JSON payload of the alerts, it can use the checklyhq.com variables, ex. {{ALERT_TITLE}}, defaults to an alert which resolves on recovery
+	Template string `json:"template,omitempty"`
+}
+
+// AlertChannelAdoption references an existing checklyhq.com alert channel, its subscriptions are kept
+type AlertChannelAdoption struct {
+	// ID is the ID of the existing checklyhq.com alert channel
+	//+kubebuilder:validation:Minimum=1
+	ID int64 `json:"id"`
+
+	// TakeOwnership deletes the alert channel together with the resource, by default an adopted alert channel is kept
+	TakeOwnership bool `json:"takeOwnership,omitempty"`
+}
+
 // AlertChannelStatus defines the observed state of AlertChannel
 type AlertChannelStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 	ID int64 `json:"id"`
+
+	// ChecklyURL links to the alert channel in the checklyhq.com app
+	ChecklyURL string `json:"checklyURL,omitempty"`
+
+	// ObservedGeneration is the generation of the spec last applied to checklyhq.com
+	//+optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastAppliedSpecHash is the hash of the spec last applied to checklyhq.com
+	//+optional
+	LastAppliedSpecHash string `json:"lastAppliedSpecHash,omitempty"`
+
+	// LastAppliedPayloadHash is the hash of the payload last pushed to checklyhq.com, unchanged payloads aren't pushed again
+	//+optional
+	LastAppliedPayloadHash string `json:"lastAppliedPayloadHash,omitempty"`
+
+	// Conditions hold the Ready, Synced and Degraded conditions of the alert channel
+	//+listType=map
+	//+listMapKey=type
+	//+optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 //+kubebuilder:object:root=true
+//+kubebuilder:storageversion
 //+kubebuilder:subresource:status
 //+kubebuilder:resource:scope=Cluster
 
@@ -0,0 +1,162 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// BrowserCheckSpec defines the desired state of BrowserCheck
+type BrowserCheckSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// Frequency is used to determine the frequency of the checks in minutes, defaults to the browser check defaults of the group or 10
+	Frequency int `json:"frequency,omitempty"`
+
+	// Muted determines if the created alert is muted or not, default false
+	Muted bool `json:"muted,omitempty"`
+
+	// Paused deactivates the check in checklyhq.com without deleting it, the history of the check is kept, default false
+	Paused bool `json:"paused,omitempty"`
+
+	// Locations determines where the check runs, if empty the locations of the group are used
+	Locations []string `json:"locations,omitempty"`
+
+	// RuntimeID pins the checklyhq.com runtime version the script runs with, ex. 2024.02, defaults to the browser check defaults or the runtime of the group, otherwise the account
+	RuntimeID string `json:"runtimeId,omitempty"`
+
+	// Script holds the inline Playwright script of the check
+	Script string `json:"script,omitempty"`
+
+	// ConfigMap references a key of a ConfigMap in the same namespace which holds the Playwright script, takes precedence over Script
+	ConfigMap *corev1.ConfigMapKeySelector `json:"configmap,omitempty"`
+
+	// EnvironmentVariables are available to the script through process.env, they replace the browser check defaults of the group with the same key
+	EnvironmentVariables []CheckEnvironmentVariable `json:"environmentVariables,omitempty"`
+
+	// RetryStrategy determines how failed runs are retried before alerting, defaults to the retry strategy of the group
+	RetryStrategy *RetryStrategy `json:"retryStrategy,omitempty"`
+
+	// Group determines in which group does the check belong to
+	Group string `json:"group"`
+
+	// Adopt references an existing checklyhq.com check which is managed by the resource instead of creating a new one
+	Adopt *CheckAdoption `json:"adopt,omitempty"`
+
+	// Account is the name of the ChecklyAccount holding the credentials used for the check, if empty the operator credentials are used
+	Account string `json:"account,omitempty"`
+
+	// DeletionPolicy determines if the Checkly check is deleted together with the resource, default Delete
+	//+kubebuilder:validation:Enum=Delete;Retain
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+}
+
+// CheckEnvironmentVariable is an environment variable of a browser or multi-step check
+type CheckEnvironmentVariable struct {
+	// Key is the name of the environment variable, ex. API_TOKEN
+	Key string `json:"key"`
+
+	// Value holds the plaintext value of the environment variable
+	Value string `json:"value,omitempty"`
+
+	// ValueFrom reads the value from a Secret or a ConfigMap in the namespace of the check, takes precedence over Value
+	ValueFrom *EnvironmentVariableSource `json:"valueFrom,omitempty"`
+
+	// Locked determines if the value is hidden in the checklyhq.com UI, values read from a Secret are always locked, default false
+	Locked bool `json:"locked,omitempty"`
+
+	// Secret determines if the value can never be read back from checklyhq.com, default false
+	Secret bool `json:"secret,omitempty"`
+}
+
+//+kubebuilder:validation:XValidation:rule="has(self.secretKeyRef) != has(self.configMapKeyRef)",message="exactly one of secretKeyRef or configMapKeyRef has to be set"
+
+// EnvironmentVariableSource is the source of an environment variable value
+type EnvironmentVariableSource struct {
+	// SecretKeyRef selects a key of a Secret
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+
+	// ConfigMapKeyRef selects a key of a ConfigMap
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+
+	// Namespace of the Secret or ConfigMap, required on the cluster scoped Group, checks always read from their own namespace
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// BrowserCheckStatus defines the observed state of BrowserCheck
+type BrowserCheckStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// ID holds the checklyhq.com internal ID of the check
+	ID string `json:"id"`
+
+	// ChecklyURL links to the check in the checklyhq.com app
+	ChecklyURL string `json:"checklyURL,omitempty"`
+
+	// Credentials is where the credentials the check is synced with come from, ChecklyAccount <name>,
+	// Secret <namespace>/checkly-credentials or empty for the credentials of the operator
+	//+optional
+	Credentials string `json:"credentials,omitempty"`
+
+	// GroupID holds the ID of the group where the check belongs to
+	GroupID int64 `json:"groupId"`
+
+	// Checkly is the state of the check as reported by checklyhq.com after the last sync
+	//+optional
+	Checkly *ChecklyState `json:"checkly,omitempty"`
+
+	// Conditions hold the Ready, Synced, Degraded and Stalled conditions of the check
+	//+listType=map
+	//+listMapKey=type
+	//+optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:storageversion
+//+kubebuilder:printcolumn:name="Muted",type="boolean",JSONPath=".spec.muted"
+//+kubebuilder:printcolumn:name="Group",type="string",JSONPath=".spec.group"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+//+kubebuilder:subresource:status
+
+// BrowserCheck is the Schema for the browserchecks API
+type BrowserCheck struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BrowserCheckSpec   `json:"spec,omitempty"`
+	Status BrowserCheckStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// BrowserCheckList contains a list of BrowserCheck
+type BrowserCheckList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BrowserCheck `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BrowserCheck{}, &BrowserCheckList{})
+}
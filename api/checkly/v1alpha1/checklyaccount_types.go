@@ -0,0 +1,76 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// ChecklyAccountSpec defines the desired state of ChecklyAccount
+type ChecklyAccountSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// SecretRef references the Secret which holds the CHECKLY_API_KEY and CHECKLY_ACCOUNT_ID keys
+	SecretRef corev1.SecretReference `json:"secretref"`
+
+	// URL is the base URL of the checklyhq.com API, default https://api.checklyhq.com
+	//+kubebuilder:default="https://api.checklyhq.com"
+	URL string `json:"url,omitempty"`
+
+	// AllowedNamespaces limits the namespaces of the resources which can use the account, resources from any namespace can use it if empty
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+}
+
+// ChecklyAccountStatus defines the observed state of ChecklyAccount
+type ChecklyAccountStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:storageversion
+//+kubebuilder:printcolumn:name="Secret",type="string",JSONPath=".spec.secretref.name"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+
+// ChecklyAccount is the Schema for the checklyaccounts API
+type ChecklyAccount struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ChecklyAccountSpec   `json:"spec,omitempty"`
+	Status ChecklyAccountStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ChecklyAccountList contains a list of ChecklyAccount
+type ChecklyAccountList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ChecklyAccount `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ChecklyAccount{}, &ChecklyAccountList{})
+}
@@ -0,0 +1,31 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// The condition types of the resources synced to checklyhq.com, see the status.conditions of
+// ApiChecks, Groups and AlertChannels
+const (
+	// ConditionReady is True when the resource exists in checklyhq.com as specified
+	ConditionReady = "Ready"
+	// ConditionSynced is True when the last reconcile applied the spec to checklyhq.com, the reason
+	// and message tell why it wasn't
+	ConditionSynced = "Synced"
+	// ConditionDegraded is True when the resource is synced but doesn't run or alert as usual, ex. a muted check
+	ConditionDegraded = "Degraded"
+	// ConditionStalled is True when the last reconciles kept failing, it's only present while the resource is stalled
+	ConditionStalled = "Stalled"
+)
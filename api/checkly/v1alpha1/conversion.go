@@ -0,0 +1,62 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// v1alpha1 is the storage version and the hub the other versions are converted to and from,
+// see https://book.kubebuilder.io/multiversion-tutorial/conversion-concepts
+
+// Hub marks this type as a conversion hub.
+func (*AlertChannel) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*AlertChannelSubscription) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*ApiCheck) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*BrowserCheck) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*ChecklyAccount) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*CheckTrigger) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*ClusterApiCheck) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*Dashboard) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*EnvironmentVariable) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*Group) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*HeartbeatCheck) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*MultiStepCheck) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*PrivateLocation) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*Snippet) Hub() {}
@@ -0,0 +1,90 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// EnvironmentVariableSpec defines the desired state of EnvironmentVariable
+type EnvironmentVariableSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// Key determines the name of the environment variable, ex. API_TOKEN
+	Key string `json:"key"`
+
+	// Value holds the plaintext value of the environment variable
+	Value string `json:"value,omitempty"`
+
+	// SecretKeyRef references the Secret which holds the value, FieldPath is the key inside the Secret, takes precedence over Value
+	SecretKeyRef corev1.ObjectReference `json:"secretKeyRef,omitempty"`
+
+	// Locked determines if the value is hidden in the checklyhq.com UI, default false
+	Locked bool `json:"locked,omitempty"`
+
+	// Secret determines if the value can never be read back from checklyhq.com, default false
+	Secret bool `json:"secret,omitempty"`
+
+	// DeletionPolicy determines if the Checkly environment variable is deleted together with the resource, default Delete
+	//+kubebuilder:validation:Enum=Delete;Retain
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+}
+
+// EnvironmentVariableStatus defines the observed state of EnvironmentVariable
+type EnvironmentVariableStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// Key holds the name of the environment variable created on checklyhq.com
+	Key string `json:"key"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:storageversion
+//+kubebuilder:printcolumn:name="Key",type="string",JSONPath=".spec.key"
+//+kubebuilder:printcolumn:name="Locked",type="boolean",JSONPath=".spec.locked"
+//+kubebuilder:printcolumn:name="Secret",type="boolean",JSONPath=".spec.secret"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+
+// EnvironmentVariable is the Schema for the environmentvariables API
+type EnvironmentVariable struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EnvironmentVariableSpec   `json:"spec,omitempty"`
+	Status EnvironmentVariableStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// EnvironmentVariableList contains a list of EnvironmentVariable
+type EnvironmentVariableList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EnvironmentVariable `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EnvironmentVariable{}, &EnvironmentVariableList{})
+}
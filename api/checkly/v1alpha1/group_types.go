@@ -31,11 +31,141 @@ type GroupSpec struct {
 	// Locations determines the locations where the checks are run from, see https://www.checklyhq.com/docs/monitoring/global-locations/ for a list, use AWS Region codes, ex. eu-west-1 for Ireland
 	Locations []string `json:"locations,omitempty"`
 
+	// PrivateLocations are names of PrivateLocation resources the checks in the group run on in addition to Locations
+	PrivateLocations []string `json:"privateLocations,omitempty"`
+
+	// PrivateLocationSlugs are slug names of private locations which aren't managed by the operator, the checks in the group run on them in addition to Locations
+	PrivateLocationSlugs []string `json:"privateLocationSlugs,omitempty"`
+
 	// Activated determines if the created group is muted or not, default false
 	Activated bool `json:"muted,omitempty"`
 
 	// AlertChannels determines where to send alerts
 	AlertChannels []string `json:"alertchannel,omitempty"`
+
+	// AlertChannelSubscriptions determines where to send alerts with an activation per alert channel, takes precedence over AlertChannels
+	AlertChannelSubscriptions []GroupAlertChannelSubscription `json:"alertChannelSubscriptions,omitempty"`
+
+	// AlertSettings determines when the alert channels are notified, defaults to an alert after 5 failed runs with a reminder every 5 minutes
+	AlertSettings *AlertSettings `json:"alertSettings,omitempty"`
+
+	// ApiCheckDefaults are applied by checklyhq.com to the ApiChecks in the group
+	ApiCheckDefaults *ApiCheckDefaults `json:"apiCheckDefaults,omitempty"`
+
+	// BrowserCheckDefaults are applied by the operator to the BrowserChecks in the group
+	BrowserCheckDefaults *BrowserCheckDefaults `json:"browserCheckDefaults,omitempty"`
+
+	// MaxResponseTime is the default maxresponsetime of the ApiChecks in the group
+	//+kubebuilder:validation:Maximum=30000
+	MaxResponseTime int `json:"maxresponsetime,omitempty"`
+
+	// DegradedResponseTime is the default degradedresponsetime of the ApiChecks in the group
+	//+kubebuilder:validation:Maximum=30000
+	DegradedResponseTime int `json:"degradedresponsetime,omitempty"`
+
+	// EnvironmentVariables are available to the checks in the group, the variables of a check take precedence
+	EnvironmentVariables []CheckEnvironmentVariable `json:"environmentVariables,omitempty"`
+
+	// RuntimeID pins the checklyhq.com runtime version of the checks in the group, ex. 2024.02, defaults to the account runtime
+	RuntimeID string `json:"runtimeId,omitempty"`
+
+	// Tags are added to the group in addition to the tags created from the labels
+	Tags []string `json:"tags,omitempty"`
+
+	// PropagateTags adds the tags of the group to the ApiChecks, BrowserChecks and MultiStepChecks in the group, default false
+	PropagateTags bool `json:"propagateTags,omitempty"`
+
+	// Concurrency is the number of checks in the group which run in parallel, default 2
+	//+kubebuilder:validation:Minimum=1
+	Concurrency int `json:"concurrency,omitempty"`
+
+	// RetryStrategy determines how failed runs of the checks in the group are retried before alerting
+	RetryStrategy *RetryStrategy `json:"retryStrategy,omitempty"`
+
+	// AllowedNamespaces limits the namespaces of the checks which can join the group, checks from any namespace can join if empty
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+
+	// Adopt references an existing checklyhq.com group which is managed by the resource instead of creating a new one
+	Adopt *GroupAdoption `json:"adopt,omitempty"`
+
+	// Account is the name of the ChecklyAccount holding the credentials used for the group, if empty the operator credentials are used
+	Account string `json:"account,omitempty"`
+
+	// DeletionPolicy determines if the Checkly group is deleted together with the resource, default Delete
+	//+kubebuilder:validation:Enum=Delete;Retain
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+}
+
+// GroupAdoption references an existing checklyhq.com group, the checks in it are kept
+type GroupAdoption struct {
+	// ID is the ID of the existing checklyhq.com group
+	//+kubebuilder:validation:Minimum=1
+	ID int64 `json:"id"`
+
+	// TakeOwnership deletes the group together with the resource, by default an adopted group is kept
+	TakeOwnership bool `json:"takeOwnership,omitempty"`
+}
+
+// GroupAlertChannelSubscription subscribes an alert channel to the group
+type GroupAlertChannelSubscription struct {
+	// AlertChannel is the name of the AlertChannel which subscribes to the group
+	AlertChannel string `json:"alertchannel"`
+
+	// Activated determines if alerts are sent to the alert channel, a deactivated alert channel is subscribed but silenced, default true
+	//+kubebuilder:default=true
+	Activated bool `json:"activated"`
+}
+
+// ApiCheckDefaults are shared by the ApiChecks in a group, see https://www.checklyhq.com/docs/groups/api-check-defaults/
+type ApiCheckDefaults struct {
+	// BaseURL is available to the ApiChecks in the group as {{GROUP_BASE_URL}}, ex. https://foo.bar
+	BaseURL string `json:"baseUrl,omitempty"`
+
+	// Headers are sent with the requests of the ApiChecks in the group
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// QueryParameters are added to the query string of the ApiChecks in the group
+	QueryParameters map[string]string `json:"queryParameters,omitempty"`
+
+	// Assertions are evaluated against the responses of the ApiChecks in the group
+	Assertions []Assertion `json:"assertions,omitempty"`
+}
+
+// BrowserCheckDefaults are shared by the BrowserChecks in a group, settings of a BrowserCheck take precedence
+type BrowserCheckDefaults struct {
+	// Frequency is the default frequency in minutes of the BrowserChecks in the group
+	//+kubebuilder:validation:Enum=1;2;5;10;15;30;60;120;180
+	Frequency int `json:"frequency,omitempty"`
+
+	// RuntimeID is the default runtime of the BrowserChecks in the group, ex. 2024.02, takes precedence over the runtime of the group
+	RuntimeID string `json:"runtimeId,omitempty"`
+
+	// EnvironmentVariables are available to the BrowserChecks in the group, a variable of a check with the same key takes precedence
+	EnvironmentVariables []CheckEnvironmentVariable `json:"environmentVariables,omitempty"`
+}
+
+// AlertSettings determines when alerts are sent, see https://www.checklyhq.com/docs/alerting-and-retries/alert-settings/
+type AlertSettings struct {
+	// EscalationType alerts after a number of failed runs with RUN_BASED or after a number of minutes failing with TIME_BASED, default RUN_BASED
+	//+kubebuilder:validation:Enum=RUN_BASED;TIME_BASED
+	EscalationType string `json:"escalationType,omitempty"`
+
+	// FailedRunThreshold is the number of failed runs before an alert is sent with RUN_BASED escalation, default 5
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=5
+	FailedRunThreshold int `json:"failedRunThreshold,omitempty"`
+
+	// MinutesFailingThreshold is the number of minutes failing before an alert is sent with TIME_BASED escalation, default 5
+	//+kubebuilder:validation:Enum=5;10;15;30
+	MinutesFailingThreshold int `json:"minutesFailingThreshold,omitempty"`
+
+	// ReminderAmount is the number of reminders sent while the check keeps failing, default 0
+	//+kubebuilder:validation:Minimum=0
+	ReminderAmount int `json:"reminderAmount,omitempty"`
+
+	// ReminderInterval is the number of minutes between the reminders, default 5
+	//+kubebuilder:validation:Enum=5;10;15;30
+	ReminderInterval int `json:"reminderInterval,omitempty"`
 }
 
 // GroupStatus defines the observed state of Group
@@ -45,9 +175,35 @@ type GroupStatus struct {
 
 	// ID holds the ID of the created checklyhq.com group
 	ID int64 `json:"ID"`
+
+	// ChecklyURL links to the group in the checklyhq.com app
+	ChecklyURL string `json:"checklyURL,omitempty"`
+
+	// ObservedGeneration is the generation of the spec last applied to checklyhq.com
+	//+optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastAppliedSpecHash is the hash of the spec last applied to checklyhq.com
+	//+optional
+	LastAppliedSpecHash string `json:"lastAppliedSpecHash,omitempty"`
+
+	// LastAppliedPayloadHash is the hash of the payload last pushed to checklyhq.com, unchanged payloads aren't pushed again
+	//+optional
+	LastAppliedPayloadHash string `json:"lastAppliedPayloadHash,omitempty"`
+
+	// Checkly is the state of the group as reported by checklyhq.com after the last sync
+	//+optional
+	Checkly *ChecklyState `json:"checkly,omitempty"`
+
+	// Conditions hold the Ready, Synced and Degraded conditions of the group
+	//+listType=map
+	//+listMapKey=type
+	//+optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 //+kubebuilder:object:root=true
+//+kubebuilder:storageversion
 //+kubebuilder:subresource:status
 //+kubebuilder:resource:scope=Cluster
 
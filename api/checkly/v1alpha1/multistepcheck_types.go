@@ -0,0 +1,131 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// MultiStepCheckSpec defines the desired state of MultiStepCheck
+type MultiStepCheckSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// Frequency is used to determine the frequency of the checks in minutes, default 10
+	Frequency int `json:"frequency,omitempty"`
+
+	// Muted determines if the created alert is muted or not, default false
+	Muted bool `json:"muted,omitempty"`
+
+	// Paused deactivates the check in checklyhq.com without deleting it, the history of the check is kept, default false
+	Paused bool `json:"paused,omitempty"`
+
+	// Locations determines where the check runs, if empty the locations of the group are used
+	Locations []string `json:"locations,omitempty"`
+
+	// Runtime determines the checklyhq.com runtime version the script runs with, ex. 2023.09, defaults to the runtime of the group or the account
+	Runtime string `json:"runtime,omitempty"`
+
+	// Script holds the inline Playwright script of the check
+	Script string `json:"script,omitempty"`
+
+	// ConfigMap references a key of a ConfigMap in the same namespace which holds the Playwright script, takes precedence over Script
+	ConfigMap *corev1.ConfigMapKeySelector `json:"configmap,omitempty"`
+
+	// EnvironmentVariables are available to the script through process.env
+	EnvironmentVariables []CheckEnvironmentVariable `json:"environmentVariables,omitempty"`
+
+	// RetryStrategy determines how failed runs are retried before alerting, defaults to the retry strategy of the group
+	RetryStrategy *RetryStrategy `json:"retryStrategy,omitempty"`
+
+	// Group determines in which group does the check belong to
+	Group string `json:"group"`
+
+	// Adopt references an existing checklyhq.com check which is managed by the resource instead of creating a new one
+	Adopt *CheckAdoption `json:"adopt,omitempty"`
+
+	// Account is the name of the ChecklyAccount holding the credentials used for the check, if empty the operator credentials are used
+	Account string `json:"account,omitempty"`
+
+	// DeletionPolicy determines if the Checkly check is deleted together with the resource, default Delete
+	//+kubebuilder:validation:Enum=Delete;Retain
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+}
+
+// MultiStepCheckStatus defines the observed state of MultiStepCheck
+type MultiStepCheckStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// ID holds the checklyhq.com internal ID of the check
+	ID string `json:"id"`
+
+	// ChecklyURL links to the check in the checklyhq.com app
+	ChecklyURL string `json:"checklyURL,omitempty"`
+
+	// Credentials is where the credentials the check is synced with come from, ChecklyAccount <name>,
+	// Secret <namespace>/checkly-credentials or empty for the credentials of the operator
+	//+optional
+	Credentials string `json:"credentials,omitempty"`
+
+	// GroupID holds the ID of the group where the check belongs to
+	GroupID int64 `json:"groupId"`
+
+	// Checkly is the state of the check as reported by checklyhq.com after the last sync
+	//+optional
+	Checkly *ChecklyState `json:"checkly,omitempty"`
+
+	// Conditions hold the Ready, Synced, Degraded and Stalled conditions of the check
+	//+listType=map
+	//+listMapKey=type
+	//+optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:storageversion
+//+kubebuilder:printcolumn:name="Runtime",type="string",JSONPath=".spec.runtime"
+//+kubebuilder:printcolumn:name="Muted",type="boolean",JSONPath=".spec.muted"
+//+kubebuilder:printcolumn:name="Group",type="string",JSONPath=".spec.group"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+//+kubebuilder:subresource:status
+
+// MultiStepCheck is the Schema for the multistepchecks API
+type MultiStepCheck struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MultiStepCheckSpec   `json:"spec,omitempty"`
+	Status MultiStepCheckStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// MultiStepCheckList contains a list of MultiStepCheck
+type MultiStepCheckList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MultiStepCheck `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MultiStepCheck{}, &MultiStepCheckList{})
+}
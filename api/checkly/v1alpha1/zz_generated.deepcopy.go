@@ -21,6 +21,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -29,8 +31,8 @@ func (in *AlertChannel) DeepCopyInto(out *AlertChannel) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertChannel.
@@ -51,6 +53,37 @@ func (in *AlertChannel) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertChannelAdoption) DeepCopyInto(out *AlertChannelAdoption) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertChannelAdoption.
+func (in *AlertChannelAdoption) DeepCopy() *AlertChannelAdoption {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertChannelAdoption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertChannelIncidentIO) DeepCopyInto(out *AlertChannelIncidentIO) {
+	*out = *in
+	out.APIKeySecret = in.APIKeySecret
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertChannelIncidentIO.
+func (in *AlertChannelIncidentIO) DeepCopy() *AlertChannelIncidentIO {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertChannelIncidentIO)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AlertChannelList) DeepCopyInto(out *AlertChannelList) {
 	*out = *in
@@ -83,6 +116,22 @@ func (in *AlertChannelList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertChannelMSTeams) DeepCopyInto(out *AlertChannelMSTeams) {
+	*out = *in
+	out.WebhookSecret = in.WebhookSecret
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertChannelMSTeams.
+func (in *AlertChannelMSTeams) DeepCopy() *AlertChannelMSTeams {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertChannelMSTeams)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AlertChannelOpsGenie) DeepCopyInto(out *AlertChannelOpsGenie) {
 	*out = *in
@@ -99,11 +148,103 @@ func (in *AlertChannelOpsGenie) DeepCopy() *AlertChannelOpsGenie {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertChannelPagerduty) DeepCopyInto(out *AlertChannelPagerduty) {
+	*out = *in
+	out.ServiceKeySecret = in.ServiceKeySecret
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertChannelPagerduty.
+func (in *AlertChannelPagerduty) DeepCopy() *AlertChannelPagerduty {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertChannelPagerduty)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertChannelPhone) DeepCopyInto(out *AlertChannelPhone) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertChannelPhone.
+func (in *AlertChannelPhone) DeepCopy() *AlertChannelPhone {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertChannelPhone)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertChannelSlack) DeepCopyInto(out *AlertChannelSlack) {
+	*out = *in
+	out.WebhookSecret = in.WebhookSecret
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertChannelSlack.
+func (in *AlertChannelSlack) DeepCopy() *AlertChannelSlack {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertChannelSlack)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AlertChannelSpec) DeepCopyInto(out *AlertChannelSpec) {
 	*out = *in
 	out.OpsGenie = in.OpsGenie
 	out.Email = in.Email
+	if in.Slack != nil {
+		in, out := &in.Slack, &out.Slack
+		*out = new(AlertChannelSlack)
+		**out = **in
+	}
+	if in.SMS != nil {
+		in, out := &in.SMS, &out.SMS
+		*out = new(AlertChannelPhone)
+		**out = **in
+	}
+	if in.Call != nil {
+		in, out := &in.Call, &out.Call
+		*out = new(AlertChannelPhone)
+		**out = **in
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(AlertChannelWebhook)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Pagerduty != nil {
+		in, out := &in.Pagerduty, &out.Pagerduty
+		*out = new(AlertChannelPagerduty)
+		**out = **in
+	}
+	if in.Telegram != nil {
+		in, out := &in.Telegram, &out.Telegram
+		*out = new(AlertChannelTelegram)
+		**out = **in
+	}
+	if in.MSTeams != nil {
+		in, out := &in.MSTeams, &out.MSTeams
+		*out = new(AlertChannelMSTeams)
+		**out = **in
+	}
+	if in.IncidentIO != nil {
+		in, out := &in.IncidentIO, &out.IncidentIO
+		*out = new(AlertChannelIncidentIO)
+		**out = **in
+	}
+	if in.Adopt != nil {
+		in, out := &in.Adopt, &out.Adopt
+		*out = new(AlertChannelAdoption)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertChannelSpec.
@@ -119,6 +260,13 @@ func (in *AlertChannelSpec) DeepCopy() *AlertChannelSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AlertChannelStatus) DeepCopyInto(out *AlertChannelStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertChannelStatus.
@@ -132,7 +280,7 @@ func (in *AlertChannelStatus) DeepCopy() *AlertChannelStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ApiCheck) DeepCopyInto(out *ApiCheck) {
+func (in *AlertChannelSubscription) DeepCopyInto(out *AlertChannelSubscription) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -140,18 +288,18 @@ func (in *ApiCheck) DeepCopyInto(out *ApiCheck) {
 	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApiCheck.
-func (in *ApiCheck) DeepCopy() *ApiCheck {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertChannelSubscription.
+func (in *AlertChannelSubscription) DeepCopy() *AlertChannelSubscription {
 	if in == nil {
 		return nil
 	}
-	out := new(ApiCheck)
+	out := new(AlertChannelSubscription)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ApiCheck) DeepCopyObject() runtime.Object {
+func (in *AlertChannelSubscription) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -159,31 +307,31 @@ func (in *ApiCheck) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ApiCheckList) DeepCopyInto(out *ApiCheckList) {
+func (in *AlertChannelSubscriptionList) DeepCopyInto(out *AlertChannelSubscriptionList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ApiCheck, len(*in))
+		*out = make([]AlertChannelSubscription, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApiCheckList.
-func (in *ApiCheckList) DeepCopy() *ApiCheckList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertChannelSubscriptionList.
+func (in *AlertChannelSubscriptionList) DeepCopy() *AlertChannelSubscriptionList {
 	if in == nil {
 		return nil
 	}
-	out := new(ApiCheckList)
+	out := new(AlertChannelSubscriptionList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ApiCheckList) DeepCopyObject() runtime.Object {
+func (in *AlertChannelSubscriptionList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -191,56 +339,121 @@ func (in *ApiCheckList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ApiCheckSpec) DeepCopyInto(out *ApiCheckSpec) {
+func (in *AlertChannelSubscriptionSpec) DeepCopyInto(out *AlertChannelSubscriptionSpec) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApiCheckSpec.
-func (in *ApiCheckSpec) DeepCopy() *ApiCheckSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertChannelSubscriptionSpec.
+func (in *AlertChannelSubscriptionSpec) DeepCopy() *AlertChannelSubscriptionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ApiCheckSpec)
+	out := new(AlertChannelSubscriptionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ApiCheckStatus) DeepCopyInto(out *ApiCheckStatus) {
+func (in *AlertChannelSubscriptionStatus) DeepCopyInto(out *AlertChannelSubscriptionStatus) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApiCheckStatus.
-func (in *ApiCheckStatus) DeepCopy() *ApiCheckStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertChannelSubscriptionStatus.
+func (in *AlertChannelSubscriptionStatus) DeepCopy() *AlertChannelSubscriptionStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ApiCheckStatus)
+	out := new(AlertChannelSubscriptionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Group) DeepCopyInto(out *Group) {
+func (in *AlertChannelTelegram) DeepCopyInto(out *AlertChannelTelegram) {
+	*out = *in
+	out.BotTokenSecret = in.BotTokenSecret
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertChannelTelegram.
+func (in *AlertChannelTelegram) DeepCopy() *AlertChannelTelegram {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertChannelTelegram)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertChannelWebhook) DeepCopyInto(out *AlertChannelWebhook) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.QueryParameters != nil {
+		in, out := &in.QueryParameters, &out.QueryParameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.WebhookSecret != nil {
+		in, out := &in.WebhookSecret, &out.WebhookSecret
+		*out = new(v1.ObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertChannelWebhook.
+func (in *AlertChannelWebhook) DeepCopy() *AlertChannelWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertChannelWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertSettings) DeepCopyInto(out *AlertSettings) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertSettings.
+func (in *AlertSettings) DeepCopy() *AlertSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApiCheck) DeepCopyInto(out *ApiCheck) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Group.
-func (in *Group) DeepCopy() *Group {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApiCheck.
+func (in *ApiCheck) DeepCopy() *ApiCheck {
 	if in == nil {
 		return nil
 	}
-	out := new(Group)
+	out := new(ApiCheck)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Group) DeepCopyObject() runtime.Object {
+func (in *ApiCheck) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -248,31 +461,65 @@ func (in *Group) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GroupList) DeepCopyInto(out *GroupList) {
+func (in *ApiCheckDefaults) DeepCopyInto(out *ApiCheckDefaults) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.QueryParameters != nil {
+		in, out := &in.QueryParameters, &out.QueryParameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Assertions != nil {
+		in, out := &in.Assertions, &out.Assertions
+		*out = make([]Assertion, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApiCheckDefaults.
+func (in *ApiCheckDefaults) DeepCopy() *ApiCheckDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(ApiCheckDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApiCheckList) DeepCopyInto(out *ApiCheckList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Group, len(*in))
+		*out = make([]ApiCheck, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupList.
-func (in *GroupList) DeepCopy() *GroupList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApiCheckList.
+func (in *ApiCheckList) DeepCopy() *ApiCheckList {
 	if in == nil {
 		return nil
 	}
-	out := new(GroupList)
+	out := new(ApiCheckList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GroupList) DeepCopyObject() runtime.Object {
+func (in *ApiCheckList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -280,41 +527,1519 @@ func (in *GroupList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GroupSpec) DeepCopyInto(out *GroupSpec) {
+func (in *ApiCheckSpec) DeepCopyInto(out *ApiCheckSpec) {
 	*out = *in
+	if in.Frequency != nil {
+		in, out := &in.Frequency, &out.Frequency
+		*out = new(int)
+		**out = **in
+	}
+	if in.ShouldFail != nil {
+		in, out := &in.ShouldFail, &out.ShouldFail
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Locations != nil {
 		in, out := &in.Locations, &out.Locations
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PrivateLocations != nil {
+		in, out := &in.PrivateLocations, &out.PrivateLocations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]HTTPHeader, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BasicAuth != nil {
+		in, out := &in.BasicAuth, &out.BasicAuth
+		*out = new(BasicAuth)
+		**out = **in
+	}
+	if in.BearerTokenSecretRef != nil {
+		in, out := &in.BearerTokenSecretRef, &out.BearerTokenSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.QueryParameters != nil {
+		in, out := &in.QueryParameters, &out.QueryParameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Assertions != nil {
+		in, out := &in.Assertions, &out.Assertions
+		*out = make([]Assertion, len(*in))
+		copy(*out, *in)
+	}
+	if in.SetupScript != nil {
+		in, out := &in.SetupScript, &out.SetupScript
+		*out = new(Script)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TeardownScript != nil {
+		in, out := &in.TeardownScript, &out.TeardownScript
+		*out = new(Script)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RetryStrategy != nil {
+		in, out := &in.RetryStrategy, &out.RetryStrategy
+		*out = new(RetryStrategy)
+		**out = **in
+	}
 	if in.AlertChannels != nil {
 		in, out := &in.AlertChannels, &out.AlertChannels
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Adopt != nil {
+		in, out := &in.Adopt, &out.Adopt
+		*out = new(CheckAdoption)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupSpec.
-func (in *GroupSpec) DeepCopy() *GroupSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApiCheckSpec.
+func (in *ApiCheckSpec) DeepCopy() *ApiCheckSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GroupSpec)
+	out := new(ApiCheckSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GroupStatus) DeepCopyInto(out *GroupStatus) {
+func (in *ApiCheckStatus) DeepCopyInto(out *ApiCheckStatus) {
 	*out = *in
+	if in.AlertChannelIDs != nil {
+		in, out := &in.AlertChannelIDs, &out.AlertChannelIDs
+		*out = make([]int64, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Checkly != nil {
+		in, out := &in.Checkly, &out.Checkly
+		*out = new(ChecklyState)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupStatus.
-func (in *GroupStatus) DeepCopy() *GroupStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApiCheckStatus.
+func (in *ApiCheckStatus) DeepCopy() *ApiCheckStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(GroupStatus)
+	out := new(ApiCheckStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Assertion) DeepCopyInto(out *Assertion) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Assertion.
+func (in *Assertion) DeepCopy() *Assertion {
+	if in == nil {
+		return nil
+	}
+	out := new(Assertion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BasicAuth) DeepCopyInto(out *BasicAuth) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BasicAuth.
+func (in *BasicAuth) DeepCopy() *BasicAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(BasicAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrowserCheck) DeepCopyInto(out *BrowserCheck) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrowserCheck.
+func (in *BrowserCheck) DeepCopy() *BrowserCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(BrowserCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BrowserCheck) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrowserCheckDefaults) DeepCopyInto(out *BrowserCheckDefaults) {
+	*out = *in
+	if in.EnvironmentVariables != nil {
+		in, out := &in.EnvironmentVariables, &out.EnvironmentVariables
+		*out = make([]CheckEnvironmentVariable, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrowserCheckDefaults.
+func (in *BrowserCheckDefaults) DeepCopy() *BrowserCheckDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(BrowserCheckDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrowserCheckList) DeepCopyInto(out *BrowserCheckList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BrowserCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrowserCheckList.
+func (in *BrowserCheckList) DeepCopy() *BrowserCheckList {
+	if in == nil {
+		return nil
+	}
+	out := new(BrowserCheckList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BrowserCheckList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrowserCheckSpec) DeepCopyInto(out *BrowserCheckSpec) {
+	*out = *in
+	if in.Locations != nil {
+		in, out := &in.Locations, &out.Locations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EnvironmentVariables != nil {
+		in, out := &in.EnvironmentVariables, &out.EnvironmentVariables
+		*out = make([]CheckEnvironmentVariable, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RetryStrategy != nil {
+		in, out := &in.RetryStrategy, &out.RetryStrategy
+		*out = new(RetryStrategy)
+		**out = **in
+	}
+	if in.Adopt != nil {
+		in, out := &in.Adopt, &out.Adopt
+		*out = new(CheckAdoption)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrowserCheckSpec.
+func (in *BrowserCheckSpec) DeepCopy() *BrowserCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BrowserCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrowserCheckStatus) DeepCopyInto(out *BrowserCheckStatus) {
+	*out = *in
+	if in.Checkly != nil {
+		in, out := &in.Checkly, &out.Checkly
+		*out = new(ChecklyState)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrowserCheckStatus.
+func (in *BrowserCheckStatus) DeepCopy() *BrowserCheckStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BrowserCheckStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckAdoption) DeepCopyInto(out *CheckAdoption) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckAdoption.
+func (in *CheckAdoption) DeepCopy() *CheckAdoption {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckAdoption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckEnvironmentVariable) DeepCopyInto(out *CheckEnvironmentVariable) {
+	*out = *in
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(EnvironmentVariableSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckEnvironmentVariable.
+func (in *CheckEnvironmentVariable) DeepCopy() *CheckEnvironmentVariable {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckEnvironmentVariable)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckTrigger) DeepCopyInto(out *CheckTrigger) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckTrigger.
+func (in *CheckTrigger) DeepCopy() *CheckTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CheckTrigger) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckTriggerList) DeepCopyInto(out *CheckTriggerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CheckTrigger, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckTriggerList.
+func (in *CheckTriggerList) DeepCopy() *CheckTriggerList {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckTriggerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CheckTriggerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckTriggerSpec) DeepCopyInto(out *CheckTriggerSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckTriggerSpec.
+func (in *CheckTriggerSpec) DeepCopy() *CheckTriggerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckTriggerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckTriggerStatus) DeepCopyInto(out *CheckTriggerStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckTriggerStatus.
+func (in *CheckTriggerStatus) DeepCopy() *CheckTriggerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckTriggerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChecklyAccount) DeepCopyInto(out *ChecklyAccount) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChecklyAccount.
+func (in *ChecklyAccount) DeepCopy() *ChecklyAccount {
+	if in == nil {
+		return nil
+	}
+	out := new(ChecklyAccount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ChecklyAccount) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChecklyAccountList) DeepCopyInto(out *ChecklyAccountList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ChecklyAccount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChecklyAccountList.
+func (in *ChecklyAccountList) DeepCopy() *ChecklyAccountList {
+	if in == nil {
+		return nil
+	}
+	out := new(ChecklyAccountList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ChecklyAccountList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChecklyAccountSpec) DeepCopyInto(out *ChecklyAccountSpec) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChecklyAccountSpec.
+func (in *ChecklyAccountSpec) DeepCopy() *ChecklyAccountSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ChecklyAccountSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChecklyAccountStatus) DeepCopyInto(out *ChecklyAccountStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChecklyAccountStatus.
+func (in *ChecklyAccountStatus) DeepCopy() *ChecklyAccountStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ChecklyAccountStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChecklyState) DeepCopyInto(out *ChecklyState) {
+	*out = *in
+	if in.Locations != nil {
+		in, out := &in.Locations, &out.Locations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastUpdatedAt != nil {
+		in, out := &in.LastUpdatedAt, &out.LastUpdatedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChecklyState.
+func (in *ChecklyState) DeepCopy() *ChecklyState {
+	if in == nil {
+		return nil
+	}
+	out := new(ChecklyState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterApiCheck) DeepCopyInto(out *ClusterApiCheck) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterApiCheck.
+func (in *ClusterApiCheck) DeepCopy() *ClusterApiCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterApiCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterApiCheck) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterApiCheckList) DeepCopyInto(out *ClusterApiCheckList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterApiCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterApiCheckList.
+func (in *ClusterApiCheckList) DeepCopy() *ClusterApiCheckList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterApiCheckList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterApiCheckList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Dashboard) DeepCopyInto(out *Dashboard) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Dashboard.
+func (in *Dashboard) DeepCopy() *Dashboard {
+	if in == nil {
+		return nil
+	}
+	out := new(Dashboard)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Dashboard) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardList) DeepCopyInto(out *DashboardList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Dashboard, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardList.
+func (in *DashboardList) DeepCopy() *DashboardList {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DashboardList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardSpec) DeepCopyInto(out *DashboardSpec) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardSpec.
+func (in *DashboardSpec) DeepCopy() *DashboardSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardStatus) DeepCopyInto(out *DashboardStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardStatus.
+func (in *DashboardStatus) DeepCopy() *DashboardStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentVariable) DeepCopyInto(out *EnvironmentVariable) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentVariable.
+func (in *EnvironmentVariable) DeepCopy() *EnvironmentVariable {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentVariable)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EnvironmentVariable) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentVariableList) DeepCopyInto(out *EnvironmentVariableList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EnvironmentVariable, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentVariableList.
+func (in *EnvironmentVariableList) DeepCopy() *EnvironmentVariableList {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentVariableList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EnvironmentVariableList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentVariableSource) DeepCopyInto(out *EnvironmentVariableSource) {
+	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentVariableSource.
+func (in *EnvironmentVariableSource) DeepCopy() *EnvironmentVariableSource {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentVariableSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentVariableSpec) DeepCopyInto(out *EnvironmentVariableSpec) {
+	*out = *in
+	out.SecretKeyRef = in.SecretKeyRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentVariableSpec.
+func (in *EnvironmentVariableSpec) DeepCopy() *EnvironmentVariableSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentVariableSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentVariableStatus) DeepCopyInto(out *EnvironmentVariableStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentVariableStatus.
+func (in *EnvironmentVariableStatus) DeepCopy() *EnvironmentVariableStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentVariableStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Group) DeepCopyInto(out *Group) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Group.
+func (in *Group) DeepCopy() *Group {
+	if in == nil {
+		return nil
+	}
+	out := new(Group)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Group) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupAdoption) DeepCopyInto(out *GroupAdoption) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupAdoption.
+func (in *GroupAdoption) DeepCopy() *GroupAdoption {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupAdoption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupAlertChannelSubscription) DeepCopyInto(out *GroupAlertChannelSubscription) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupAlertChannelSubscription.
+func (in *GroupAlertChannelSubscription) DeepCopy() *GroupAlertChannelSubscription {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupAlertChannelSubscription)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupList) DeepCopyInto(out *GroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Group, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupList.
+func (in *GroupList) DeepCopy() *GroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupSpec) DeepCopyInto(out *GroupSpec) {
+	*out = *in
+	if in.Locations != nil {
+		in, out := &in.Locations, &out.Locations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PrivateLocations != nil {
+		in, out := &in.PrivateLocations, &out.PrivateLocations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PrivateLocationSlugs != nil {
+		in, out := &in.PrivateLocationSlugs, &out.PrivateLocationSlugs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AlertChannels != nil {
+		in, out := &in.AlertChannels, &out.AlertChannels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AlertChannelSubscriptions != nil {
+		in, out := &in.AlertChannelSubscriptions, &out.AlertChannelSubscriptions
+		*out = make([]GroupAlertChannelSubscription, len(*in))
+		copy(*out, *in)
+	}
+	if in.AlertSettings != nil {
+		in, out := &in.AlertSettings, &out.AlertSettings
+		*out = new(AlertSettings)
+		**out = **in
+	}
+	if in.ApiCheckDefaults != nil {
+		in, out := &in.ApiCheckDefaults, &out.ApiCheckDefaults
+		*out = new(ApiCheckDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BrowserCheckDefaults != nil {
+		in, out := &in.BrowserCheckDefaults, &out.BrowserCheckDefaults
+		*out = new(BrowserCheckDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EnvironmentVariables != nil {
+		in, out := &in.EnvironmentVariables, &out.EnvironmentVariables
+		*out = make([]CheckEnvironmentVariable, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RetryStrategy != nil {
+		in, out := &in.RetryStrategy, &out.RetryStrategy
+		*out = new(RetryStrategy)
+		**out = **in
+	}
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Adopt != nil {
+		in, out := &in.Adopt, &out.Adopt
+		*out = new(GroupAdoption)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupSpec.
+func (in *GroupSpec) DeepCopy() *GroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupStatus) DeepCopyInto(out *GroupStatus) {
+	*out = *in
+	if in.Checkly != nil {
+		in, out := &in.Checkly, &out.Checkly
+		*out = new(ChecklyState)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupStatus.
+func (in *GroupStatus) DeepCopy() *GroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPHeader) DeepCopyInto(out *HTTPHeader) {
+	*out = *in
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(HeaderValueSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPHeader.
+func (in *HTTPHeader) DeepCopy() *HTTPHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeaderValueSource) DeepCopyInto(out *HeaderValueSource) {
+	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeaderValueSource.
+func (in *HeaderValueSource) DeepCopy() *HeaderValueSource {
+	if in == nil {
+		return nil
+	}
+	out := new(HeaderValueSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeartbeatCheck) DeepCopyInto(out *HeartbeatCheck) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeartbeatCheck.
+func (in *HeartbeatCheck) DeepCopy() *HeartbeatCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(HeartbeatCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HeartbeatCheck) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeartbeatCheckList) DeepCopyInto(out *HeartbeatCheckList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HeartbeatCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeartbeatCheckList.
+func (in *HeartbeatCheckList) DeepCopy() *HeartbeatCheckList {
+	if in == nil {
+		return nil
+	}
+	out := new(HeartbeatCheckList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HeartbeatCheckList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeartbeatCheckSpec) DeepCopyInto(out *HeartbeatCheckSpec) {
+	*out = *in
+	if in.AlertChannels != nil {
+		in, out := &in.AlertChannels, &out.AlertChannels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Adopt != nil {
+		in, out := &in.Adopt, &out.Adopt
+		*out = new(CheckAdoption)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeartbeatCheckSpec.
+func (in *HeartbeatCheckSpec) DeepCopy() *HeartbeatCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HeartbeatCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeartbeatCheckStatus) DeepCopyInto(out *HeartbeatCheckStatus) {
+	*out = *in
+	if in.Checkly != nil {
+		in, out := &in.Checkly, &out.Checkly
+		*out = new(ChecklyState)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeartbeatCheckStatus.
+func (in *HeartbeatCheckStatus) DeepCopy() *HeartbeatCheckStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HeartbeatCheckStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiStepCheck) DeepCopyInto(out *MultiStepCheck) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiStepCheck.
+func (in *MultiStepCheck) DeepCopy() *MultiStepCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiStepCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MultiStepCheck) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiStepCheckList) DeepCopyInto(out *MultiStepCheckList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MultiStepCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiStepCheckList.
+func (in *MultiStepCheckList) DeepCopy() *MultiStepCheckList {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiStepCheckList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MultiStepCheckList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiStepCheckSpec) DeepCopyInto(out *MultiStepCheckSpec) {
+	*out = *in
+	if in.Locations != nil {
+		in, out := &in.Locations, &out.Locations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EnvironmentVariables != nil {
+		in, out := &in.EnvironmentVariables, &out.EnvironmentVariables
+		*out = make([]CheckEnvironmentVariable, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RetryStrategy != nil {
+		in, out := &in.RetryStrategy, &out.RetryStrategy
+		*out = new(RetryStrategy)
+		**out = **in
+	}
+	if in.Adopt != nil {
+		in, out := &in.Adopt, &out.Adopt
+		*out = new(CheckAdoption)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiStepCheckSpec.
+func (in *MultiStepCheckSpec) DeepCopy() *MultiStepCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiStepCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiStepCheckStatus) DeepCopyInto(out *MultiStepCheckStatus) {
+	*out = *in
+	if in.Checkly != nil {
+		in, out := &in.Checkly, &out.Checkly
+		*out = new(ChecklyState)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiStepCheckStatus.
+func (in *MultiStepCheckStatus) DeepCopy() *MultiStepCheckStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiStepCheckStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateLocation) DeepCopyInto(out *PrivateLocation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateLocation.
+func (in *PrivateLocation) DeepCopy() *PrivateLocation {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateLocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PrivateLocation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateLocationList) DeepCopyInto(out *PrivateLocationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PrivateLocation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateLocationList.
+func (in *PrivateLocationList) DeepCopy() *PrivateLocationList {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateLocationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PrivateLocationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateLocationSpec) DeepCopyInto(out *PrivateLocationSpec) {
+	*out = *in
+	if in.KeySecret != nil {
+		in, out := &in.KeySecret, &out.KeySecret
+		*out = new(v1.SecretReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateLocationSpec.
+func (in *PrivateLocationSpec) DeepCopy() *PrivateLocationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateLocationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateLocationStatus) DeepCopyInto(out *PrivateLocationStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateLocationStatus.
+func (in *PrivateLocationStatus) DeepCopy() *PrivateLocationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateLocationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryStrategy) DeepCopyInto(out *RetryStrategy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryStrategy.
+func (in *RetryStrategy) DeepCopy() *RetryStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Script) DeepCopyInto(out *Script) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Script.
+func (in *Script) DeepCopy() *Script {
+	if in == nil {
+		return nil
+	}
+	out := new(Script)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Snippet) DeepCopyInto(out *Snippet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Snippet.
+func (in *Snippet) DeepCopy() *Snippet {
+	if in == nil {
+		return nil
+	}
+	out := new(Snippet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Snippet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnippetList) DeepCopyInto(out *SnippetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Snippet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnippetList.
+func (in *SnippetList) DeepCopy() *SnippetList {
+	if in == nil {
+		return nil
+	}
+	out := new(SnippetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SnippetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnippetSpec) DeepCopyInto(out *SnippetSpec) {
+	*out = *in
+	out.ConfigMap = in.ConfigMap
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnippetSpec.
+func (in *SnippetSpec) DeepCopy() *SnippetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SnippetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnippetStatus) DeepCopyInto(out *SnippetStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnippetStatus.
+func (in *SnippetStatus) DeepCopy() *SnippetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SnippetStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -0,0 +1,85 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// AlertChannelSubscriptionSpec defines the desired state of AlertChannelSubscription
+type AlertChannelSubscriptionSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// AlertChannel is the name of the AlertChannel which subscribes to the group or check
+	AlertChannel string `json:"alertchannel"`
+
+	// Group is the name of the group the alert channel subscribes to
+	Group string `json:"group,omitempty"`
+
+	// Check is the name of the check in the same namespace the alert channel subscribes to, ignored if Group is set
+	Check string `json:"check,omitempty"`
+
+	// CheckKind determines the kind of the referenced check, default HeartbeatCheck
+	//+kubebuilder:validation:Enum=HeartbeatCheck;ApiCheck
+	//+kubebuilder:default=HeartbeatCheck
+	CheckKind string `json:"checkkind,omitempty"`
+
+	// Activated determines if alerts are sent to the alert channel, default true
+	//+kubebuilder:default=true
+	Activated bool `json:"activated"`
+}
+
+// AlertChannelSubscriptionStatus defines the observed state of AlertChannelSubscription
+type AlertChannelSubscriptionStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:unservedversion
+//+kubebuilder:printcolumn:name="AlertChannel",type="string",JSONPath=".spec.alertchannel"
+//+kubebuilder:printcolumn:name="Group",type="string",JSONPath=".spec.group"
+//+kubebuilder:printcolumn:name="Check",type="string",JSONPath=".spec.check"
+//+kubebuilder:printcolumn:name="Activated",type="boolean",JSONPath=".spec.activated"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+//+kubebuilder:subresource:status
+
+// AlertChannelSubscription is the Schema for the alertchannelsubscriptions API
+type AlertChannelSubscription struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AlertChannelSubscriptionSpec   `json:"spec,omitempty"`
+	Status AlertChannelSubscriptionStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// AlertChannelSubscriptionList contains a list of AlertChannelSubscription
+type AlertChannelSubscriptionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AlertChannelSubscription `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AlertChannelSubscription{}, &AlertChannelSubscriptionList{})
+}
@@ -0,0 +1,359 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+const (
+	// DeletionPolicyDelete deletes the Checkly object together with the resource
+	DeletionPolicyDelete = "Delete"
+
+	// DeletionPolicyRetain keeps the Checkly object when the resource is deleted
+	DeletionPolicyRetain = "Retain"
+)
+
+//+kubebuilder:validation:XValidation:rule="!has(self.degradedresponsetime) || !has(self.maxresponsetime) || duration(self.degradedresponsetime) <= duration(self.maxresponsetime)",message="degradedresponsetime can't be higher than maxresponsetime"
+//+kubebuilder:validation:XValidation:rule="!has(self.frequencyOffset) || !has(self.frequency) || duration(self.frequency) >= duration('1m')",message="frequencyOffset can't be set for frequencies below 1m"
+//+kubebuilder:validation:XValidation:rule="!has(self.frequencyOffset) || (has(self.frequency) ? (duration(self.frequency) < duration('1m') || self.frequencyOffset <= (duration(self.frequency).getMinutes() <= 60 ? duration(self.frequency).getMinutes() * 10 : (duration(self.frequency).getMinutes() + 59) / 60)) : self.frequencyOffset <= 50)",message="frequencyOffset can be at most frequency in minutes * 10, or frequency in hours rounded up for frequencies above 1h, without a frequency it's 5m"
+
+// ApiCheckSpec defines the desired state of ApiCheck
+type ApiCheckSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// Frequency is the time between the runs of the check, ex. 30s or 1h, default 5m or the default frequency of the operator
+	//+kubebuilder:validation:XValidation:rule="duration(self) in [duration('10s'), duration('20s'), duration('30s'), duration('1m'), duration('2m'), duration('5m'), duration('10m'), duration('15m'), duration('30m'), duration('1h'), duration('2h'), duration('3h'), duration('6h'), duration('12h'), duration('24h')]",message="frequency has to be one of 10s, 20s, 30s, 1m, 2m, 5m, 10m, 15m, 30m, 1h, 2h, 3h, 6h, 12h or 24h"
+	Frequency *metav1.Duration `json:"frequency,omitempty"`
+
+	// FrequencyOffset spreads the runs of checks with the same frequency in minutes, only for frequencies of 1m or more
+	//+kubebuilder:validation:Minimum=1
+	FrequencyOffset int `json:"frequencyOffset,omitempty"`
+
+	// Muted determines if the created alert is muted or not, default false
+	Muted bool `json:"muted,omitempty"`
+
+	// Paused deactivates the check in checklyhq.com without deleting it, the history of the check is kept, default false
+	Paused bool `json:"paused,omitempty"`
+
+	// Endpoint determines which URL to monitor, ex. https://foo.bar/baz
+	Endpoint string `json:"endpoint"`
+
+	// Success determines the returned success code, ex. 200
+	Success string `json:"success"`
+
+	// ShouldFail inverts the check, responses with a 4xx or 5xx status code pass, ex. for endpoints behind authentication, defaults to true if success is 400 or higher
+	ShouldFail *bool `json:"shouldFail,omitempty"`
+
+	// Method is the HTTP method of the request, default GET
+	Method HTTPMethod `json:"method,omitempty"`
+
+	// Body is sent with the request, ex. a JSON document or a GraphQL query
+	Body string `json:"body,omitempty"`
+
+	// BodyType determines how the body is encoded, default NONE without a body and RAW with one
+	//+kubebuilder:validation:Enum=NONE;JSON;FORM;RAW;GRAPHQL
+	BodyType string `json:"bodyType,omitempty"`
+
+	// IPFamily determines the IP version used to reach the endpoint, default IPv4
+	//+kubebuilder:validation:Enum=IPv4;IPv6
+	IPFamily string `json:"ipFamily,omitempty"`
+
+	// FollowRedirects follows the redirects of the endpoint and asserts on the final response, default false so the redirect itself is asserted
+	FollowRedirects bool `json:"followRedirects,omitempty"`
+
+	// SkipSSL skips the verification of the TLS certificate of the endpoint, ex. for self-signed certificates, default false
+	SkipSSL bool `json:"skipSsl,omitempty"`
+
+	// MaxResponseTime is the response time after which the check fails, ex. 10s, at most 30s, default is the value of the group or 15s
+	//+kubebuilder:validation:XValidation:rule="duration(self) <= duration('30s')",message="maxresponsetime can be at most 30s"
+	//+kubebuilder:validation:XValidation:rule="duration(self) == duration(string(duration(self).getMilliseconds()) + 'ms')",message="maxresponsetime has to be a whole number of milliseconds"
+	MaxResponseTime *metav1.Duration `json:"maxresponsetime,omitempty"`
+
+	// DegradedResponseTime is the response time after which the check is marked as degraded, ex. 2500ms, at most 30s, default is the value of the group or 5s
+	//+kubebuilder:validation:XValidation:rule="duration(self) <= duration('30s')",message="degradedresponsetime can be at most 30s"
+	//+kubebuilder:validation:XValidation:rule="duration(self) == duration(string(duration(self).getMilliseconds()) + 'ms')",message="degradedresponsetime has to be a whole number of milliseconds"
+	DegradedResponseTime *metav1.Duration `json:"degradedresponsetime,omitempty"`
+
+	// Locations determines where the check runs, ex. eu-west-1, if empty the locations of the group are used
+	Locations []Location `json:"locations,omitempty"`
+
+	// PrivateLocations are names of PrivateLocation resources the check runs on in addition to Locations
+	PrivateLocations []string `json:"privateLocations,omitempty"`
+
+	// SSLCertificateExpiry determines how many days before the SSL certificate of the endpoint expires an alert is sent, disabled if empty
+	//+kubebuilder:validation:Enum=3;7;14;30
+	SSLCertificateExpiry int `json:"sslcertificateexpiry,omitempty"`
+
+	// Headers are sent with the request of the check
+	Headers []HTTPHeader `json:"headers,omitempty"`
+
+	// BasicAuth sets the username and password of the request from a Secret
+	BasicAuth *BasicAuth `json:"basicAuth,omitempty"`
+
+	// BearerTokenSecretRef selects a key of a Secret in the namespace of the check holding a token,
+	// sent in the Authorization header of the request
+	BearerTokenSecretRef *corev1.SecretKeySelector `json:"bearerTokenSecretRef,omitempty"`
+
+	// QueryParameters are added to the query string of the endpoint
+	QueryParameters map[string]string `json:"queryParameters,omitempty"`
+
+	// Assertions are evaluated against the response in addition to the success code
+	Assertions []Assertion `json:"assertions,omitempty"`
+
+	// SetupScript runs before the request, ex. to fetch a token
+	SetupScript *Script `json:"setupScript,omitempty"`
+
+	// TeardownScript runs after the request, ex. to clean up test data
+	TeardownScript *Script `json:"teardownScript,omitempty"`
+
+	// RuntimeID pins the checklyhq.com runtime version of the setup and teardown scripts, ex. 2024.02, defaults to the runtime of the group or the account
+	RuntimeID string `json:"runtimeId,omitempty"`
+
+	// Tags are added to the check in addition to the tags created from the labels
+	Tags []string `json:"tags,omitempty"`
+
+	// RetryStrategy determines how failed runs are retried before alerting, defaults to the retry strategy of the group
+	RetryStrategy *RetryStrategy `json:"retryStrategy,omitempty"`
+
+	// Group determines in which group does the check belong to
+	Group string `json:"group"`
+
+	// AlertChannels determines which alert channels subscribe to the check in addition to the alert channels of the group
+	AlertChannels []string `json:"alertchannel,omitempty"`
+
+	// Adopt references an existing checklyhq.com check which is managed by the resource instead of creating a new one
+	Adopt *CheckAdoption `json:"adopt,omitempty"`
+
+	// Account is the name of the ChecklyAccount holding the credentials used for the check, if empty the operator credentials are used
+	Account string `json:"account,omitempty"`
+
+	// DeletionPolicy determines if the Checkly check is deleted together with the resource, default Delete
+	//+kubebuilder:validation:Enum=Delete;Retain
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+}
+
+//+kubebuilder:validation:Enum=GET;POST;PUT;PATCH;DELETE;HEAD
+
+// HTTPMethod is the method of an HTTP request
+type HTTPMethod string
+
+//+kubebuilder:validation:Enum=af-south-1;ap-east-1;ap-northeast-1;ap-northeast-2;ap-northeast-3;ap-south-1;ap-southeast-1;ap-southeast-2;ap-southeast-3;ca-central-1;eu-central-1;eu-north-1;eu-south-1;eu-west-1;eu-west-2;eu-west-3;me-south-1;sa-east-1;us-east-1;us-east-2;us-west-1;us-west-2
+
+// Location is a checklyhq.com public location, see https://www.checklyhq.com/docs/monitoring/global-locations/
+type Location string
+
+// CheckAdoption references an existing checklyhq.com check, its results and history are kept
+type CheckAdoption struct {
+	// ID is the ID of the existing checklyhq.com check
+	//+kubebuilder:validation:MinLength=1
+	ID string `json:"id"`
+
+	// TakeOwnership deletes the check together with the resource, by default an adopted check is kept
+	TakeOwnership bool `json:"takeOwnership,omitempty"`
+}
+
+// HTTPHeader is a header sent with the request of the check
+type HTTPHeader struct {
+	// Key is the name of the header
+	Key string `json:"key"`
+
+	// Value is the value of the header
+	Value string `json:"value,omitempty"`
+
+	// ValueFrom reads the value of the header from a Secret, takes precedence over Value
+	ValueFrom *HeaderValueSource `json:"valueFrom,omitempty"`
+}
+
+// HeaderValueSource is the source of a header value
+type HeaderValueSource struct {
+	// SecretKeyRef selects a key of a Secret in the namespace of the check
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// BasicAuth references the Secret holding the basic authentication credentials of the check
+type BasicAuth struct {
+	// SecretRef is a Secret in the namespace of the check with the username and password keys, ex. a kubernetes.io/basic-auth Secret
+	SecretRef corev1.LocalObjectReference `json:"secretRef"`
+}
+
+//+kubebuilder:validation:XValidation:rule="(has(self.inline) ? 1 : 0) + (has(self.configmap) ? 1 : 0) + (has(self.snippet) ? 1 : 0) == 1",message="exactly one of inline, configmap or snippet has to be set"
+
+// Script is a setup or teardown script of a check
+type Script struct {
+	// Inline holds the script
+	Inline string `json:"inline,omitempty"`
+
+	// ConfigMap references a key of a ConfigMap in the namespace of the check which holds the script
+	ConfigMap *corev1.ConfigMapKeySelector `json:"configmap,omitempty"`
+
+	// Snippet is the name of the Snippet resource holding the script
+	Snippet string `json:"snippet,omitempty"`
+}
+
+// RetryStrategy determines how failed check runs are retried, see https://www.checklyhq.com/docs/alerting-and-retries/retries/
+type RetryStrategy struct {
+	// Type is the backoff between the retries, NO_RETRIES disables retrying
+	//+kubebuilder:validation:Enum=FIXED;LINEAR;EXPONENTIAL;NO_RETRIES
+	Type string `json:"type"`
+
+	// BaseBackoffSeconds is the time to wait before the first retry, default 60
+	//+kubebuilder:default=60
+	//+kubebuilder:validation:Minimum=0
+	//+kubebuilder:validation:Maximum=600
+	BaseBackoffSeconds int `json:"baseBackoffSeconds,omitempty"`
+
+	// MaxRetries is the maximum number of retries, default 2
+	//+kubebuilder:default=2
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=10
+	MaxRetries int `json:"maxRetries,omitempty"`
+
+	// MaxDurationSeconds is the maximum time spent retrying, default 600
+	//+kubebuilder:default=600
+	//+kubebuilder:validation:Minimum=0
+	//+kubebuilder:validation:Maximum=600
+	MaxDurationSeconds int `json:"maxDurationSeconds,omitempty"`
+
+	// SameRegion retries the run in the region of the failed run instead of a random one
+	SameRegion bool `json:"sameRegion,omitempty"`
+}
+
+//+kubebuilder:validation:XValidation:rule="self.source != 'HEADERS' || has(self.property)",message="property has to hold the header name for HEADERS assertions"
+
+// Assertion is evaluated against the response of the check, see https://www.checklyhq.com/docs/api-checks/assertions/
+type Assertion struct {
+	// Source is the part of the response the assertion reads
+	//+kubebuilder:validation:Enum=STATUS_CODE;JSON_BODY;HEADERS;TEXT_BODY;RESPONSE_TIME
+	Source string `json:"source"`
+
+	// Property is the JSON path for JSON_BODY or the header name for HEADERS, ex. $.status
+	Property string `json:"property,omitempty"`
+
+	// Comparison is the comparison operator
+	//+kubebuilder:validation:Enum=EQUALS;NOT_EQUALS;HAS_KEY;NOT_HAS_KEY;HAS_VALUE;NOT_HAS_VALUE;IS_EMPTY;NOT_EMPTY;GREATER_THAN;LESS_THAN;CONTAINS;NOT_CONTAINS;IS_NULL;NOT_NULL
+	Comparison string `json:"comparison"`
+
+	// Target is the value the source is compared to
+	Target string `json:"target,omitempty"`
+}
+
+// ChecklyState is the state of a check or group in checklyhq.com
+type ChecklyState struct {
+	// Activated reports whether the check or group runs
+	Activated bool `json:"activated"`
+
+	// Muted reports whether the alerts of the check or group are muted
+	Muted bool `json:"muted"`
+
+	// Locations are the public locations the check or group runs from
+	//+optional
+	Locations []string `json:"locations,omitempty"`
+
+	// LastUpdatedAt is when checklyhq.com last recorded a change of the check or group
+	//+optional
+	LastUpdatedAt *metav1.Time `json:"lastUpdatedAt,omitempty"`
+}
+
+// ApiCheckStatus defines the observed state of ApiCheck
+type ApiCheckStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// ID holds the checklyhq.com internal ID of the check
+	ID string `json:"id"`
+
+	// ChecklyURL links to the check in the checklyhq.com app
+	ChecklyURL string `json:"checklyURL,omitempty"`
+
+	// Credentials is where the credentials the check is synced with come from, ChecklyAccount <name>,
+	// Secret <namespace>/checkly-credentials or empty for the credentials of the operator
+	//+optional
+	Credentials string `json:"credentials,omitempty"`
+
+	// GroupID holds the ID of the group where the check belongs to
+	GroupID int64 `json:"groupId"`
+
+	// AlertChannelIDs holds the IDs of the alert channels subscribed to the check by the operator
+	AlertChannelIDs []int64 `json:"alertChannelIds,omitempty"`
+
+	// LastSyncTime is when the spec was last applied to checklyhq.com
+	//+optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// ObservedGeneration is the generation of the spec last applied to checklyhq.com
+	//+optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastAppliedSpecHash is the hash of the spec last applied to checklyhq.com
+	//+optional
+	LastAppliedSpecHash string `json:"lastAppliedSpecHash,omitempty"`
+
+	// LastAppliedPayloadHash is the hash of the payload last pushed to checklyhq.com, unchanged payloads aren't pushed again
+	//+optional
+	LastAppliedPayloadHash string `json:"lastAppliedPayloadHash,omitempty"`
+
+	// Checkly is the state of the check as reported by checklyhq.com after the last sync
+	//+optional
+	Checkly *ChecklyState `json:"checkly,omitempty"`
+
+	// Conditions hold the Ready, Synced and Degraded conditions of the check
+	//+listType=map
+	//+listMapKey=type
+	//+optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:unservedversion
+//+kubebuilder:printcolumn:name="Endpoint",type="string",JSONPath=".spec.endpoint",description="Name of the monitored endpoint"
+//+kubebuilder:printcolumn:name="Status code",type="string",JSONPath=".spec.success",description="Expected status code"
+//+kubebuilder:printcolumn:name="Muted",type="boolean",JSONPath=".spec.muted"
+//+kubebuilder:printcolumn:name="Group",type="string",JSONPath=".spec.group"
+//+kubebuilder:printcolumn:name="Group ID",type="integer",JSONPath=".status.groupId",priority=1
+//+kubebuilder:printcolumn:name="Checkly ID",type="string",JSONPath=".status.id"
+//+kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+//+kubebuilder:printcolumn:name="Last sync",type="date",JSONPath=".status.lastSyncTime"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+//+kubebuilder:subresource:status
+
+// ApiCheck is the Schema for the apichecks API
+type ApiCheck struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ApiCheckSpec   `json:"spec,omitempty"`
+	Status ApiCheckStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ApiCheckList contains a list of ApiCheck
+type ApiCheckList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ApiCheck `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ApiCheck{}, &ApiCheckList{})
+}
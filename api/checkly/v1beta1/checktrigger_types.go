@@ -0,0 +1,90 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// CheckTriggerSpec defines the desired state of CheckTrigger
+type CheckTriggerSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// Check is the name of the check in the same namespace the trigger is created for
+	Check string `json:"check,omitempty"`
+
+	// CheckKind determines the kind of the referenced check, default ApiCheck
+	//+kubebuilder:validation:Enum=ApiCheck;BrowserCheck;MultiStepCheck
+	//+kubebuilder:default=ApiCheck
+	CheckKind string `json:"checkkind,omitempty"`
+
+	// Group is the name of the group the trigger is created for, takes precedence over Check
+	Group string `json:"group,omitempty"`
+
+	// DeletionPolicy determines if the Checkly trigger is deleted together with the resource, default Delete
+	//+kubebuilder:validation:Enum=Delete;Retain
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+}
+
+// CheckTriggerStatus defines the observed state of CheckTrigger
+type CheckTriggerStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// CheckID holds the checklyhq.com internal ID of the triggered check
+	CheckID string `json:"checkId,omitempty"`
+
+	// GroupID holds the checklyhq.com internal ID of the triggered group
+	GroupID int64 `json:"groupId,omitempty"`
+
+	// URL holds the URL which starts a run of the check or group when called
+	URL string `json:"url,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:unservedversion
+//+kubebuilder:printcolumn:name="Check",type="string",JSONPath=".spec.check"
+//+kubebuilder:printcolumn:name="Group",type="string",JSONPath=".spec.group"
+//+kubebuilder:printcolumn:name="URL",type="string",JSONPath=".status.url"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+//+kubebuilder:subresource:status
+
+// CheckTrigger is the Schema for the checktriggers API
+type CheckTrigger struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CheckTriggerSpec   `json:"spec,omitempty"`
+	Status CheckTriggerStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CheckTriggerList contains a list of CheckTrigger
+type CheckTriggerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CheckTrigger `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CheckTrigger{}, &CheckTriggerList{})
+}
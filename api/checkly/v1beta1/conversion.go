@@ -0,0 +1,461 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"maps"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+// convertFields copies the spec and status between the versions through their JSON representation, the typed
+// spec fields are skipped, their JSON name is the same in both versions but their shape isn't, the caller converts them
+func convertFields(srcSpec, srcStatus, dstSpec, dstStatus interface{}, typedFields ...string) error {
+	if err := convertJSON(srcSpec, dstSpec, typedFields); err != nil {
+		return err
+	}
+
+	return convertJSON(srcStatus, dstStatus, nil)
+}
+
+func convertJSON(src, dst interface{}, skip []string) error {
+	b, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+
+	if len(skip) > 0 {
+		fields := map[string]interface{}{}
+		if err := json.Unmarshal(b, &fields); err != nil {
+			return err
+		}
+		for _, path := range skip {
+			deleteField(fields, strings.Split(path, "."))
+		}
+		if b, err = json.Marshal(fields); err != nil {
+			return err
+		}
+	}
+
+	return json.Unmarshal(b, dst)
+}
+
+// deleteField deletes the field of the dot separated path
+func deleteField(fields map[string]interface{}, path []string) {
+	if len(path) == 1 {
+		delete(fields, path[0])
+		return
+	}
+
+	if nested, ok := fields[path[0]].(map[string]interface{}); ok {
+		deleteField(nested, path[1:])
+	}
+}
+
+// minutes returns the whole minutes of the duration, 0 if it's unset
+func minutes(d *metav1.Duration) int {
+	if d == nil {
+		return 0
+	}
+
+	return int(d.Minutes())
+}
+
+// minutesDuration returns the duration of the minutes, nil for 0
+func minutesDuration(minutes int) *metav1.Duration {
+	if minutes == 0 {
+		return nil
+	}
+
+	return &metav1.Duration{Duration: time.Duration(minutes) * time.Minute}
+}
+
+// milliseconds returns the milliseconds of the duration, 0 if it's unset
+func milliseconds(d *metav1.Duration) int {
+	if d == nil {
+		return 0
+	}
+
+	return int(d.Milliseconds())
+}
+
+// millisecondsDuration returns the duration of the milliseconds, nil for 0
+func millisecondsDuration(milliseconds int) *metav1.Duration {
+	if milliseconds == 0 {
+		return nil
+	}
+
+	return &metav1.Duration{Duration: time.Duration(milliseconds) * time.Millisecond}
+}
+
+// apiCheckTypedFields are the fields of the ApiCheck spec with a different type in v1alpha1
+var apiCheckTypedFields = []string{"frequency", "frequencyOffset", "maxresponsetime", "degradedresponsetime"}
+
+// frequencyOffsetAnnotation keeps the frequencyOffset of a v1beta1 frequency below a minute in v1alpha1, which
+// stores the seconds of the frequency in frequencyOffset. The validation rejects the offset, but the conversion
+// doesn't drop it
+var frequencyOffsetAnnotation = v1alpha1.GroupVersion.Group + "/v1beta1-frequency-offset"
+
+// convertApiCheckSpecTo converts the typed fields to v1alpha1, where a frequency
+// below a minute is frequency 0 with the number of seconds in frequencyOffset
+func convertApiCheckSpecTo(src *ApiCheckSpec, dst *v1alpha1.ApiCheckSpec, dstMeta *metav1.ObjectMeta) {
+	dst.Frequency, dst.FrequencyOffset = nil, src.FrequencyOffset
+	if _, kept := dstMeta.Annotations[frequencyOffsetAnnotation]; kept {
+		setAnnotation(dstMeta, frequencyOffsetAnnotation, "")
+	}
+	if src.Frequency != nil {
+		frequency := minutes(src.Frequency)
+		dst.Frequency = &frequency
+	}
+	if src.Frequency != nil && src.Frequency.Duration < time.Minute {
+		dst.FrequencyOffset = int(src.Frequency.Seconds())
+		if src.FrequencyOffset != 0 {
+			setAnnotation(dstMeta, frequencyOffsetAnnotation, strconv.Itoa(src.FrequencyOffset))
+		}
+	}
+	dst.MaxResponseTime = milliseconds(src.MaxResponseTime)
+	dst.DegradedResponseTime = milliseconds(src.DegradedResponseTime)
+}
+
+// convertApiCheckSpecFrom converts the typed fields from v1alpha1
+func convertApiCheckSpecFrom(src *v1alpha1.ApiCheckSpec, dst *ApiCheckSpec, dstMeta *metav1.ObjectMeta) {
+	dst.Frequency, dst.FrequencyOffset = nil, src.FrequencyOffset
+	if src.Frequency != nil {
+		dst.Frequency = &metav1.Duration{Duration: time.Duration(*src.Frequency) * time.Minute}
+	}
+	offset, kept := dstMeta.Annotations[frequencyOffsetAnnotation]
+	if kept {
+		setAnnotation(dstMeta, frequencyOffsetAnnotation, "")
+	}
+	if src.Frequency != nil && *src.Frequency == 0 {
+		dst.Frequency, dst.FrequencyOffset = &metav1.Duration{Duration: time.Duration(src.FrequencyOffset) * time.Second}, 0
+		if kept {
+			dst.FrequencyOffset, _ = strconv.Atoi(offset)
+		}
+	}
+	dst.MaxResponseTime = millisecondsDuration(src.MaxResponseTime)
+	dst.DegradedResponseTime = millisecondsDuration(src.DegradedResponseTime)
+}
+
+// setAnnotation sets the annotation on a copy of the annotations, which are shared with the converted object,
+// an empty value deletes it
+func setAnnotation(meta *metav1.ObjectMeta, key, value string) {
+	annotations := maps.Clone(meta.Annotations)
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	if value == "" {
+		delete(annotations, key)
+	} else {
+		annotations[key] = value
+	}
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	meta.Annotations = annotations
+}
+
+// frequencyTypedFields are the fields of the BrowserCheck and MultiStepCheck specs with a different type in v1alpha1
+var frequencyTypedFields = []string{"frequency"}
+
+func convertBrowserCheckSpecTo(src *BrowserCheckSpec, dst *v1alpha1.BrowserCheckSpec) {
+	dst.Frequency = minutes(src.Frequency)
+}
+
+func convertBrowserCheckSpecFrom(src *v1alpha1.BrowserCheckSpec, dst *BrowserCheckSpec) {
+	dst.Frequency = minutesDuration(src.Frequency)
+}
+
+func convertMultiStepCheckSpecTo(src *MultiStepCheckSpec, dst *v1alpha1.MultiStepCheckSpec) {
+	dst.Frequency = minutes(src.Frequency)
+}
+
+func convertMultiStepCheckSpecFrom(src *v1alpha1.MultiStepCheckSpec, dst *MultiStepCheckSpec) {
+	dst.Frequency = minutesDuration(src.Frequency)
+}
+
+// groupTypedFields are the fields of the Group spec with a different type in v1alpha1
+var groupTypedFields = []string{"maxresponsetime", "degradedresponsetime", "browserCheckDefaults.frequency"}
+
+func convertGroupSpecTo(src *GroupSpec, dst *v1alpha1.GroupSpec) {
+	dst.MaxResponseTime = milliseconds(src.MaxResponseTime)
+	dst.DegradedResponseTime = milliseconds(src.DegradedResponseTime)
+	if src.BrowserCheckDefaults != nil && dst.BrowserCheckDefaults != nil {
+		dst.BrowserCheckDefaults.Frequency = minutes(src.BrowserCheckDefaults.Frequency)
+	}
+}
+
+func convertGroupSpecFrom(src *v1alpha1.GroupSpec, dst *GroupSpec) {
+	dst.MaxResponseTime = millisecondsDuration(src.MaxResponseTime)
+	dst.DegradedResponseTime = millisecondsDuration(src.DegradedResponseTime)
+	if src.BrowserCheckDefaults != nil && dst.BrowserCheckDefaults != nil {
+		dst.BrowserCheckDefaults.Frequency = minutesDuration(src.BrowserCheckDefaults.Frequency)
+	}
+}
+
+// ConvertTo converts this AlertChannel to the hub version (v1alpha1).
+func (src *AlertChannel) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.AlertChannel)
+	dst.ObjectMeta = src.ObjectMeta
+	return convertFields(&src.Spec, &src.Status, &dst.Spec, &dst.Status)
+}
+
+// ConvertFrom converts from the hub version (v1alpha1) to this version.
+func (dst *AlertChannel) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.AlertChannel)
+	dst.ObjectMeta = src.ObjectMeta
+	return convertFields(&src.Spec, &src.Status, &dst.Spec, &dst.Status)
+}
+
+// ConvertTo converts this AlertChannelSubscription to the hub version (v1alpha1).
+func (src *AlertChannelSubscription) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.AlertChannelSubscription)
+	dst.ObjectMeta = src.ObjectMeta
+	return convertFields(&src.Spec, &src.Status, &dst.Spec, &dst.Status)
+}
+
+// ConvertFrom converts from the hub version (v1alpha1) to this version.
+func (dst *AlertChannelSubscription) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.AlertChannelSubscription)
+	dst.ObjectMeta = src.ObjectMeta
+	return convertFields(&src.Spec, &src.Status, &dst.Spec, &dst.Status)
+}
+
+// ConvertTo converts this ApiCheck to the hub version (v1alpha1).
+func (src *ApiCheck) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.ApiCheck)
+	dst.ObjectMeta = src.ObjectMeta
+	if err := convertFields(&src.Spec, &src.Status, &dst.Spec, &dst.Status, apiCheckTypedFields...); err != nil {
+		return err
+	}
+
+	convertApiCheckSpecTo(&src.Spec, &dst.Spec, &dst.ObjectMeta)
+	return nil
+}
+
+// ConvertFrom converts from the hub version (v1alpha1) to this version.
+func (dst *ApiCheck) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.ApiCheck)
+	dst.ObjectMeta = src.ObjectMeta
+	if err := convertFields(&src.Spec, &src.Status, &dst.Spec, &dst.Status, apiCheckTypedFields...); err != nil {
+		return err
+	}
+
+	convertApiCheckSpecFrom(&src.Spec, &dst.Spec, &dst.ObjectMeta)
+	return nil
+}
+
+// ConvertTo converts this BrowserCheck to the hub version (v1alpha1).
+func (src *BrowserCheck) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.BrowserCheck)
+	dst.ObjectMeta = src.ObjectMeta
+	if err := convertFields(&src.Spec, &src.Status, &dst.Spec, &dst.Status, frequencyTypedFields...); err != nil {
+		return err
+	}
+
+	convertBrowserCheckSpecTo(&src.Spec, &dst.Spec)
+	return nil
+}
+
+// ConvertFrom converts from the hub version (v1alpha1) to this version.
+func (dst *BrowserCheck) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.BrowserCheck)
+	dst.ObjectMeta = src.ObjectMeta
+	if err := convertFields(&src.Spec, &src.Status, &dst.Spec, &dst.Status, frequencyTypedFields...); err != nil {
+		return err
+	}
+
+	convertBrowserCheckSpecFrom(&src.Spec, &dst.Spec)
+	return nil
+}
+
+// ConvertTo converts this ChecklyAccount to the hub version (v1alpha1).
+func (src *ChecklyAccount) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.ChecklyAccount)
+	dst.ObjectMeta = src.ObjectMeta
+	return convertFields(&src.Spec, &src.Status, &dst.Spec, &dst.Status)
+}
+
+// ConvertFrom converts from the hub version (v1alpha1) to this version.
+func (dst *ChecklyAccount) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.ChecklyAccount)
+	dst.ObjectMeta = src.ObjectMeta
+	return convertFields(&src.Spec, &src.Status, &dst.Spec, &dst.Status)
+}
+
+// ConvertTo converts this CheckTrigger to the hub version (v1alpha1).
+func (src *CheckTrigger) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.CheckTrigger)
+	dst.ObjectMeta = src.ObjectMeta
+	return convertFields(&src.Spec, &src.Status, &dst.Spec, &dst.Status)
+}
+
+// ConvertFrom converts from the hub version (v1alpha1) to this version.
+func (dst *CheckTrigger) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.CheckTrigger)
+	dst.ObjectMeta = src.ObjectMeta
+	return convertFields(&src.Spec, &src.Status, &dst.Spec, &dst.Status)
+}
+
+// ConvertTo converts this ClusterApiCheck to the hub version (v1alpha1).
+func (src *ClusterApiCheck) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.ClusterApiCheck)
+	dst.ObjectMeta = src.ObjectMeta
+	if err := convertFields(&src.Spec, &src.Status, &dst.Spec, &dst.Status, apiCheckTypedFields...); err != nil {
+		return err
+	}
+
+	convertApiCheckSpecTo(&src.Spec, &dst.Spec, &dst.ObjectMeta)
+	return nil
+}
+
+// ConvertFrom converts from the hub version (v1alpha1) to this version.
+func (dst *ClusterApiCheck) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.ClusterApiCheck)
+	dst.ObjectMeta = src.ObjectMeta
+	if err := convertFields(&src.Spec, &src.Status, &dst.Spec, &dst.Status, apiCheckTypedFields...); err != nil {
+		return err
+	}
+
+	convertApiCheckSpecFrom(&src.Spec, &dst.Spec, &dst.ObjectMeta)
+	return nil
+}
+
+// ConvertTo converts this Dashboard to the hub version (v1alpha1).
+func (src *Dashboard) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.Dashboard)
+	dst.ObjectMeta = src.ObjectMeta
+	return convertFields(&src.Spec, &src.Status, &dst.Spec, &dst.Status)
+}
+
+// ConvertFrom converts from the hub version (v1alpha1) to this version.
+func (dst *Dashboard) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.Dashboard)
+	dst.ObjectMeta = src.ObjectMeta
+	return convertFields(&src.Spec, &src.Status, &dst.Spec, &dst.Status)
+}
+
+// ConvertTo converts this EnvironmentVariable to the hub version (v1alpha1).
+func (src *EnvironmentVariable) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.EnvironmentVariable)
+	dst.ObjectMeta = src.ObjectMeta
+	return convertFields(&src.Spec, &src.Status, &dst.Spec, &dst.Status)
+}
+
+// ConvertFrom converts from the hub version (v1alpha1) to this version.
+func (dst *EnvironmentVariable) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.EnvironmentVariable)
+	dst.ObjectMeta = src.ObjectMeta
+	return convertFields(&src.Spec, &src.Status, &dst.Spec, &dst.Status)
+}
+
+// ConvertTo converts this Group to the hub version (v1alpha1).
+func (src *Group) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.Group)
+	dst.ObjectMeta = src.ObjectMeta
+	if err := convertFields(&src.Spec, &src.Status, &dst.Spec, &dst.Status, groupTypedFields...); err != nil {
+		return err
+	}
+
+	convertGroupSpecTo(&src.Spec, &dst.Spec)
+	return nil
+}
+
+// ConvertFrom converts from the hub version (v1alpha1) to this version.
+func (dst *Group) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.Group)
+	dst.ObjectMeta = src.ObjectMeta
+	if err := convertFields(&src.Spec, &src.Status, &dst.Spec, &dst.Status, groupTypedFields...); err != nil {
+		return err
+	}
+
+	convertGroupSpecFrom(&src.Spec, &dst.Spec)
+	return nil
+}
+
+// ConvertTo converts this HeartbeatCheck to the hub version (v1alpha1).
+func (src *HeartbeatCheck) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.HeartbeatCheck)
+	dst.ObjectMeta = src.ObjectMeta
+	return convertFields(&src.Spec, &src.Status, &dst.Spec, &dst.Status)
+}
+
+// ConvertFrom converts from the hub version (v1alpha1) to this version.
+func (dst *HeartbeatCheck) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.HeartbeatCheck)
+	dst.ObjectMeta = src.ObjectMeta
+	return convertFields(&src.Spec, &src.Status, &dst.Spec, &dst.Status)
+}
+
+// ConvertTo converts this MultiStepCheck to the hub version (v1alpha1).
+func (src *MultiStepCheck) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.MultiStepCheck)
+	dst.ObjectMeta = src.ObjectMeta
+	if err := convertFields(&src.Spec, &src.Status, &dst.Spec, &dst.Status, frequencyTypedFields...); err != nil {
+		return err
+	}
+
+	convertMultiStepCheckSpecTo(&src.Spec, &dst.Spec)
+	return nil
+}
+
+// ConvertFrom converts from the hub version (v1alpha1) to this version.
+func (dst *MultiStepCheck) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.MultiStepCheck)
+	dst.ObjectMeta = src.ObjectMeta
+	if err := convertFields(&src.Spec, &src.Status, &dst.Spec, &dst.Status, frequencyTypedFields...); err != nil {
+		return err
+	}
+
+	convertMultiStepCheckSpecFrom(&src.Spec, &dst.Spec)
+	return nil
+}
+
+// ConvertTo converts this PrivateLocation to the hub version (v1alpha1).
+func (src *PrivateLocation) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.PrivateLocation)
+	dst.ObjectMeta = src.ObjectMeta
+	return convertFields(&src.Spec, &src.Status, &dst.Spec, &dst.Status)
+}
+
+// ConvertFrom converts from the hub version (v1alpha1) to this version.
+func (dst *PrivateLocation) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.PrivateLocation)
+	dst.ObjectMeta = src.ObjectMeta
+	return convertFields(&src.Spec, &src.Status, &dst.Spec, &dst.Status)
+}
+
+// ConvertTo converts this Snippet to the hub version (v1alpha1).
+func (src *Snippet) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.Snippet)
+	dst.ObjectMeta = src.ObjectMeta
+	return convertFields(&src.Spec, &src.Status, &dst.Spec, &dst.Status)
+}
+
+// ConvertFrom converts from the hub version (v1alpha1) to this version.
+func (dst *Snippet) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.Snippet)
+	dst.ObjectMeta = src.ObjectMeta
+	return convertFields(&src.Spec, &src.Status, &dst.Spec, &dst.Status)
+}
@@ -0,0 +1,231 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+func TestApiCheckConversion(t *testing.T) {
+	frequency := 10
+	hub := &v1alpha1.ApiCheck{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "bar", Labels: map[string]string{"team": "platform"}},
+		Spec: v1alpha1.ApiCheckSpec{
+			Frequency:            &frequency,
+			FrequencyOffset:      2,
+			Endpoint:             "https://foo.bar/baz",
+			Success:              "200",
+			Method:               "POST",
+			MaxResponseTime:      10000,
+			DegradedResponseTime: 2500,
+			Locations:            []string{"eu-west-1"},
+			Headers: []v1alpha1.HTTPHeader{{
+				Key: "Authorization",
+				ValueFrom: &v1alpha1.HeaderValueSource{SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "token"},
+					Key:                  "token",
+				}},
+			}},
+			Assertions:    []v1alpha1.Assertion{{Source: "JSON_BODY", Property: "$.status", Comparison: "EQUALS", Target: "ok"}},
+			RetryStrategy: &v1alpha1.RetryStrategy{Type: "FIXED", MaxRetries: 2},
+			Group:         "group",
+		},
+		Status: v1alpha1.ApiCheckStatus{ID: "1", GroupID: 2, AlertChannelIDs: []int64{3}},
+	}
+
+	apiCheck := &ApiCheck{}
+	if err := apiCheck.ConvertFrom(hub); err != nil {
+		t.Fatal(err)
+	}
+	if apiCheck.Name != hub.Name || apiCheck.Labels["team"] != "platform" {
+		t.Errorf("Expected the metadata to be kept, got %+v", apiCheck.ObjectMeta)
+	}
+	if apiCheck.Spec.Headers[0].ValueFrom.SecretKeyRef.Name != "token" || apiCheck.Status.GroupID != 2 {
+		t.Errorf("Expected the spec and status to be converted, got %+v", apiCheck)
+	}
+	if apiCheck.Spec.Frequency.Duration != 10*time.Minute || apiCheck.Spec.FrequencyOffset != 2 {
+		t.Errorf("Expected a frequency of %s with offset %d, got %s with offset %d", 10*time.Minute, 2, apiCheck.Spec.Frequency.Duration, apiCheck.Spec.FrequencyOffset)
+	}
+	if apiCheck.Spec.MaxResponseTime.Duration != 10*time.Second || apiCheck.Spec.DegradedResponseTime.Duration != 2500*time.Millisecond {
+		t.Errorf("Expected response times of %s and %s, got %s and %s", 10*time.Second, 2500*time.Millisecond, apiCheck.Spec.MaxResponseTime.Duration, apiCheck.Spec.DegradedResponseTime.Duration)
+	}
+	if apiCheck.Spec.Method != "POST" || apiCheck.Spec.Locations[0] != "eu-west-1" {
+		t.Errorf("Expected the method and locations to be converted, got %s and %v", apiCheck.Spec.Method, apiCheck.Spec.Locations)
+	}
+
+	converted := &v1alpha1.ApiCheck{}
+	if err := apiCheck.ConvertTo(converted); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(hub, converted) {
+		t.Errorf("Expected %+v, got %+v", hub, converted)
+	}
+
+	// Frequencies below a minute are stored as frequency 0 with the seconds in frequencyOffset
+	apiCheck.Spec.Frequency, apiCheck.Spec.FrequencyOffset = &metav1.Duration{Duration: 30 * time.Second}, 0
+	if err := apiCheck.ConvertTo(converted); err != nil {
+		t.Fatal(err)
+	}
+	if *converted.Spec.Frequency != 0 || converted.Spec.FrequencyOffset != 30 {
+		t.Errorf("Expected frequency %d with offset %d, got %d with offset %d", 0, 30, *converted.Spec.Frequency, converted.Spec.FrequencyOffset)
+	}
+
+	if err := apiCheck.ConvertFrom(converted); err != nil {
+		t.Fatal(err)
+	}
+	if apiCheck.Spec.Frequency.Duration != 30*time.Second || apiCheck.Spec.FrequencyOffset != 0 {
+		t.Errorf("Expected a frequency of %s without offset, got %s with offset %d", 30*time.Second, apiCheck.Spec.Frequency.Duration, apiCheck.Spec.FrequencyOffset)
+	}
+
+	// Unset frequencies stay unset
+	if err := apiCheck.ConvertFrom(&v1alpha1.ApiCheck{}); err != nil {
+		t.Fatal(err)
+	}
+	if apiCheck.Spec.Frequency != nil || apiCheck.Spec.MaxResponseTime != nil {
+		t.Errorf("Expected no frequency and max response time, got %v and %v", apiCheck.Spec.Frequency, apiCheck.Spec.MaxResponseTime)
+	}
+}
+
+func TestApiCheckFrequencyRoundTrip(t *testing.T) {
+	subMinute, frequency := 0, 10
+
+	// Specs which aren't valid in the other version are converted back unchanged as well
+	for name, spec := range map[string]ApiCheckSpec{
+		"sub-minute":             {Frequency: &metav1.Duration{Duration: 30 * time.Second}},
+		"sub-minute with offset": {Frequency: &metav1.Duration{Duration: 30 * time.Second}, FrequencyOffset: 3},
+		"offset":                 {Frequency: &metav1.Duration{Duration: time.Hour}, FrequencyOffset: 2},
+		"offset only":            {FrequencyOffset: 2},
+		"unset":                  {},
+	} {
+		apiCheck := &ApiCheck{ObjectMeta: metav1.ObjectMeta{Name: "foo"}, Spec: spec}
+		hub := &v1alpha1.ApiCheck{}
+		if err := apiCheck.ConvertTo(hub); err != nil {
+			t.Fatal(err)
+		}
+		converted := &ApiCheck{}
+		if err := converted.ConvertFrom(hub); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(apiCheck, converted) {
+			t.Errorf("%s: expected %+v, got %+v", name, apiCheck, converted)
+		}
+	}
+
+	for name, spec := range map[string]v1alpha1.ApiCheckSpec{
+		"sub-minute":  {Frequency: &subMinute, FrequencyOffset: 20},
+		"offset":      {Frequency: &frequency, FrequencyOffset: 2},
+		"offset only": {FrequencyOffset: 2},
+		"unset":       {},
+	} {
+		hub := &v1alpha1.ApiCheck{ObjectMeta: metav1.ObjectMeta{Name: "foo"}, Spec: spec}
+		apiCheck := &ApiCheck{}
+		if err := apiCheck.ConvertFrom(hub); err != nil {
+			t.Fatal(err)
+		}
+		converted := &v1alpha1.ApiCheck{}
+		if err := apiCheck.ConvertTo(converted); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(hub, converted) {
+			t.Errorf("%s: expected %+v, got %+v", name, hub, converted)
+		}
+	}
+
+	// An offset without a frequency spreads the checks of the default frequency, it isn't a sub-minute frequency
+	apiCheck := &ApiCheck{}
+	if err := apiCheck.ConvertFrom(&v1alpha1.ApiCheck{Spec: v1alpha1.ApiCheckSpec{FrequencyOffset: 2}}); err != nil {
+		t.Fatal(err)
+	}
+	if apiCheck.Spec.Frequency != nil || apiCheck.Spec.FrequencyOffset != 2 {
+		t.Errorf("Expected no frequency with offset %d, got %v with offset %d", 2, apiCheck.Spec.Frequency, apiCheck.Spec.FrequencyOffset)
+	}
+
+	// The kept offset doesn't change the annotations of the converted object
+	annotated := &ApiCheck{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"foo": "bar"}},
+		Spec:       ApiCheckSpec{Frequency: &metav1.Duration{Duration: 30 * time.Second}, FrequencyOffset: 3},
+	}
+	hub := &v1alpha1.ApiCheck{}
+	if err := annotated.ConvertTo(hub); err != nil {
+		t.Fatal(err)
+	}
+	if len(annotated.Annotations) != 1 || hub.Annotations[frequencyOffsetAnnotation] != "3" {
+		t.Errorf("Expected the offset to be kept on the converted object only, got %v and %v", annotated.Annotations, hub.Annotations)
+	}
+}
+
+func TestBrowserCheckConversion(t *testing.T) {
+	hub := &v1alpha1.BrowserCheck{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "bar"},
+		Spec:       v1alpha1.BrowserCheckSpec{Frequency: 60, Script: "console.log('foo')", Group: "group"},
+	}
+
+	browserCheck := &BrowserCheck{}
+	if err := browserCheck.ConvertFrom(hub); err != nil {
+		t.Fatal(err)
+	}
+	if browserCheck.Spec.Frequency.Duration != time.Hour {
+		t.Errorf("Expected %s, got %s", time.Hour, browserCheck.Spec.Frequency.Duration)
+	}
+
+	converted := &v1alpha1.BrowserCheck{}
+	if err := browserCheck.ConvertTo(converted); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(hub, converted) {
+		t.Errorf("Expected %+v, got %+v", hub, converted)
+	}
+}
+
+func TestGroupConversion(t *testing.T) {
+	hub := &v1alpha1.Group{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+		Spec: v1alpha1.GroupSpec{
+			Locations: []string{"eu-west-1"},
+			AlertChannelSubscriptions: []v1alpha1.GroupAlertChannelSubscription{
+				{AlertChannel: "email", Activated: false},
+			},
+			ApiCheckDefaults:     &v1alpha1.ApiCheckDefaults{BaseURL: "https://foo.bar", Headers: map[string]string{"foo": "bar"}},
+			BrowserCheckDefaults: &v1alpha1.BrowserCheckDefaults{Frequency: 30, RuntimeID: "2024.02"},
+			MaxResponseTime:      20000,
+		},
+		Status: v1alpha1.GroupStatus{ID: 1},
+	}
+
+	group := &Group{}
+	if err := group.ConvertFrom(hub); err != nil {
+		t.Fatal(err)
+	}
+	if group.Spec.BrowserCheckDefaults.Frequency.Duration != 30*time.Minute || group.Spec.MaxResponseTime.Duration != 20*time.Second {
+		t.Errorf("Expected %s and %s, got %s and %s", 30*time.Minute, 20*time.Second, group.Spec.BrowserCheckDefaults.Frequency.Duration, group.Spec.MaxResponseTime.Duration)
+	}
+
+	converted := &v1alpha1.Group{}
+	if err := group.ConvertTo(converted); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(hub, converted) {
+		t.Errorf("Expected %+v, got %+v", hub, converted)
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// DashboardSpec defines the desired state of Dashboard
+type DashboardSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// CustomUrl determines the subdomain of the dashboard on checkly-dashboards.com, ex. foo for https://foo.checkly-dashboards.com
+	CustomUrl string `json:"customurl"`
+
+	// CustomDomain determines a custom domain the dashboard is served on, ex. status.foo.bar
+	CustomDomain string `json:"customdomain,omitempty"`
+
+	// Header determines the title of the dashboard, defaults to the name of the resource
+	Header string `json:"header,omitempty"`
+
+	// Description holds a text shown below the header of the dashboard
+	Description string `json:"description,omitempty"`
+
+	// Logo holds the URL of the logo shown on the dashboard
+	Logo string `json:"logo,omitempty"`
+
+	// Favicon holds the URL of the favicon of the dashboard
+	Favicon string `json:"favicon,omitempty"`
+
+	// Link determines where the logo links to
+	Link string `json:"link,omitempty"`
+
+	// Width determines the width of the dashboard, default FULL
+	//+kubebuilder:validation:Enum=FULL;960PX
+	Width string `json:"width,omitempty"`
+
+	// RefreshRate determines how often the dashboard refreshes in seconds, default 60
+	//+kubebuilder:validation:Enum=60;300;600
+	RefreshRate int `json:"refreshrate,omitempty"`
+
+	// Paginate determines if the checks are shown on multiple pages, default false
+	Paginate bool `json:"paginate,omitempty"`
+
+	// PaginationRate determines how often the pages switch in seconds, default 60
+	//+kubebuilder:validation:Enum=30;60;300
+	PaginationRate int `json:"paginationrate,omitempty"`
+
+	// ChecksPerPage determines how many checks are shown on a page, default 15
+	ChecksPerPage int `json:"checksperpage,omitempty"`
+
+	// Tags determines which checks are shown on the dashboard, checks matching any of the tags are shown
+	Tags []string `json:"tags,omitempty"`
+
+	// UseTagsAndOperator determines if checks need to match all of the tags to be shown, default false
+	UseTagsAndOperator bool `json:"usetagsandoperator,omitempty"`
+
+	// HideTags determines if the tags of the checks are hidden on the dashboard, default false
+	HideTags bool `json:"hidetags,omitempty"`
+
+	// DeletionPolicy determines if the Checkly dashboard is deleted together with the resource, default Delete
+	//+kubebuilder:validation:Enum=Delete;Retain
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+}
+
+// DashboardStatus defines the observed state of Dashboard
+type DashboardStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// ID holds the checklyhq.com internal ID of the dashboard
+	ID string `json:"id"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:unservedversion
+//+kubebuilder:printcolumn:name="Custom URL",type="string",JSONPath=".spec.customurl"
+//+kubebuilder:printcolumn:name="Custom domain",type="string",JSONPath=".spec.customdomain"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+
+// Dashboard is the Schema for the dashboards API
+type Dashboard struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DashboardSpec   `json:"spec,omitempty"`
+	Status DashboardStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DashboardList contains a list of Dashboard
+type DashboardList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Dashboard `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Dashboard{}, &DashboardList{})
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// HeartbeatCheckSpec defines the desired state of HeartbeatCheck
+type HeartbeatCheckSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// Period determines how often a ping is expected, default 1
+	Period int `json:"period,omitempty"`
+
+	// PeriodUnit determines the unit of the period, default days
+	//+kubebuilder:validation:Enum=seconds;minutes;hours;days
+	PeriodUnit string `json:"periodunit,omitempty"`
+
+	// Grace determines how long to wait for a late ping before alerting, default 1
+	Grace int `json:"grace,omitempty"`
+
+	// GraceUnit determines the unit of the grace period, default hours
+	//+kubebuilder:validation:Enum=seconds;minutes;hours;days
+	GraceUnit string `json:"graceunit,omitempty"`
+
+	// Muted determines if the created alert is muted or not, default false
+	Muted bool `json:"muted,omitempty"`
+
+	// Paused deactivates the check in checklyhq.com without deleting it, the history of the check is kept, default false
+	Paused bool `json:"paused,omitempty"`
+
+	// AlertChannels determines which alert channels subscribe to the check
+	AlertChannels []string `json:"alertchannel,omitempty"`
+
+	// Adopt references an existing checklyhq.com check which is managed by the resource instead of creating a new one
+	Adopt *CheckAdoption `json:"adopt,omitempty"`
+
+	// Account is the name of the ChecklyAccount holding the credentials used for the check, if empty the operator credentials are used
+	Account string `json:"account,omitempty"`
+
+	// DeletionPolicy determines if the Checkly check is deleted together with the resource, default Delete
+	//+kubebuilder:validation:Enum=Delete;Retain
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+}
+
+// HeartbeatCheckStatus defines the observed state of HeartbeatCheck
+type HeartbeatCheckStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// ID holds the checklyhq.com internal ID of the check
+	ID string `json:"id"`
+
+	// ChecklyURL links to the check in the checklyhq.com app
+	ChecklyURL string `json:"checklyURL,omitempty"`
+
+	// Credentials is where the credentials the check is synced with come from, ChecklyAccount <name>,
+	// Secret <namespace>/checkly-credentials or empty for the credentials of the operator
+	//+optional
+	Credentials string `json:"credentials,omitempty"`
+
+	// PingURL holds the URL which the monitored workload has to ping
+	PingURL string `json:"pingUrl,omitempty"`
+
+	// Checkly is the state of the check as reported by checklyhq.com after the last sync
+	//+optional
+	Checkly *ChecklyState `json:"checkly,omitempty"`
+
+	// Conditions hold the Ready, Synced, Degraded and Stalled conditions of the check
+	//+listType=map
+	//+listMapKey=type
+	//+optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:unservedversion
+//+kubebuilder:printcolumn:name="Period",type="integer",JSONPath=".spec.period"
+//+kubebuilder:printcolumn:name="Unit",type="string",JSONPath=".spec.periodunit"
+//+kubebuilder:printcolumn:name="Muted",type="boolean",JSONPath=".spec.muted"
+//+kubebuilder:printcolumn:name="Ping URL",type="string",JSONPath=".status.pingUrl"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+//+kubebuilder:subresource:status
+
+// HeartbeatCheck is the Schema for the heartbeatchecks API
+type HeartbeatCheck struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   HeartbeatCheckSpec   `json:"spec,omitempty"`
+	Status HeartbeatCheckStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// HeartbeatCheckList contains a list of HeartbeatCheck
+type HeartbeatCheckList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []HeartbeatCheck `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&HeartbeatCheck{}, &HeartbeatCheckList{})
+}
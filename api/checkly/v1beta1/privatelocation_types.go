@@ -0,0 +1,87 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// PrivateLocationSpec defines the desired state of PrivateLocation
+type PrivateLocationSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// SlugName determines the identifier of the location used in checks and groups, defaults to the name of the resource
+	//+kubebuilder:validation:Pattern=`^[a-z0-9-]+$`
+	SlugName string `json:"slugname,omitempty"`
+
+	// Icon determines the icon of the location, see https://www.checklyhq.com/docs/private-locations/ for the options, default location
+	Icon string `json:"icon,omitempty"`
+
+	// KeySecret references the Secret which is created with the API key of the location under the API_KEY key, the key is only available when the location is created
+	KeySecret *corev1.SecretReference `json:"keysecret,omitempty"`
+
+	// DeletionPolicy determines if the Checkly private location is deleted together with the resource, default Delete
+	//+kubebuilder:validation:Enum=Delete;Retain
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+}
+
+// PrivateLocationStatus defines the observed state of PrivateLocation
+type PrivateLocationStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// ID holds the checklyhq.com internal ID of the private location
+	ID string `json:"id"`
+
+	// MaskedKey holds the masked API key of the private location
+	MaskedKey string `json:"maskedKey,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:unservedversion
+//+kubebuilder:printcolumn:name="Slug name",type="string",JSONPath=".spec.slugname"
+//+kubebuilder:printcolumn:name="Key",type="string",JSONPath=".status.maskedKey"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+
+// PrivateLocation is the Schema for the privatelocations API
+type PrivateLocation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PrivateLocationSpec   `json:"spec,omitempty"`
+	Status PrivateLocationStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// PrivateLocationList contains a list of PrivateLocation
+type PrivateLocationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PrivateLocation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PrivateLocation{}, &PrivateLocationList{})
+}
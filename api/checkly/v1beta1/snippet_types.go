@@ -0,0 +1,77 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// SnippetSpec defines the desired state of Snippet
+type SnippetSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// Script holds the inline script of the snippet
+	Script string `json:"script,omitempty"`
+
+	// ConfigMap references the ConfigMap which holds the script, FieldPath is the key inside the ConfigMap, takes precedence over Script
+	ConfigMap corev1.ObjectReference `json:"configmap,omitempty"`
+
+	// DeletionPolicy determines if the Checkly snippet is deleted together with the resource, default Delete
+	//+kubebuilder:validation:Enum=Delete;Retain
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+}
+
+// SnippetStatus defines the observed state of Snippet
+type SnippetStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// ID holds the checklyhq.com internal ID of the snippet
+	ID int64 `json:"id"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:unservedversion
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+
+// Snippet is the Schema for the snippets API
+type Snippet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SnippetSpec   `json:"spec,omitempty"`
+	Status SnippetStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// SnippetList contains a list of Snippet
+type SnippetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Snippet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Snippet{}, &SnippetList{})
+}
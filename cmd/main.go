@@ -17,28 +17,50 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"errors"
 	"flag"
+	"fmt"
+	"maps"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/config"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/checkly/checkly-go-sdk"
 
 	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+	checklyv1beta1 "github.com/checkly/checkly-operator/api/checkly/v1beta1"
+	external "github.com/checkly/checkly-operator/external/checkly"
 	checklycontrollers "github.com/checkly/checkly-operator/internal/controller/checkly"
 	networkingcontrollers "github.com/checkly/checkly-operator/internal/controller/networking"
+	"github.com/checkly/checkly-operator/internal/importer"
+	checklywebhooks "github.com/checkly/checkly-operator/internal/webhook/checkly/v1alpha1"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -51,20 +73,95 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(checklyv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(checklyv1beta1.AddToScheme(scheme))
+	utilruntime.Must(gatewayv1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
 func main() {
+	// The import subcommand prints the resources adopting the checks, groups and alert channels of an account
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		os.Exit(importer.Main(os.Args[2:], os.Stdout, os.Stderr))
+	}
+
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
 	var controllerDomain string
+	var credentialsSecret string
+	var enableIstio bool
+	var watchIngressClasses string
+	var ingressDeletionPolicy string
+	var globalTags string
+	var enableWebhooks bool
+	var defaultGroup string
+	var defaultFrequency int
+	var defaultLocations string
+	var defaultTags string
+	var dryRun bool
+	var observe bool
+	var watchNamespaces string
+	var excludeNamespaces string
+	var resourceSelectorFlag string
+	var resultsPollInterval time.Duration
+	var resyncInterval time.Duration
+	var clusterName string
+	var gcInterval time.Duration
+	var gcDelete bool
+	var maxConcurrentReconcilesFlag string
+	var readCacheTTL time.Duration
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
 	flag.StringVar(&controllerDomain, "controller-domain", "k8s.checklyhq.com", "Domain to use for annotations and finalizers.")
+	flag.StringVar(&credentialsSecret, "credentials-secret", "",
+		"Secret in namespace/name format holding the CHECKLY_API_KEY and CHECKLY_ACCOUNT_ID keys. "+
+			"If empty, the credentials are read from the environment variables of the same name.")
+	flag.BoolVar(&enableIstio, "enable-istio", false,
+		"Create ApiChecks from annotated Istio VirtualServices, requires the Istio CRDs to be installed.")
+	flag.StringVar(&watchIngressClasses, "watch-ingress-class", "",
+		"Comma separated list of ingress classes to watch, ingresses of other classes are ignored. If empty, all ingresses are watched.")
+	flag.StringVar(&ingressDeletionPolicy, "ingress-deletion-policy", checklyv1alpha1.DeletionPolicyDelete,
+		"Deletion policy of the checks created from ingresses, Delete or Retain. The deletion-policy annotation of the ingress takes precedence.")
+	flag.StringVar(&globalTags, "tags", "",
+		"Comma separated list of tags added to every check and group created by the operator, ex. cluster:production.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"Serve the admission webhooks, requires the webhook configurations and a serving certificate, see config/webhook.")
+	flag.StringVar(&defaultGroup, "default-group", "",
+		"Group set by the defaulting webhook on checks without a group, requires --enable-webhooks.")
+	flag.IntVar(&defaultFrequency, "default-frequency", 0,
+		"Frequency in minutes set by the defaulting webhook on checks without a frequency, requires --enable-webhooks. If empty, the default of the check type is used.")
+	flag.StringVar(&defaultLocations, "default-locations", "",
+		"Comma separated list of locations set by the defaulting webhook on groups without locations, requires --enable-webhooks.")
+	flag.StringVar(&defaultTags, "default-tags", "",
+		"Comma separated list of tags set by the defaulting webhook on groups and api checks without tags, requires --enable-webhooks.")
+	flag.BoolVar(&dryRun, "dry-run", false,
+		"Log the changes the controllers would make in checklyhq.com instead of making them, reads are still sent to the API.")
+	flag.BoolVar(&observe, "observe", false,
+		"Only read from checklyhq.com and the cluster, the differences are recorded as Drift events of the resources. Implies --dry-run.")
+	flag.StringVar(&watchNamespaces, "watch-namespaces", "",
+		"Comma separated list of namespaces the namespaced resources are watched in. If empty, all namespaces are watched.")
+	flag.StringVar(&excludeNamespaces, "exclude-namespaces", "",
+		"Comma separated list of namespaces the namespaced resources are ignored in, ex. kube-system.")
+	flag.StringVar(&resourceSelectorFlag, "resource-selector", "",
+		"Label selector of the resources and ingresses the operator reconciles, ex. checkly-operator=canary. If empty, all resources are reconciled.")
+	flag.DurationVar(&resultsPollInterval, "results-poll-interval", 0,
+		"Interval the latest check results are read from checklyhq.com and exposed as metrics, ex. 5m. If empty, the results aren't polled.")
+	flag.DurationVar(&resyncInterval, "resync-interval", 0,
+		"Interval checks, groups and alert channels are pushed to checklyhq.com again, reverting changes made outside of the operator, ex. 1h. "+
+			"Drift is only detected and reported for api checks, groups and alert channels. If empty, only alert channels are resynced, every 10 minutes.")
+	flag.StringVar(&clusterName, "cluster-name", "",
+		"Name of the cluster in the owner tags of the checks and groups, ex. production. If empty, no owner tags are added. Required by --gc-interval.")
+	flag.DurationVar(&gcInterval, "gc-interval", 0,
+		"Interval the checks and groups with the owner tag of a resource which doesn't exist anymore are looked for, ex. 1h. If empty, they aren't looked for.")
+	flag.BoolVar(&gcDelete, "gc-delete", false,
+		"Delete the orphaned checks and groups found by the garbage collection in checklyhq.com instead of only logging them.")
+	flag.StringVar(&maxConcurrentReconcilesFlag, "max-concurrent-reconciles", "",
+		"Comma separated list of controller=count pairs, the number of resources the controller reconciles at the same time, ex. ApiCheck=10,Group=2. A count without a controller applies to the other controllers, ex. 4,ApiCheck=10. If empty, every controller reconciles one resource at a time.")
+	flag.DurationVar(&readCacheTTL, "read-cache-ttl", external.ReadCacheTTL,
+		"Duration the checklyhq.com reads of groups, alert channels and runtimes are shared by the reconciles, ex. 1m. If 0, every reconcile reads them again.")
 	opts := zap.Options{
 		// Development: true,
 	}
@@ -75,8 +172,75 @@ func main() {
 
 	setupLog.Info("Controller domain setup", "value", controllerDomain)
 
+	var credentialsSecretKey types.NamespacedName
+	if credentialsSecret != "" {
+		namespace, name, found := strings.Cut(credentialsSecret, "/")
+		if !found || namespace == "" || name == "" {
+			setupLog.Error(errors.New("credentials secret has to be in namespace/name format"), "checklyhq.com credentials missing", "value", credentialsSecret)
+			os.Exit(1)
+		}
+		credentialsSecretKey = types.NamespacedName{Namespace: namespace, Name: name}
+	}
+
+	var resourceSelector labels.Selector
+	if resourceSelectorFlag != "" {
+		var err error
+		resourceSelector, err = labels.Parse(resourceSelectorFlag)
+		if err != nil {
+			setupLog.Error(err, "invalid --resource-selector flag", "value", resourceSelectorFlag)
+			os.Exit(1)
+		}
+	}
+
+	cacheOptions := cache.Options{}
+	var excludedNamespaces []string
+	if excludeNamespaces != "" {
+		excludedNamespaces = strings.Split(excludeNamespaces, ",")
+	}
+	if watchNamespaces != "" {
+		cacheOptions.DefaultNamespaces = map[string]cache.Config{}
+		for _, namespace := range strings.Split(watchNamespaces, ",") {
+			if !slices.Contains(excludedNamespaces, namespace) {
+				cacheOptions.DefaultNamespaces[namespace] = cache.Config{}
+			}
+		}
+		if len(cacheOptions.DefaultNamespaces) == 0 {
+			setupLog.Error(errors.New("every watched namespace is excluded"), "invalid --watch-namespaces flag", "value", watchNamespaces)
+			os.Exit(1)
+		}
+
+		// The credentials secret is read through the cache by the CredentialsReconciler
+		if _, watched := cacheOptions.DefaultNamespaces[credentialsSecretKey.Namespace]; credentialsSecret != "" && !watched {
+			secretNamespaces := maps.Clone(cacheOptions.DefaultNamespaces)
+			secretNamespaces[credentialsSecretKey.Namespace] = cache.Config{}
+			cacheOptions.ByObject = map[ctrlclient.Object]cache.ByObject{
+				&corev1.Secret{}: {Namespaces: secretNamespaces},
+			}
+		}
+	} else if len(excludedNamespaces) > 0 {
+		var selectors []fields.Selector
+		for _, namespace := range excludedNamespaces {
+			selectors = append(selectors, fields.OneTermNotEqualSelector("metadata.namespace", namespace))
+		}
+		cacheOptions.DefaultNamespaces = map[string]cache.Config{
+			cache.AllNamespaces: {FieldSelector: fields.AndSelectors(selectors...)},
+		}
+	}
+
+	var controllerOptions config.Controller
+	if maxConcurrentReconcilesFlag != "" {
+		var err error
+		controllerOptions, err = maxConcurrentReconciles(maxConcurrentReconcilesFlag)
+		if err != nil {
+			setupLog.Error(err, "invalid --max-concurrent-reconciles flag", "value", maxConcurrentReconcilesFlag)
+			os.Exit(1)
+		}
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Scheme: scheme,
+		Scheme:     scheme,
+		Cache:      cacheOptions,
+		Controller: controllerOptions,
 		Metrics: metricsserver.Options{
 			BindAddress: metricsAddr,
 		},
@@ -90,64 +254,340 @@ func main() {
 	}
 
 	baseUrl := "https://api.checklyhq.com"
-	apiKey := os.Getenv("CHECKLY_API_KEY")
-	if apiKey == "" {
-		setupLog.Error(errors.New("checklyhq.com API key environment variable is undefined"), "checklyhq.com credentials missing")
-		os.Exit(1)
-	}
+	var client checkly.Client
+	var credentials *external.Credentials
+	if credentialsSecret != "" {
+		// The manager's cache isn't started yet, read the secret straight from the API server
+		credentials, err = checklycontrollers.NewCredentialsFromSecret(context.Background(), mgr.GetAPIReader(), credentialsSecretKey)
+		if err != nil {
+			setupLog.Error(err, "checklyhq.com credentials missing", "secret", credentialsSecret)
+			os.Exit(1)
+		}
 
-	accountId := os.Getenv("CHECKLY_ACCOUNT_ID")
-	if accountId == "" {
-		setupLog.Error(errors.New("checklyhq.com Account ID environment variable is undefined"), "checklyhq.com credentials missing")
-		os.Exit(1)
+		// The credentials are reloaded by the CredentialsReconciler when the secret changes
+		client = external.NewClientWithCredentials(baseUrl, credentials)
+	} else {
+		apiKey := os.Getenv("CHECKLY_API_KEY")
+		if apiKey == "" {
+			setupLog.Error(errors.New("checklyhq.com API key environment variable is undefined"), "checklyhq.com credentials missing")
+			os.Exit(1)
+		}
+
+		accountId := os.Getenv("CHECKLY_ACCOUNT_ID")
+		if accountId == "" {
+			setupLog.Error(errors.New("checklyhq.com Account ID environment variable is undefined"), "checklyhq.com credentials missing")
+			os.Exit(1)
+		}
+
+		client = checkly.NewClient(
+			baseUrl,
+			apiKey,
+			external.NewHTTPClient(),
+			nil, //io.Writer to output debug messages
+		)
+
+		client.SetAccountId(accountId)
 	}
 
-	client := checkly.NewClient(
-		baseUrl,
-		apiKey,
-		nil, //custom http client, defaults to http.DefaultClient
-		nil, //io.Writer to output debug messages
-	)
+	reconcilerClient := mgr.GetClient()
+	recorder := mgr.GetEventRecorderFor("checkly-operator")
+	if observe {
+		setupLog.Info("Observer mode, no changes are made in checklyhq.com or the cluster")
+		client = &external.DryRunClient{Client: client, Recorder: recorder}
+		reconcilerClient = checklycontrollers.NewObserverClient(mgr.GetClient(), controllerDomain)
+	} else if dryRun {
+		setupLog.Info("Dry-run mode, no changes are made in checklyhq.com")
+		client = external.NewDryRunClient(client)
+	}
 
-	client.SetAccountId(accountId)
+	if ingressDeletionPolicy != checklyv1alpha1.DeletionPolicyDelete && ingressDeletionPolicy != checklyv1alpha1.DeletionPolicyRetain {
+		setupLog.Error(errors.New("deletion policy must be Delete or Retain"), "invalid --ingress-deletion-policy flag", "value", ingressDeletionPolicy)
+		os.Exit(1)
+	}
+	if globalTags != "" {
+		external.GlobalTags = strings.Split(globalTags, ",")
+	}
+	if gcInterval > 0 && clusterName == "" {
+		setupLog.Error(errors.New("the garbage collection requires a cluster name"), "invalid --gc-interval flag", "value", gcInterval)
+		os.Exit(1)
+	}
+	external.ClusterName = clusterName
+	external.ReadCacheTTL = readCacheTTL
 
+	var ingressClasses []string
+	if watchIngressClasses != "" {
+		ingressClasses = strings.Split(watchIngressClasses, ",")
+	}
 	if err = (&networkingcontrollers.IngressReconciler{
-		Client:           mgr.GetClient(),
+		Client:           reconcilerClient,
 		Scheme:           mgr.GetScheme(),
 		ControllerDomain: controllerDomain,
+		ResourceSelector: resourceSelector,
+		IngressClasses:   ingressClasses,
+		DeletionPolicy:   ingressDeletionPolicy,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Ingress")
 		os.Exit(1)
 	}
+	if err = (&networkingcontrollers.ServiceReconciler{
+		Client:           reconcilerClient,
+		Scheme:           mgr.GetScheme(),
+		ControllerDomain: controllerDomain,
+		ResourceSelector: resourceSelector,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Service")
+		os.Exit(1)
+	}
+	// The Gateway API CRDs are optional, only watch HTTPRoutes if they are installed
+	_, err = mgr.GetRESTMapper().RESTMapping(schema.GroupKind{Group: gatewayv1.GroupName, Kind: "HTTPRoute"}, gatewayv1.GroupVersion.Version)
+	if err == nil {
+		if err = (&networkingcontrollers.HTTPRouteReconciler{
+			Client:           reconcilerClient,
+			Scheme:           mgr.GetScheme(),
+			ControllerDomain: controllerDomain,
+			ResourceSelector: resourceSelector,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "HTTPRoute")
+			os.Exit(1)
+		}
+	} else {
+		setupLog.Info("Gateway API HTTPRoute resource not found, skipping controller", "reason", err.Error())
+	}
+	if enableIstio {
+		if err = (&networkingcontrollers.VirtualServiceReconciler{
+			Client:           reconcilerClient,
+			Scheme:           mgr.GetScheme(),
+			ControllerDomain: controllerDomain,
+			ResourceSelector: resourceSelector,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "VirtualService")
+			os.Exit(1)
+		}
+	}
 	if err = (&checklycontrollers.ApiCheckReconciler{
-		Client:           mgr.GetClient(),
+		Client:           reconcilerClient,
 		Scheme:           mgr.GetScheme(),
 		ApiClient:        client,
 		ControllerDomain: controllerDomain,
+		ResourceSelector: resourceSelector,
+		Recorder:         recorder,
+		ResyncInterval:   resyncInterval,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ApiCheck")
 		os.Exit(1)
 	}
 	if err = (&checklycontrollers.GroupReconciler{
-		Client:           mgr.GetClient(),
+		Client:           reconcilerClient,
 		Scheme:           mgr.GetScheme(),
 		ApiClient:        client,
 		ControllerDomain: controllerDomain,
+		ResourceSelector: resourceSelector,
+		Recorder:         recorder,
+		ResyncInterval:   resyncInterval,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Group")
 		os.Exit(1)
 	}
+	if credentials != nil {
+		if err = (&checklycontrollers.CredentialsReconciler{
+			Client:      reconcilerClient,
+			Secret:      credentialsSecretKey,
+			Credentials: credentials,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Credentials")
+			os.Exit(1)
+		}
+	}
 	if err = (&checklycontrollers.AlertChannelReconciler{
-		Client:           mgr.GetClient(),
+		Client:           reconcilerClient,
 		Scheme:           mgr.GetScheme(),
 		ApiClient:        client,
 		ControllerDomain: controllerDomain,
+		ResourceSelector: resourceSelector,
+		Recorder:         recorder,
+		ResyncInterval:   resyncInterval,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AlertChannel")
 		os.Exit(1)
 	}
+	if err = (&checklycontrollers.BrowserCheckReconciler{
+		Client:           reconcilerClient,
+		Scheme:           mgr.GetScheme(),
+		ApiClient:        client,
+		ControllerDomain: controllerDomain,
+		ResourceSelector: resourceSelector,
+		Recorder:         recorder,
+		ResyncInterval:   resyncInterval,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "BrowserCheck")
+		os.Exit(1)
+	}
+	if err = (&checklycontrollers.HeartbeatCheckReconciler{
+		Client:           reconcilerClient,
+		Scheme:           mgr.GetScheme(),
+		ApiClient:        client,
+		ControllerDomain: controllerDomain,
+		ResourceSelector: resourceSelector,
+		Recorder:         recorder,
+		ResyncInterval:   resyncInterval,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "HeartbeatCheck")
+		os.Exit(1)
+	}
+	if err = (&checklycontrollers.MultiStepCheckReconciler{
+		Client:           reconcilerClient,
+		Scheme:           mgr.GetScheme(),
+		ApiClient:        client,
+		ControllerDomain: controllerDomain,
+		ResourceSelector: resourceSelector,
+		Recorder:         recorder,
+		ResyncInterval:   resyncInterval,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "MultiStepCheck")
+		os.Exit(1)
+	}
+	if err = (&checklycontrollers.DashboardReconciler{
+		Client:           reconcilerClient,
+		Scheme:           mgr.GetScheme(),
+		ApiClient:        client,
+		ControllerDomain: controllerDomain,
+		ResourceSelector: resourceSelector,
+		Recorder:         recorder,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Dashboard")
+		os.Exit(1)
+	}
+	if err = (&checklycontrollers.PrivateLocationReconciler{
+		Client:           reconcilerClient,
+		Scheme:           mgr.GetScheme(),
+		ApiClient:        client,
+		ControllerDomain: controllerDomain,
+		ResourceSelector: resourceSelector,
+		Recorder:         recorder,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "PrivateLocation")
+		os.Exit(1)
+	}
+	if err = (&checklycontrollers.SnippetReconciler{
+		Client:           reconcilerClient,
+		Scheme:           mgr.GetScheme(),
+		ApiClient:        client,
+		ControllerDomain: controllerDomain,
+		ResourceSelector: resourceSelector,
+		Recorder:         recorder,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Snippet")
+		os.Exit(1)
+	}
+	if err = (&checklycontrollers.EnvironmentVariableReconciler{
+		Client:           reconcilerClient,
+		Scheme:           mgr.GetScheme(),
+		ApiClient:        client,
+		ControllerDomain: controllerDomain,
+		ResourceSelector: resourceSelector,
+		Recorder:         recorder,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "EnvironmentVariable")
+		os.Exit(1)
+	}
+	if err = (&checklycontrollers.CheckTriggerReconciler{
+		Client:           reconcilerClient,
+		Scheme:           mgr.GetScheme(),
+		ApiClient:        client,
+		ControllerDomain: controllerDomain,
+		ResourceSelector: resourceSelector,
+		Recorder:         recorder,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "CheckTrigger")
+		os.Exit(1)
+	}
+	if err = (&checklycontrollers.ClusterApiCheckReconciler{
+		Client:           reconcilerClient,
+		Scheme:           mgr.GetScheme(),
+		ApiClient:        client,
+		ControllerDomain: controllerDomain,
+		ResourceSelector: resourceSelector,
+		Recorder:         recorder,
+		ResyncInterval:   resyncInterval,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterApiCheck")
+		os.Exit(1)
+	}
+	if enableWebhooks {
+		defaults := checklywebhooks.Defaults{
+			Group:     defaultGroup,
+			Frequency: defaultFrequency,
+		}
+		if defaultLocations != "" {
+			defaults.Locations = strings.Split(defaultLocations, ",")
+		}
+		if defaultTags != "" {
+			defaults.Tags = strings.Split(defaultTags, ",")
+		}
+		if err = defaults.Validate(); err != nil {
+			setupLog.Error(err, "invalid webhook defaults")
+			os.Exit(1)
+		}
+
+		// The conversion webhook converts every kind between v1beta1 and the v1alpha1 storage version
+		mgr.GetWebhookServer().Register("/convert", conversion.NewWebhookHandler(mgr.GetScheme()))
+
+		if err = checklywebhooks.SetupApiCheckWebhookWithManager(mgr, defaults); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ApiCheck")
+			os.Exit(1)
+		}
+		if err = checklywebhooks.SetupClusterApiCheckWebhookWithManager(mgr, defaults); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ClusterApiCheck")
+			os.Exit(1)
+		}
+		if err = checklywebhooks.SetupBrowserCheckWebhookWithManager(mgr, defaults); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "BrowserCheck")
+			os.Exit(1)
+		}
+		if err = checklywebhooks.SetupMultiStepCheckWebhookWithManager(mgr, defaults); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "MultiStepCheck")
+			os.Exit(1)
+		}
+		if err = checklywebhooks.SetupGroupWebhookWithManager(mgr, defaults); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Group")
+			os.Exit(1)
+		}
+		if err = checklywebhooks.SetupAlertChannelWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "AlertChannel")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
+	if resultsPollInterval > 0 {
+		if err = mgr.Add(&checklycontrollers.ResultsPoller{
+			Client:    mgr.GetClient(),
+			ApiClient: client,
+			Interval:  resultsPollInterval,
+		}); err != nil {
+			setupLog.Error(err, "unable to add the results poller")
+			os.Exit(1)
+		}
+	}
+
+	if gcInterval > 0 {
+		// Without the credentials secret the credentials are the environment variables
+		listerCredentials := credentials
+		if listerCredentials == nil {
+			listerCredentials = external.NewCredentials(os.Getenv("CHECKLY_API_KEY"), os.Getenv("CHECKLY_ACCOUNT_ID"))
+		}
+		if err = mgr.Add(&checklycontrollers.GarbageCollector{
+			Reader:    mgr.GetAPIReader(),
+			Lister:    external.NewLister(baseUrl, listerCredentials),
+			ApiClient: client,
+			Interval:  gcInterval,
+			Delete:    gcDelete,
+		}); err != nil {
+			setupLog.Error(err, "unable to add the garbage collector")
+			os.Exit(1)
+		}
+	}
+
 	setupLog.V(1).Info("starting health endpoint")
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
@@ -166,3 +606,54 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// controllerKinds are the kinds of the controllers --max-concurrent-reconciles refers to, the manager looks up
+// the concurrency of a controller by the kind it reconciles
+var controllerKinds = []schema.GroupKind{
+	{Group: networkingv1.GroupName, Kind: "Ingress"},
+	{Group: corev1.GroupName, Kind: "Service"},
+	{Group: gatewayv1.GroupName, Kind: "HTTPRoute"},
+	networkingcontrollers.VirtualServiceGVK.GroupKind(),
+	checklyv1alpha1.GroupVersion.WithKind("ApiCheck").GroupKind(),
+	checklyv1alpha1.GroupVersion.WithKind("ClusterApiCheck").GroupKind(),
+	checklyv1alpha1.GroupVersion.WithKind("BrowserCheck").GroupKind(),
+	checklyv1alpha1.GroupVersion.WithKind("MultiStepCheck").GroupKind(),
+	checklyv1alpha1.GroupVersion.WithKind("HeartbeatCheck").GroupKind(),
+	checklyv1alpha1.GroupVersion.WithKind("CheckTrigger").GroupKind(),
+	checklyv1alpha1.GroupVersion.WithKind("Group").GroupKind(),
+	checklyv1alpha1.GroupVersion.WithKind("AlertChannel").GroupKind(),
+	checklyv1alpha1.GroupVersion.WithKind("Dashboard").GroupKind(),
+	checklyv1alpha1.GroupVersion.WithKind("PrivateLocation").GroupKind(),
+	checklyv1alpha1.GroupVersion.WithKind("Snippet").GroupKind(),
+	checklyv1alpha1.GroupVersion.WithKind("EnvironmentVariable").GroupKind(),
+}
+
+// maxConcurrentReconciles returns the controller options of the --max-concurrent-reconciles flag, the
+// controllers are named by their kind, case insensitive
+func maxConcurrentReconciles(value string) (config.Controller, error) {
+	options := config.Controller{GroupKindConcurrency: map[string]int{}}
+	for _, pair := range strings.Split(value, ",") {
+		name, count, found := strings.Cut(pair, "=")
+		if !found {
+			count = name
+		}
+		concurrency, err := strconv.Atoi(count)
+		if err != nil || concurrency < 1 {
+			return options, fmt.Errorf("%q isn't a positive number of concurrent reconciles", count)
+		}
+
+		if !found {
+			options.MaxConcurrentReconciles = concurrency
+			continue
+		}
+		i := slices.IndexFunc(controllerKinds, func(kind schema.GroupKind) bool {
+			return strings.EqualFold(kind.Kind, name)
+		})
+		if i < 0 {
+			return options, fmt.Errorf("unknown controller %q", name)
+		}
+		options.GroupKindConcurrency[controllerKinds[i].String()] = concurrency
+	}
+
+	return options, nil
+}
@@ -2,24 +2,166 @@ package external
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/checkly/checkly-go-sdk"
 	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
 )
 
-func checklyAlertChannel(alertChannel *checklyv1alpha1.AlertChannel, opsGenieConfig checkly.AlertChannelOpsgenie) (ac checkly.AlertChannel, err error) {
-	sslExpiry := false
+// AlertChannelConfig holds the configuration of the alert channel types which read values from Secrets,
+// the values are filled in by the controller
+type AlertChannelConfig struct {
+	OpsGenie  checkly.AlertChannelOpsgenie
+	Slack     checkly.AlertChannelSlack
+	Webhook   *checkly.AlertChannelWebhook
+	Pagerduty checkly.AlertChannelPagerduty
+}
+
+// webhookTypeTelegram is used to identify a Telegram webhook, the SDK has no constant for it
+const webhookTypeTelegram = "WEBHOOK_TELEGRAM"
+
+// defaultTelegramMessage is the message checklyhq.com uses for new Telegram alert channels
+const defaultTelegramMessage = `<b>{{ALERT_TITLE}}</b> at {{STARTED_AT}} in {{RUN_LOCATION}} ({{RESPONSE_TIME}}ms)
+<a href="{{RESULT_LINK}}">View check result</a>`
+
+// TelegramWebhook returns the webhook which posts alerts with the message to a Telegram chat through the bot
+func TelegramWebhook(name string, botToken string, chatID string, message string) *checkly.AlertChannelWebhook {
+	return &checkly.AlertChannelWebhook{
+		Name:        name,
+		URL:         fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", botToken),
+		WebhookType: webhookTypeTelegram,
+		Method:      "POST",
+		Template:    fmt.Sprintf("chat_id=%s&parse_mode=HTML&text=%s", chatID, checkValueString(message, defaultTelegramMessage)),
+	}
+}
+
+// webhookTypeMSTeams is used to identify a Microsoft Teams webhook, the SDK has no constant for it
+const webhookTypeMSTeams = "WEBHOOK_MSTEAMS"
+
+// defaultMSTeamsTemplate is a message card with the details of the alert and a link to the check result
+const defaultMSTeamsTemplate = `{
+  "@type": "MessageCard",
+  "@context": "https://schema.org/extensions",
+  "themeColor": "0076D7",
+  "summary": "{{ALERT_TITLE}}",
+  "sections": [
+    {
+      "activityTitle": "{{ALERT_TITLE}}",
+      "facts": [
+        { "name": "Check", "value": "{{CHECK_NAME}}" },
+        { "name": "Location", "value": "{{RUN_LOCATION}}" },
+        { "name": "Response time", "value": "{{RESPONSE_TIME}}ms" },
+        { "name": "Started at", "value": "{{STARTED_AT}}" }
+      ],
+      "markdown": true
+    }
+  ],
+  "potentialAction": [
+    {
+      "@type": "OpenUri",
+      "name": "View check result",
+      "targets": [{ "os": "default", "uri": "{{RESULT_LINK}}" }]
+    }
+  ]
+}`
+
+// MSTeamsWebhook returns the webhook which posts alerts with the template to a Microsoft Teams incoming webhook
+func MSTeamsWebhook(name string, url string, template string) *checkly.AlertChannelWebhook {
+	return &checkly.AlertChannelWebhook{
+		Name:        name,
+		URL:         url,
+		WebhookType: webhookTypeMSTeams,
+		Method:      "POST",
+		Template:    checkValueString(template, defaultMSTeamsTemplate),
+	}
+}
+
+// webhookTypeIncidentIO is used to identify an incident.io webhook, the SDK has no constant for it
+const webhookTypeIncidentIO = "WEBHOOK_INCIDENTIO"
+
+// defaultIncidentIOTemplate is an incident.io alert per check which is resolved when the check recovers
+const defaultIncidentIOTemplate = `{
+  "title": "{{ALERT_TITLE}}",
+  "description": "{{ALERT_TITLE}} at {{STARTED_AT}} in {{RUN_LOCATION}} {{RESPONSE_TIME}}ms Link: {{RESULT_LINK}}",
+  "deduplication_key": "{{CHECK_ID}}",
+  "metadata": {
+    "alertType": "{{ALERT_TYPE}}",
+    "check_result_id": "{{CHECK_RESULT_ID}}",
+    "resultLink": "{{RESULT_LINK}}"
+  },
+  {{#contains ALERT_TYPE "RECOVERY"}}
+  "status": "resolved"
+  {{else}}
+  "status": "firing"
+  {{/contains}}
+}`
+
+// IncidentIOWebhook returns the webhook which sends alerts with the template to an incident.io HTTP alert source
+func IncidentIOWebhook(name string, url string, apiKey string, template string) *checkly.AlertChannelWebhook {
+	return &checkly.AlertChannelWebhook{
+		Name:        name,
+		URL:         url,
+		WebhookType: webhookTypeIncidentIO,
+		Method:      "POST",
+		Headers:     []checkly.KeyValue{{Key: "Authorization", Value: "Bearer " + apiKey}},
+		Template:    checkValueString(template, defaultIncidentIOTemplate),
+	}
+}
+
+func checklyAlertChannel(alertChannel *checklyv1alpha1.AlertChannel, config AlertChannelConfig) (ac checkly.AlertChannel, err error) {
+	sslExpiryThreshold := checkValueInt(alertChannel.Spec.SSLExpiryThreshold, 30)
 
 	ac = checkly.AlertChannel{
-		SendRecovery: &alertChannel.Spec.SendRecovery,
-		SendFailure:  &alertChannel.Spec.SendFailure,
-		SSLExpiry:    &sslExpiry,
+		SendRecovery:       &alertChannel.Spec.SendRecovery,
+		SendFailure:        &alertChannel.Spec.SendFailure,
+		SendDegraded:       &alertChannel.Spec.SendDegraded,
+		SSLExpiry:          &alertChannel.Spec.SSLExpiry,
+		SSLExpiryThreshold: &sslExpiryThreshold,
 	}
 
-	if opsGenieConfig != (checkly.AlertChannelOpsgenie{}) {
+	if config.OpsGenie != (checkly.AlertChannelOpsgenie{}) {
 		ac.Type = "OPSGENIE" // Type has to be all caps, see https://developers.checklyhq.com/reference/postv1alertchannels
-		ac.Opsgenie = &opsGenieConfig
+		ac.Opsgenie = &config.OpsGenie
+		return
+	}
+
+	if config.Pagerduty != (checkly.AlertChannelPagerduty{}) {
+		ac.Type = checkly.AlertTypePagerduty
+		ac.Pagerduty = &config.Pagerduty
+		return
+	}
+
+	if config.Webhook != nil {
+		webhook := *config.Webhook
+		webhook.Method = checkValueString(webhook.Method, "POST")
+
+		ac.Type = checkly.AlertTypeWebhook
+		ac.Webhook = &webhook
+		return
+	}
+
+	if alertChannel.Spec.SMS != nil {
+		ac.Type = checkly.AlertTypeSMS
+		ac.SMS = &checkly.AlertChannelSMS{
+			Name:   checkValueString(alertChannel.Spec.SMS.Name, alertChannel.Name),
+			Number: alertChannel.Spec.SMS.Number,
+		}
+		return
+	}
+
+	if alertChannel.Spec.Call != nil {
+		ac.Type = checkly.AlertTypeCall
+		ac.CALL = &checkly.AlertChannelCall{
+			Name:   checkValueString(alertChannel.Spec.Call.Name, alertChannel.Name),
+			Number: alertChannel.Spec.Call.Number,
+		}
+		return
+	}
+
+	if config.Slack != (checkly.AlertChannelSlack{}) {
+		ac.Type = checkly.AlertTypeSlack
+		ac.Slack = &config.Slack
 		return
 	}
 
@@ -33,9 +175,9 @@ func checklyAlertChannel(alertChannel *checklyv1alpha1.AlertChannel, opsGenieCon
 	return
 }
 
-func CreateAlertChannel(alertChannel *checklyv1alpha1.AlertChannel, opsGenieConfig checkly.AlertChannelOpsgenie, client checkly.Client) (ID int64, err error) {
+func CreateAlertChannel(alertChannel *checklyv1alpha1.AlertChannel, config AlertChannelConfig, client checkly.Client) (ID int64, err error) {
 
-	ac, err := checklyAlertChannel(alertChannel, opsGenieConfig)
+	ac, err := checklyAlertChannel(alertChannel, config)
 	if err != nil {
 		return
 	}
@@ -53,8 +195,8 @@ func CreateAlertChannel(alertChannel *checklyv1alpha1.AlertChannel, opsGenieConf
 	return
 }
 
-func UpdateAlertChannel(alertChannel *checklyv1alpha1.AlertChannel, opsGenieConfig checkly.AlertChannelOpsgenie, client checkly.Client) (err error) {
-	ac, err := checklyAlertChannel(alertChannel, opsGenieConfig)
+func UpdateAlertChannel(alertChannel *checklyv1alpha1.AlertChannel, config AlertChannelConfig, client checkly.Client) (err error) {
+	ac, err := checklyAlertChannel(alertChannel, config)
 	if err != nil {
 		return
 	}
@@ -26,9 +26,9 @@ func TestChecklyAlertChannel(t *testing.T) {
 		},
 	}
 
-	opsGenieConfigEmpty := checkly.AlertChannelOpsgenie{}
+	configEmpty := AlertChannelConfig{}
 
-	returned, err := checklyAlertChannel(&dataEmpty, opsGenieConfigEmpty)
+	returned, err := checklyAlertChannel(&dataEmpty, configEmpty)
 	if err != nil {
 		t.Errorf("Expected no error, got %e", err)
 	}
@@ -37,12 +37,30 @@ func TestChecklyAlertChannel(t *testing.T) {
 		t.Errorf("Expected empty Opsgenie config, got %s", returned.Opsgenie)
 	}
 
+	if *returned.SendDegraded || *returned.SSLExpiry || *returned.SSLExpiryThreshold != 30 {
+		t.Errorf("Expected no degraded and SSL expiry alerts with a threshold of %d, got %t, %t, %d", 30, *returned.SendDegraded, *returned.SSLExpiry, *returned.SSLExpiryThreshold)
+	}
+
+	dataSend := dataEmpty
+	dataSend.Spec.SendDegraded = true
+	dataSend.Spec.SSLExpiry = true
+	dataSend.Spec.SSLExpiryThreshold = 14
+
+	returned, err = checklyAlertChannel(&dataSend, configEmpty)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+
+	if !*returned.SendDegraded || !*returned.SSLExpiry || *returned.SSLExpiryThreshold != 14 {
+		t.Errorf("Expected degraded and SSL expiry alerts with a threshold of %d, got %t, %t, %d", 14, *returned.SendDegraded, *returned.SSLExpiry, *returned.SSLExpiryThreshold)
+	}
+
 	dataEmail := dataEmpty
 	dataEmail.Spec.Email = checkly.AlertChannelEmail{
 		Address: acEmailAddress,
 	}
 
-	returned, err = checklyAlertChannel(&dataEmail, opsGenieConfigEmpty)
+	returned, err = checklyAlertChannel(&dataEmail, configEmpty)
 	if err != nil {
 		t.Errorf("Expected no error, got %e", err)
 	}
@@ -51,11 +69,13 @@ func TestChecklyAlertChannel(t *testing.T) {
 		t.Errorf("Expected %s, got %s", acEmailAddress, returned.Email.Address)
 	}
 
-	dataOpsGenieFull := checkly.AlertChannelOpsgenie{
-		APIKey:   "foo-bar",
-		Region:   "US",
-		Priority: "999",
-		Name:     "baz",
+	dataOpsGenieFull := AlertChannelConfig{
+		OpsGenie: checkly.AlertChannelOpsgenie{
+			APIKey:   "foo-bar",
+			Region:   "US",
+			Priority: "999",
+			Name:     "baz",
+		},
 	}
 
 	returned, err = checklyAlertChannel(&dataEmpty, dataOpsGenieFull)
@@ -79,6 +99,158 @@ func TestChecklyAlertChannel(t *testing.T) {
 		t.Errorf("Expected nil, got %s", returned.Email)
 	}
 
+	dataSlackFull := AlertChannelConfig{
+		Slack: checkly.AlertChannelSlack{
+			WebhookURL: "https://hooks.slack.com/services/foo",
+			Channel:    "#alerts",
+		},
+	}
+
+	returned, err = checklyAlertChannel(&dataEmpty, dataSlackFull)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+
+	if returned.Type != checkly.AlertTypeSlack {
+		t.Errorf("Expected %s, got %s", checkly.AlertTypeSlack, returned.Type)
+	}
+
+	if returned.Slack == nil || returned.Slack.Channel != "#alerts" {
+		t.Errorf("Expected %v, got %v", dataSlackFull.Slack, returned.Slack)
+	}
+
+	dataWebhook := AlertChannelConfig{
+		Webhook: &checkly.AlertChannelWebhook{
+			Name:          acName,
+			URL:           "https://foo.bar/alerts",
+			Template:      `{"title": "{{ALERT_TITLE}}"}`,
+			WebhookSecret: "baz",
+		},
+	}
+
+	returned, err = checklyAlertChannel(&dataEmpty, dataWebhook)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+
+	if returned.Type != checkly.AlertTypeWebhook || returned.Webhook == nil {
+		t.Fatalf("Expected %s config, got %s", checkly.AlertTypeWebhook, returned.Type)
+	}
+
+	if returned.Webhook.Method != "POST" {
+		t.Errorf("Expected %s, got %s", "POST", returned.Webhook.Method)
+	}
+
+	if returned.Webhook.WebhookSecret != "baz" || returned.Webhook.Template != dataWebhook.Webhook.Template {
+		t.Errorf("Expected %v, got %v", dataWebhook.Webhook, returned.Webhook)
+	}
+
+	dataPagerduty := AlertChannelConfig{
+		Pagerduty: checkly.AlertChannelPagerduty{
+			ServiceKey:  "foo-bar",
+			Account:     "baz",
+			ServiceName: "checkout",
+		},
+	}
+
+	returned, err = checklyAlertChannel(&dataEmpty, dataPagerduty)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+
+	if returned.Type != checkly.AlertTypePagerduty || returned.Pagerduty == nil || *returned.Pagerduty != dataPagerduty.Pagerduty {
+		t.Errorf("Expected %s config %v, got %s", checkly.AlertTypePagerduty, dataPagerduty.Pagerduty, returned.Type)
+	}
+
+	dataSMS := dataEmpty
+	dataSMS.Spec.SMS = &checklyv1alpha1.AlertChannelPhone{
+		Number: "+31612345678",
+	}
+
+	returned, err = checklyAlertChannel(&dataSMS, configEmpty)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+
+	if returned.Type != checkly.AlertTypeSMS || returned.SMS == nil {
+		t.Fatalf("Expected %s config, got %s", checkly.AlertTypeSMS, returned.Type)
+	}
+
+	if returned.SMS.Name != acName || returned.SMS.Number != dataSMS.Spec.SMS.Number {
+		t.Errorf("Expected %s with %s, got %v", acName, dataSMS.Spec.SMS.Number, returned.SMS)
+	}
+
+	dataCall := dataEmpty
+	dataCall.Spec.Call = &checklyv1alpha1.AlertChannelPhone{
+		Number: "+31612345678",
+		Name:   "on-call",
+	}
+
+	returned, err = checklyAlertChannel(&dataCall, configEmpty)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+
+	if returned.Type != checkly.AlertTypeCall || returned.CALL == nil || returned.CALL.Name != "on-call" {
+		t.Errorf("Expected %s config for %s, got %s", checkly.AlertTypeCall, "on-call", returned.Type)
+	}
+
+}
+
+func TestTelegramWebhook(t *testing.T) {
+	webhook := TelegramWebhook("foo", "123:abc", "-100", "")
+
+	if webhook.URL != "https://api.telegram.org/bot123:abc/sendMessage" {
+		t.Errorf("Expected %s, got %s", "https://api.telegram.org/bot123:abc/sendMessage", webhook.URL)
+	}
+
+	if webhook.WebhookType != webhookTypeTelegram {
+		t.Errorf("Expected %s, got %s", webhookTypeTelegram, webhook.WebhookType)
+	}
+
+	expected := "chat_id=-100&parse_mode=HTML&text=" + defaultTelegramMessage
+	if webhook.Template != expected {
+		t.Errorf("Expected %s, got %s", expected, webhook.Template)
+	}
+
+	webhook = TelegramWebhook("foo", "123:abc", "-100", "{{ALERT_TITLE}}")
+	if webhook.Template != "chat_id=-100&parse_mode=HTML&text={{ALERT_TITLE}}" {
+		t.Errorf("Expected %s, got %s", "chat_id=-100&parse_mode=HTML&text={{ALERT_TITLE}}", webhook.Template)
+	}
+}
+
+func TestMSTeamsWebhook(t *testing.T) {
+	webhook := MSTeamsWebhook("foo", "https://foo.webhook.office.com/bar", "")
+
+	if webhook.WebhookType != webhookTypeMSTeams {
+		t.Errorf("Expected %s, got %s", webhookTypeMSTeams, webhook.WebhookType)
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal([]byte(webhook.Template), &payload); err != nil {
+		t.Errorf("Expected a JSON template, got %e", err)
+	}
+
+	webhook = MSTeamsWebhook("foo", "https://foo.webhook.office.com/bar", `{"text": "{{ALERT_TITLE}}"}`)
+	if webhook.Template != `{"text": "{{ALERT_TITLE}}"}` {
+		t.Errorf("Expected %s, got %s", `{"text": "{{ALERT_TITLE}}"}`, webhook.Template)
+	}
+}
+
+func TestIncidentIOWebhook(t *testing.T) {
+	webhook := IncidentIOWebhook("foo", "https://api.incident.io/v2/alert_events/http/bar", "baz", "")
+
+	if webhook.WebhookType != webhookTypeIncidentIO {
+		t.Errorf("Expected %s, got %s", webhookTypeIncidentIO, webhook.WebhookType)
+	}
+
+	if len(webhook.Headers) != 1 || webhook.Headers[0].Value != "Bearer baz" {
+		t.Errorf("Expected %s, got %v", "Bearer baz", webhook.Headers)
+	}
+
+	if webhook.Template != defaultIncidentIOTemplate {
+		t.Errorf("Expected %s, got %s", defaultIncidentIOTemplate, webhook.Template)
+	}
 }
 
 func TestAlertChannelActions(t *testing.T) {
@@ -100,7 +272,7 @@ func TestAlertChannelActions(t *testing.T) {
 		},
 	}
 
-	opsGenieConfigEmpty := checkly.AlertChannelOpsgenie{}
+	configEmpty := AlertChannelConfig{}
 
 	// Test errors
 	testClient := checkly.NewClient(
@@ -112,13 +284,13 @@ func TestAlertChannelActions(t *testing.T) {
 	testClient.SetAccountId("1234567890")
 
 	// Create fail
-	_, err := CreateAlertChannel(testData, opsGenieConfigEmpty, testClient)
+	_, err := CreateAlertChannel(testData, configEmpty, testClient)
 	if err == nil {
 		t.Error("Expected error, got none")
 	}
 
 	// Update fail
-	err = UpdateAlertChannel(testData, opsGenieConfigEmpty, testClient)
+	err = UpdateAlertChannel(testData, configEmpty, testClient)
 	if err == nil {
 		t.Error("Expected error, got none")
 	}
@@ -159,7 +331,7 @@ func TestAlertChannelActions(t *testing.T) {
 	}()
 
 	// Create success
-	testID, err := CreateAlertChannel(testData, opsGenieConfigEmpty, testClient)
+	testID, err := CreateAlertChannel(testData, configEmpty, testClient)
 	if err != nil {
 		t.Errorf("Expected no error, got %e", err)
 	}
@@ -168,7 +340,7 @@ func TestAlertChannelActions(t *testing.T) {
 	}
 
 	// Update success
-	err = UpdateAlertChannel(testData, opsGenieConfigEmpty, testClient)
+	err = UpdateAlertChannel(testData, configEmpty, testClient)
 	if err != nil {
 		t.Errorf("Expected no error, got %e", err)
 	}
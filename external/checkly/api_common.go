@@ -16,7 +16,62 @@ limitations under the License.
 
 package external
 
-import "fmt"
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/checkly/checkly-go-sdk"
+)
+
+// GlobalTags are added to every check and group created by the operator, ex. the name of the cluster
+var GlobalTags []string
+
+// ClusterName identifies the cluster in the owner tags of the checks and groups, they aren't tagged if empty
+var ClusterName string
+
+// ownerTagPrefix starts the owner tags, the rest is the cluster, the namespace and the name of the resource
+const ownerTagPrefix = "checkly-operator-owner:"
+
+// OwnerTag returns the tag of the checks and groups created for the resource in this cluster, cluster scoped
+// resources have an empty namespace
+func OwnerTag(namespace, name string) string {
+	return fmt.Sprintf("%s%s/%s/%s", ownerTagPrefix, ClusterName, namespace, name)
+}
+
+// IsOwnerTag returns whether the tag is the owner tag of a resource in any cluster
+func IsOwnerTag(tag string) bool {
+	return strings.HasPrefix(tag, ownerTagPrefix)
+}
+
+// Owner returns the namespace and the name of the resource in this cluster whose owner tag is in the tags
+func Owner(tags []string) (namespace, name string, found bool) {
+	if ClusterName == "" {
+		return "", "", false
+	}
+	for _, tag := range tags {
+		owner, isOwner := strings.CutPrefix(tag, ownerTagPrefix+ClusterName+"/")
+		if !isOwner {
+			continue
+		}
+		namespace, name, found = strings.Cut(owner, "/")
+		if found && name != "" {
+			return namespace, name, true
+		}
+	}
+
+	return "", "", false
+}
+
+// withOwnerTag adds the owner tag of the resource to the tags, retained checks and groups outlive their
+// resource, so they aren't tagged and the garbage collection leaves them alone
+func withOwnerTag(tags []string, namespace, name string, retained bool) []string {
+	if ClusterName == "" || retained {
+		return tags
+	}
+
+	return append(tags, OwnerTag(namespace, name))
+}
 
 func checkValueString(x string, y string) (value string) {
 	if x == "" {
@@ -45,11 +100,60 @@ func checkValueArray(x []string, y []string) (value []string) {
 	return
 }
 
+// checkValueEnvironmentVariables never returns nil, so removed environment variables are removed from checklyhq.com too
+func checkValueEnvironmentVariables(x []checkly.EnvironmentVariable) []checkly.EnvironmentVariable {
+	if x == nil {
+		return []checkly.EnvironmentVariable{}
+	}
+	return x
+}
+
+// getTags returns the labels as key:value tags, sorted so the payloads don't change with the order of the map
 func getTags(labels map[string]string) (tags []string) {
 
 	for k, v := range labels {
 		tags = append(tags, fmt.Sprintf("%s:%s", k, v))
 	}
+	slices.Sort(tags)
+
+	return
+}
+
+// operatorTags returns the tags of the labels, the extra tags, the global tags and the checkly-operator tag,
+// duplicates are dropped
+func operatorTags(labels map[string]string, extra []string) (tags []string) {
+	all := getTags(labels)
+	all = append(all, extra...)
+	all = append(all, GlobalTags...)
+	all = append(all, "checkly-operator")
+
+	seen := make(map[string]bool, len(all))
+	for _, tag := range all {
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
 
 	return
 }
+
+func defaultAlertSettings() checkly.AlertSettings {
+	return checkly.AlertSettings{
+		EscalationType: checkly.RunBased,
+		RunBasedEscalation: checkly.RunBasedEscalation{
+			FailedRunThreshold: 5,
+		},
+		TimeBasedEscalation: checkly.TimeBasedEscalation{
+			MinutesFailingThreshold: 5,
+		},
+		Reminders: checkly.Reminders{
+			Interval: 5,
+		},
+		SSLCertificates: checkly.SSLCertificates{
+			Enabled:        false,
+			AlertThreshold: 3,
+		},
+	}
+}
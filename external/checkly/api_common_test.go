@@ -93,3 +93,52 @@ func TestGetTags(t *testing.T) {
 	}
 
 }
+
+func TestOperatorTags(t *testing.T) {
+	GlobalTags = []string{"cluster:foo"}
+	defer func() { GlobalTags = nil }()
+
+	response := operatorTags(map[string]string{"foo": "bar"}, []string{"team:baz", "cluster:foo"})
+	expected := []string{"foo:bar", "team:baz", "cluster:foo", "checkly-operator"}
+	if len(response) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, response)
+	}
+
+	for i, v := range response {
+		if v != expected[i] {
+			t.Errorf("Expected %s, got %s", expected[i], v)
+		}
+	}
+}
+
+func TestOwnerTag(t *testing.T) {
+	tags := withOwnerTag([]string{"foo"}, "bar", "baz", false)
+	if len(tags) != 1 {
+		t.Errorf("Expected no owner tag without a cluster name, got %v", tags)
+	}
+
+	ClusterName = "production"
+	defer func() { ClusterName = "" }()
+
+	tags = withOwnerTag([]string{"foo"}, "bar", "baz", false)
+	if len(tags) != 2 || tags[1] != "checkly-operator-owner:production/bar/baz" {
+		t.Errorf("Expected the owner tag, got %v", tags)
+	}
+
+	if tags := withOwnerTag([]string{"foo"}, "bar", "baz", true); len(tags) != 1 {
+		t.Errorf("Expected no owner tag of a retained check, got %v", tags)
+	}
+
+	namespace, name, found := Owner([]string{"foo", "checkly-operator-owner:staging/bar/qux", "checkly-operator-owner:production//baz"})
+	if !found || namespace != "" || name != "baz" {
+		t.Errorf("Expected the cluster scoped owner baz, got %q %q %t", namespace, name, found)
+	}
+
+	if _, _, found := Owner([]string{"checkly-operator-owner:staging/bar/baz"}); found {
+		t.Error("Expected no owner of another cluster")
+	}
+
+	if !IsOwnerTag("checkly-operator-owner:staging/bar/baz") || IsOwnerTag("checkly-operator") {
+		t.Error("Expected only owner tags to be recognized")
+	}
+}
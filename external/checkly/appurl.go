@@ -0,0 +1,50 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import "fmt"
+
+// appURL is the checklyhq.com web app the resources are linked to
+const appURL = "https://app.checklyhq.com"
+
+// CheckURL returns the link to the check in the checklyhq.com app, empty if the check isn't created
+func CheckURL(ID string) string {
+	if ID == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("%s/checks/%s", appURL, ID)
+}
+
+// GroupURL returns the link to the group in the checklyhq.com app, empty if the group isn't created
+func GroupURL(ID int64) string {
+	if ID == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("%s/check-groups/%d", appURL, ID)
+}
+
+// AlertChannelURL returns the link to the alert channel in the checklyhq.com app, empty if the
+// alert channel isn't created
+func AlertChannelURL(ID int64) string {
+	if ID == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("%s/alerts/settings/channels/%d", appURL, ID)
+}
@@ -0,0 +1,35 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import "testing"
+
+func TestAppURLs(t *testing.T) {
+	if url := CheckURL("abc"); url != "https://app.checklyhq.com/checks/abc" {
+		t.Errorf("Unexpected check URL %s", url)
+	}
+	if url := GroupURL(1); url != "https://app.checklyhq.com/check-groups/1" {
+		t.Errorf("Unexpected group URL %s", url)
+	}
+	if url := AlertChannelURL(2); url != "https://app.checklyhq.com/alerts/settings/channels/2" {
+		t.Errorf("Unexpected alert channel URL %s", url)
+	}
+
+	if CheckURL("") != "" || GroupURL(0) != "" || AlertChannelURL(0) != "" {
+		t.Error("Expected no URLs for resources which aren't created")
+	}
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/checkly/checkly-go-sdk"
+)
+
+// BrowserCheck is a struct for the internal packages to help put together the checkly browser check
+type BrowserCheck struct {
+	Name      string
+	Namespace string
+	Frequency int
+	Locations []string
+	Script    string
+	GroupID   int64
+	ID        string
+	Muted     bool
+	Paused    bool
+	Labels    map[string]string
+	Tags      []string
+	// EnvironmentVariables replace the environment variables of the check
+	EnvironmentVariables []checkly.EnvironmentVariable
+	RetryStrategy        *checkly.RetryStrategy
+	RuntimeID            string
+	// Retained checks outlive their resource, they get no owner tag
+	Retained bool
+}
+
+func checklyBrowserCheck(browserCheck BrowserCheck) (check checkly.Check, err error) {
+
+	if browserCheck.Script == "" {
+		err = errors.New("browser check script is empty")
+		return
+	}
+
+	tags := operatorTags(browserCheck.Labels, browserCheck.Tags)
+	tags = withOwnerTag(tags, browserCheck.Namespace, browserCheck.Name, browserCheck.Retained)
+	tags = append(tags, browserCheck.Namespace)
+
+	check = checkly.Check{
+		Name:                   browserCheck.Name,
+		Type:                   checkly.TypeBrowser,
+		Frequency:              checkValueInt(browserCheck.Frequency, 10),
+		Activated:              !browserCheck.Paused,
+		Muted:                  browserCheck.Muted,
+		ShouldFail:             false,
+		DoubleCheck:            false,
+		SSLCheck:               false,
+		Locations:              checkValueArray(browserCheck.Locations, []string{}),
+		Script:                 browserCheck.Script,
+		EnvironmentVariables:   checkValueEnvironmentVariables(browserCheck.EnvironmentVariables),
+		RetryStrategy:          browserCheck.RetryStrategy,
+		Tags:                   tags,
+		AlertSettings:          defaultAlertSettings(),
+		UseGlobalAlertSettings: false,
+		GroupID:                browserCheck.GroupID,
+		RuntimeID:              runtimeID(browserCheck.RuntimeID),
+	}
+
+	return
+}
+
+// CreateBrowserCheck creates a new checklyhq.com browser check
+func CreateBrowserCheck(browserCheck BrowserCheck, client checkly.Client) (ID string, err error) {
+
+	check, err := checklyBrowserCheck(browserCheck)
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	gotCheck, err := client.CreateCheck(ctx, check)
+	if err != nil {
+		return
+	}
+
+	ID = gotCheck.ID
+
+	return
+}
+
+// UpdateBrowserCheck updates an existing checklyhq.com browser check
+func UpdateBrowserCheck(browserCheck BrowserCheck, client checkly.Client) (state State, err error) {
+
+	check, err := checklyBrowserCheck(browserCheck)
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	gotCheck, err := client.UpdateCheck(ctx, browserCheck.ID, check)
+	if err != nil {
+		return
+	}
+
+	return checkState(gotCheck), nil
+}
+
+// DeleteBrowserCheck deletes an existing checklyhq.com browser check
+func DeleteBrowserCheck(ID string, client checkly.Client) (err error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	err = client.DeleteCheck(ctx, ID)
+
+	return
+}
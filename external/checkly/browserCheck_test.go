@@ -0,0 +1,193 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/checkly/checkly-go-sdk"
+)
+
+func TestChecklyBrowserCheck(t *testing.T) {
+
+	data1 := BrowserCheck{
+		Name:      "foo",
+		Namespace: "bar",
+		Frequency: 15,
+		Locations: []string{"eu-west-1"},
+		Script:    "console.log('foo')",
+		Muted:     true,
+
+		EnvironmentVariables: []checkly.EnvironmentVariable{{Key: "FOO", Value: "bar", Locked: true}},
+		RetryStrategy:        &checkly.RetryStrategy{Type: "FIXED", BaseBackoffSeconds: 60, MaxRetries: 2, MaxDurationSeconds: 600},
+		Tags:                 []string{"team:foo"},
+	}
+
+	testData, _ := checklyBrowserCheck(data1)
+
+	if testData.Name != data1.Name {
+		t.Errorf("Expected %s, got %s", data1.Name, testData.Name)
+	}
+
+	if testData.Type != checkly.TypeBrowser {
+		t.Errorf("Expected %s, got %s", checkly.TypeBrowser, testData.Type)
+	}
+
+	if testData.Frequency != data1.Frequency {
+		t.Errorf("Expected %d, got %d", data1.Frequency, testData.Frequency)
+	}
+
+	if testData.Script != data1.Script {
+		t.Errorf("Expected %s, got %s", data1.Script, testData.Script)
+	}
+
+	if len(testData.Locations) != 1 {
+		t.Errorf("Expected %d, got %d", 1, len(testData.Locations))
+	}
+
+	if testData.Muted != data1.Muted {
+		t.Errorf("Expected %t, got %t", data1.Muted, testData.Muted)
+	}
+
+	if len(testData.EnvironmentVariables) != 1 || testData.EnvironmentVariables[0] != data1.EnvironmentVariables[0] {
+		t.Errorf("Expected %v, got %v", data1.EnvironmentVariables, testData.EnvironmentVariables)
+	}
+
+	if len(testData.Tags) != 3 || testData.Tags[0] != "team:foo" {
+		t.Errorf("Expected %v with the operator and namespace tags, got %v", data1.Tags, testData.Tags)
+	}
+
+	if testData.RetryStrategy != data1.RetryStrategy {
+		t.Errorf("Expected %v, got %v", data1.RetryStrategy, testData.RetryStrategy)
+	}
+
+	data2 := BrowserCheck{
+		Name:      "foo",
+		Namespace: "bar",
+		Script:    "console.log('foo')",
+	}
+
+	testData, _ = checklyBrowserCheck(data2)
+
+	if testData.Frequency != 10 {
+		t.Errorf("Expected %d, got %d", 10, testData.Frequency)
+	}
+
+	if len(testData.Locations) != 0 {
+		t.Errorf("Expected %d, got %d", 0, len(testData.Locations))
+	}
+
+	if testData.EnvironmentVariables == nil {
+		t.Error("Expected an empty list of environment variables, got nil")
+	}
+
+	failData := BrowserCheck{
+		Name:      "fail",
+		Namespace: "bar",
+	}
+
+	_, err := checklyBrowserCheck(failData)
+	if err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
+
+func TestChecklyBrowserCheckActions(t *testing.T) {
+
+	expectedCheckID := "2"
+	testData := BrowserCheck{
+		Name:      "foo",
+		Namespace: "bar",
+		Frequency: 15,
+		Script:    "console.log('foo')",
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/checks/browser", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		resp := make(map[string]string)
+		resp["id"] = expectedCheckID
+		jsonResp, _ := json.Marshal(resp)
+		w.Write(jsonResp)
+	})
+	mux.HandleFunc("/v1/checks/2", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			resp := make(map[string]string)
+			resp["id"] = expectedCheckID
+			jsonResp, _ := json.Marshal(resp)
+			w.Write(jsonResp)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	testClient := checkly.NewClient(
+		server.URL,
+		"foobarbaz",
+		nil,
+		nil,
+	)
+	testClient.SetAccountId("1234567890")
+
+	testID, err := CreateBrowserCheck(testData, testClient)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+
+	if testID != expectedCheckID {
+		t.Errorf("Expected %s, got %s", expectedCheckID, testID)
+	}
+
+	testData.ID = testID
+
+	_, err = UpdateBrowserCheck(testData, testClient)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+
+	err = DeleteBrowserCheck(testID, testClient)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+
+	// Test errors
+	server.Close()
+
+	_, err = CreateBrowserCheck(testData, testClient)
+	if err == nil {
+		t.Error("Expected error, got none")
+	}
+
+	_, err = UpdateBrowserCheck(testData, testClient)
+	if err == nil {
+		t.Error("Expected error, got none")
+	}
+
+	err = DeleteBrowserCheck(testID, testClient)
+	if err == nil {
+		t.Error("Expected error, got none")
+	}
+}
@@ -31,12 +31,49 @@ type Check struct {
 	Namespace       string
 	Frequency       int
 	MaxResponseTime int
-	Endpoint        string
-	SuccessCode     string
-	GroupID         int64
-	ID              string
-	Muted           bool
-	Labels          map[string]string
+	// FrequencyOffset spreads the checks with the same frequency over time
+	FrequencyOffset int
+	// FrequencySeconds runs the check every 10, 20 or 30 seconds, it takes precedence over Frequency
+	FrequencySeconds int
+	// DegradedResponseTime defaults to 5000, it's capped at MaxResponseTime
+	DegradedResponseTime int
+	Endpoint             string
+	SuccessCode          string
+	Method               string
+	Body                 string
+	BodyType             string
+	SkipSSL              bool
+	FollowRedirects      bool
+	IPFamily             string
+	GroupID              int64
+	ID                   string
+	Muted                bool
+	Paused               bool
+	// ShouldFail is derived from SuccessCode when nil
+	ShouldFail    *bool
+	Labels        map[string]string
+	Tags          []string
+	AlertChannels []checkly.AlertChannelSubscription
+	RuntimeID     string
+	Locations     []string
+	// PrivateLocations are the slug names of the private locations
+	PrivateLocations []string
+	// SSLCertificateExpiry is the number of days before the certificate expiry to alert on, 0 disables the alert
+	SSLCertificateExpiry int
+	Headers              []checkly.KeyValue
+	QueryParameters      []checkly.KeyValue
+	BasicAuth            *checkly.BasicAuth
+	// SetupScript and TeardownScript are inline scripts, the snippet IDs reference checkly snippets instead
+	SetupScript       string
+	SetupSnippetID    int64
+	TeardownScript    string
+	TeardownSnippetID int64
+	// Assertions are added after the success code assertion
+	Assertions []checkly.Assertion
+	// RetryStrategy is left to Checkly when nil
+	RetryStrategy *checkly.RetryStrategy
+	// Retained checks outlive their resource, they get no owner tag
+	Retained bool
 }
 
 func checklyCheck(apiCheck Check) (check checkly.Check, err error) {
@@ -45,70 +82,108 @@ func checklyCheck(apiCheck Check) (check checkly.Check, err error) {
 	if err != nil {
 		return
 	}
+	if apiCheck.ShouldFail != nil {
+		shouldFail = *apiCheck.ShouldFail
+	}
 
-	tags := getTags(apiCheck.Labels)
-	tags = append(tags, "checkly-operator")
-	tags = append(tags, apiCheck.Namespace)
+	tags := operatorTags(apiCheck.Labels, apiCheck.Tags)
+	tags = withOwnerTag(tags, apiCheck.Namespace, apiCheck.Name, apiCheck.Retained)
+	// Cluster scoped checks don't have a namespace to tag
+	if apiCheck.Namespace != "" {
+		tags = append(tags, apiCheck.Namespace)
+	}
 
-	alertSettings := checkly.AlertSettings{
-		EscalationType: checkly.RunBased,
-		RunBasedEscalation: checkly.RunBasedEscalation{
-			FailedRunThreshold: 5,
-		},
-		TimeBasedEscalation: checkly.TimeBasedEscalation{
-			MinutesFailingThreshold: 5,
-		},
-		Reminders: checkly.Reminders{
-			Interval: 5,
-		},
-		SSLCertificates: checkly.SSLCertificates{
-			Enabled:        false,
-			AlertThreshold: 3,
+	alertSettings := defaultAlertSettings()
+	if apiCheck.SSLCertificateExpiry > 0 {
+		alertSettings.SSLCertificates = checkly.SSLCertificates{
+			Enabled:        true,
+			AlertThreshold: apiCheck.SSLCertificateExpiry,
+		}
+	}
+
+	headers := []checkly.KeyValue{}
+	headers = append(headers, apiCheck.Headers...)
+
+	queryParameters := []checkly.KeyValue{}
+	queryParameters = append(queryParameters, apiCheck.QueryParameters...)
+
+	assertions := []checkly.Assertion{
+		{
+			Source:     checkly.StatusCode,
+			Comparison: checkly.Equals,
+			Target:     apiCheck.SuccessCode,
 		},
 	}
+	assertions = append(assertions, apiCheck.Assertions...)
+	// Checkly shows the assertions in this order
+	for i := range assertions {
+		assertions[i].Order = i
+	}
+
+	bodyType := apiCheck.BodyType
+	if bodyType == "" {
+		bodyType = "NONE"
+		if apiCheck.Body != "" {
+			bodyType = "RAW"
+		}
+	}
+
+	var privateLocations *[]string
+	if len(apiCheck.PrivateLocations) > 0 {
+		privateLocations = &apiCheck.PrivateLocations
+	}
+
+	maxResponseTime := checkValueInt(apiCheck.MaxResponseTime, 15000)
+	degradedResponseTime := checkValueInt(apiCheck.DegradedResponseTime, 5000)
+	if degradedResponseTime > maxResponseTime {
+		degradedResponseTime = maxResponseTime
+	}
+
+	// Checkly runs checks with frequency 0 every frequencyOffset seconds
+	frequency := checkValueInt(apiCheck.Frequency, 5)
+	frequencyOffset := apiCheck.FrequencyOffset
+	if apiCheck.FrequencySeconds != 0 {
+		frequency = 0
+		frequencyOffset = apiCheck.FrequencySeconds
+	}
 
 	check = checkly.Check{
-		Name:                   apiCheck.Name,
-		Type:                   checkly.TypeAPI,
-		Frequency:              checkValueInt(apiCheck.Frequency, 5),
-		DegradedResponseTime:   5000,
-		MaxResponseTime:        checkValueInt(apiCheck.MaxResponseTime, 15000),
-		Activated:              true,
-		Muted:                  apiCheck.Muted, // muted for development
-		ShouldFail:             shouldFail,
-		DoubleCheck:            false,
-		SSLCheck:               false,
-		LocalSetupScript:       "",
-		LocalTearDownScript:    "",
-		Locations:              []string{},
-		Tags:                   tags,
-		AlertSettings:          alertSettings,
-		UseGlobalAlertSettings: false,
-		GroupID:                apiCheck.GroupID,
+		Name:                      apiCheck.Name,
+		Type:                      checkly.TypeAPI,
+		Frequency:                 frequency,
+		FrequencyOffset:           frequencyOffset,
+		DegradedResponseTime:      degradedResponseTime,
+		MaxResponseTime:           maxResponseTime,
+		Activated:                 !apiCheck.Paused,
+		Muted:                     apiCheck.Muted, // muted for development
+		ShouldFail:                shouldFail,
+		DoubleCheck:               false,
+		SSLCheck:                  false,
+		LocalSetupScript:          apiCheck.SetupScript,
+		LocalTearDownScript:       apiCheck.TeardownScript,
+		SetupSnippetID:            apiCheck.SetupSnippetID,
+		TearDownSnippetID:         apiCheck.TeardownSnippetID,
+		Locations:                 checkValueArray(apiCheck.Locations, []string{}),
+		Tags:                      tags,
+		AlertSettings:             alertSettings,
+		UseGlobalAlertSettings:    false,
+		PrivateLocations:          privateLocations,
+		RetryStrategy:             apiCheck.RetryStrategy,
+		RuntimeID:                 runtimeID(apiCheck.RuntimeID),
+		GroupID:                   apiCheck.GroupID,
+		AlertChannelSubscriptions: apiCheck.AlertChannels,
 		Request: checkly.Request{
-			Method:  http.MethodGet,
-			URL:     apiCheck.Endpoint,
-			Headers: []checkly.KeyValue{
-				// {
-				// 	Key:   "X-Test",
-				// 	Value: "foo",
-				// },
-			},
-			QueryParameters: []checkly.KeyValue{
-				// {
-				// 	Key:   "query",
-				// 	Value: "foo",
-				// },
-			},
-			Assertions: []checkly.Assertion{
-				{
-					Source:     checkly.StatusCode,
-					Comparison: checkly.Equals,
-					Target:     apiCheck.SuccessCode,
-				},
-			},
-			Body:     "",
-			BodyType: "NONE",
+			Method:          checkValueString(apiCheck.Method, http.MethodGet),
+			URL:             apiCheck.Endpoint,
+			Headers:         headers,
+			QueryParameters: queryParameters,
+			BasicAuth:       apiCheck.BasicAuth,
+			Assertions:      assertions,
+			Body:            apiCheck.Body,
+			BodyType:        bodyType,
+			SkipSSL:         apiCheck.SkipSSL,
+			FollowRedirects: apiCheck.FollowRedirects,
+			IPFamily:        checkValueString(apiCheck.IPFamily, "IPv4"),
 		},
 	}
 
@@ -137,7 +212,7 @@ func Create(apiCheck Check, client checkly.Client) (ID string, err error) {
 }
 
 // Update updates an existing checklyhq.com check
-func Update(apiCheck Check, client checkly.Client) (err error) {
+func Update(apiCheck Check, client checkly.Client) (state State, err error) {
 
 	check, err := checklyCheck(apiCheck)
 	if err != nil {
@@ -147,9 +222,12 @@ func Update(apiCheck Check, client checkly.Client) (err error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 	defer cancel()
 
-	_, err = client.Update(ctx, apiCheck.ID, check)
+	gotCheck, err := client.Update(ctx, apiCheck.ID, check)
+	if err != nil {
+		return
+	}
 
-	return
+	return checkState(gotCheck), nil
 }
 
 // Delete deletes an existing checklyhq.com check
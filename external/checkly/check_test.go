@@ -33,7 +33,27 @@ func TestChecklyCheck(t *testing.T) {
 		MaxResponseTime: 2000,
 		Endpoint:        "https://foo.bar/baz",
 		SuccessCode:     "403",
+		Method:          "POST",
+		Body:            `{"query": "{ status }"}`,
+		BodyType:        "GRAPHQL",
+		SkipSSL:         true,
+		FollowRedirects: true,
+		IPFamily:        "IPv6",
 		Muted:           true,
+		Paused:          true,
+
+		SSLCertificateExpiry: 14,
+		Headers:              []checkly.KeyValue{{Key: "X-Foo", Value: "foo"}},
+		QueryParameters:      []checkly.KeyValue{{Key: "page", Value: "1"}},
+		BasicAuth:            &checkly.BasicAuth{Username: "foo", Password: "bar"},
+		SetupScript:          "console.log('setup')",
+		Locations:            []string{"eu-west-1"},
+		PrivateLocations:     []string{"basement"},
+		TeardownSnippetID:    6,
+		RetryStrategy:        &checkly.RetryStrategy{Type: "LINEAR", BaseBackoffSeconds: 30, MaxRetries: 3},
+		Assertions: []checkly.Assertion{
+			{Source: checkly.JSONBody, Property: "$.status", Comparison: checkly.Equals, Target: "ok"},
+		},
 	}
 
 	testData, _ := checklyCheck(data1)
@@ -50,14 +70,82 @@ func TestChecklyCheck(t *testing.T) {
 		t.Errorf("Expected %d, got %d", data1.MaxResponseTime, testData.MaxResponseTime)
 	}
 
+	if testData.DegradedResponseTime != data1.MaxResponseTime {
+		t.Errorf("Expected %d, got %d", data1.MaxResponseTime, testData.DegradedResponseTime)
+	}
+
 	if testData.Muted != data1.Muted {
 		t.Errorf("Expected %t, got %t", data1.Muted, testData.Muted)
 	}
 
+	if testData.Activated != false {
+		t.Errorf("Expected %t, got %t", false, testData.Activated)
+	}
+
 	if testData.ShouldFail != true {
 		t.Errorf("Expected %t, got %t", true, testData.ShouldFail)
 	}
 
+	if testData.AlertSettings.SSLCertificates.Enabled != true {
+		t.Errorf("Expected %t, got %t", true, testData.AlertSettings.SSLCertificates.Enabled)
+	}
+
+	if testData.AlertSettings.SSLCertificates.AlertThreshold != data1.SSLCertificateExpiry {
+		t.Errorf("Expected %d, got %d", data1.SSLCertificateExpiry, testData.AlertSettings.SSLCertificates.AlertThreshold)
+	}
+
+	if testData.Request.Method != data1.Method {
+		t.Errorf("Expected %s, got %s", data1.Method, testData.Request.Method)
+	}
+
+	if testData.Request.Body != data1.Body || testData.Request.BodyType != data1.BodyType {
+		t.Errorf("Expected %s %s, got %s %s", data1.BodyType, data1.Body, testData.Request.BodyType, testData.Request.Body)
+	}
+
+	if testData.Request.SkipSSL != data1.SkipSSL {
+		t.Errorf("Expected %t, got %t", data1.SkipSSL, testData.Request.SkipSSL)
+	}
+
+	if testData.Request.FollowRedirects != data1.FollowRedirects {
+		t.Errorf("Expected %t, got %t", data1.FollowRedirects, testData.Request.FollowRedirects)
+	}
+
+	if testData.Request.IPFamily != data1.IPFamily {
+		t.Errorf("Expected %s, got %s", data1.IPFamily, testData.Request.IPFamily)
+	}
+
+	if len(testData.Request.Headers) != 1 || testData.Request.Headers[0] != data1.Headers[0] {
+		t.Errorf("Expected %v, got %v", data1.Headers, testData.Request.Headers)
+	}
+
+	if len(testData.Request.QueryParameters) != 1 || testData.Request.QueryParameters[0] != data1.QueryParameters[0] {
+		t.Errorf("Expected %v, got %v", data1.QueryParameters, testData.Request.QueryParameters)
+	}
+
+	if testData.Request.BasicAuth == nil || *testData.Request.BasicAuth != *data1.BasicAuth {
+		t.Errorf("Expected %v, got %v", data1.BasicAuth, testData.Request.BasicAuth)
+	}
+
+	if testData.LocalSetupScript != data1.SetupScript || testData.TearDownSnippetID != data1.TeardownSnippetID {
+		t.Errorf("Expected %s and %d, got %s and %d", data1.SetupScript, data1.TeardownSnippetID, testData.LocalSetupScript, testData.TearDownSnippetID)
+	}
+
+	if len(testData.Locations) != 1 || testData.PrivateLocations == nil || len(*testData.PrivateLocations) != 1 {
+		t.Errorf("Expected %v and %v, got %v and %v", data1.Locations, data1.PrivateLocations, testData.Locations, testData.PrivateLocations)
+	}
+
+	if testData.RetryStrategy == nil || *testData.RetryStrategy != *data1.RetryStrategy {
+		t.Errorf("Expected %v, got %v", data1.RetryStrategy, testData.RetryStrategy)
+	}
+
+	if len(testData.Request.Assertions) != 2 || testData.Request.Assertions[1].Property != data1.Assertions[0].Property {
+		t.Errorf("Expected status code and %v assertions, got %v", data1.Assertions, testData.Request.Assertions)
+	}
+
+	if testData.Request.Assertions[1].Order != 1 {
+		t.Errorf("Expected %d, got %d", 1, testData.Request.Assertions[1].Order)
+	}
+
 	data2 := Check{
 		Name:        "foo",
 		Namespace:   "bar",
@@ -71,14 +159,88 @@ func TestChecklyCheck(t *testing.T) {
 		t.Errorf("Expected %d, got %d", 5, testData.Frequency)
 	}
 
+	if testData.Activated != true {
+		t.Errorf("Expected %t, got %t", true, testData.Activated)
+	}
+
+	data2.FrequencySeconds = 20
+	data2.FrequencyOffset = 3
+	testData, _ = checklyCheck(data2)
+
+	if testData.Frequency != 0 || testData.FrequencyOffset != 20 {
+		t.Errorf("Expected %d and %d, got %d and %d", 0, 20, testData.Frequency, testData.FrequencyOffset)
+	}
+
+	data2.FrequencySeconds = 0
+	testData, _ = checklyCheck(data2)
+
+	if testData.Frequency != 5 || testData.FrequencyOffset != 3 {
+		t.Errorf("Expected %d and %d, got %d and %d", 5, 3, testData.Frequency, testData.FrequencyOffset)
+	}
+
+	if testData.Request.IPFamily != "IPv4" {
+		t.Errorf("Expected %s, got %s", "IPv4", testData.Request.IPFamily)
+	}
+
 	if testData.MaxResponseTime != 15000 {
 		t.Errorf("Expected %d, got %d", 15000, testData.MaxResponseTime)
 	}
 
+	if testData.DegradedResponseTime != 5000 {
+		t.Errorf("Expected %d, got %d", 5000, testData.DegradedResponseTime)
+	}
+
+	if testData.ShouldFail != false {
+		t.Errorf("Expected %t, got %t", false, testData.ShouldFail)
+	}
+
+	if testData.AlertSettings.SSLCertificates.Enabled != false {
+		t.Errorf("Expected %t, got %t", false, testData.AlertSettings.SSLCertificates.Enabled)
+	}
+
+	if len(testData.Locations) != 0 || testData.PrivateLocations != nil {
+		t.Errorf("Expected no locations, got %v and %v", testData.Locations, testData.PrivateLocations)
+	}
+
+	if testData.Request.Method != http.MethodGet {
+		t.Errorf("Expected %s, got %s", http.MethodGet, testData.Request.Method)
+	}
+
+	if testData.Request.BodyType != "NONE" {
+		t.Errorf("Expected %s, got %s", "NONE", testData.Request.BodyType)
+	}
+
+	data2.Body = "foo=bar"
+	testData, _ = checklyCheck(data2)
+
+	if testData.Request.BodyType != "RAW" {
+		t.Errorf("Expected %s, got %s", "RAW", testData.Request.BodyType)
+	}
+
+	data3 := Check{
+		Name:        "foo",
+		Endpoint:    "https://foo.bar/baz",
+		SuccessCode: "200",
+	}
+
+	testData, _ = checklyCheck(data3)
+
+	if len(testData.Tags) != 1 {
+		t.Errorf("Expected %d, got %d", 1, len(testData.Tags))
+	}
+
 	if testData.ShouldFail != false {
 		t.Errorf("Expected %t, got %t", false, testData.ShouldFail)
 	}
 
+	shouldFail := true
+	data3.ShouldFail = &shouldFail
+	testData, _ = checklyCheck(data3)
+
+	if testData.ShouldFail != true {
+		t.Errorf("Expected %t, got %t", true, testData.ShouldFail)
+	}
+
 	failData := Check{
 		Name:        "fail",
 		Namespace:   "bar",
@@ -122,7 +284,7 @@ func TestChecklyCheckActions(t *testing.T) {
 	}
 
 	// Update
-	err = Update(testData, testClientFail)
+	_, err = Update(testData, testClientFail)
 	if err == nil {
 		t.Error("Expected error, got none")
 	}
@@ -207,7 +369,7 @@ func TestChecklyCheckActions(t *testing.T) {
 
 	testData.ID = expectedCheckID
 
-	err = Update(testData, testClient)
+	_, err = Update(testData, testClient)
 	if err != nil {
 		t.Errorf("Expected no error, got %e", err)
 	}
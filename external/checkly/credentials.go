@@ -0,0 +1,89 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/checkly/checkly-go-sdk"
+)
+
+// Credentials are the checklyhq.com API key and account ID of a client, they can be rotated while
+// the client is in use
+type Credentials struct {
+	mu        sync.RWMutex
+	apiKey    string
+	accountID string
+}
+
+// NewCredentials returns the credentials of the API key and account ID
+func NewCredentials(apiKey, accountID string) *Credentials {
+	return &Credentials{apiKey: apiKey, accountID: accountID}
+}
+
+// Get returns the current API key and account ID
+func (c *Credentials) Get() (apiKey, accountID string) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.apiKey, c.accountID
+}
+
+// Set rotates the API key and account ID, it returns whether they changed
+func (c *Credentials) Set(apiKey, accountID string) (changed bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	changed = c.apiKey != apiKey || c.accountID != accountID
+	c.apiKey = apiKey
+	c.accountID = accountID
+
+	return
+}
+
+// NewClientWithCredentials returns a checkly client which sends the current credentials with every
+// request, so in-flight reconciles keep working when they're rotated
+func NewClientWithCredentials(baseURL string, credentials *Credentials) checkly.Client {
+	apiKey, accountID := credentials.Get()
+	client := checkly.NewClient(
+		baseURL,
+		apiKey,
+		&http.Client{Transport: &credentialsTransport{credentials: credentials, base: NewHTTPClient().Transport}},
+		nil, //io.Writer to output debug messages
+	)
+	client.SetAccountId(accountID)
+
+	return client
+}
+
+// credentialsTransport replaces the authentication headers the SDK sets with the current credentials
+type credentialsTransport struct {
+	credentials *Credentials
+	base        http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *credentialsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	apiKey, accountID := t.credentials.Get()
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	req.Header.Set("x-checkly-account", accountID)
+
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientWithCredentials(t *testing.T) {
+
+	var authorization, account string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/checks/1", func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		account = r.Header.Get("x-checkly-account")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"1"}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	credentials := NewCredentials("foobarbaz", "1234567890")
+	testClient := NewClientWithCredentials(server.URL, credentials)
+
+	if _, err := testClient.GetCheck(context.Background(), "1"); err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+	if authorization != "Bearer foobarbaz" || account != "1234567890" {
+		t.Errorf("Expected the initial credentials, got %s %s", authorization, account)
+	}
+
+	if credentials.Set("foobarbaz", "1234567890") {
+		t.Error("Expected unchanged credentials")
+	}
+	if !credentials.Set("rotated", "0987654321") {
+		t.Error("Expected changed credentials")
+	}
+
+	if _, err := testClient.GetCheck(context.Background(), "1"); err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+	if authorization != "Bearer rotated" || account != "0987654321" {
+		t.Errorf("Expected the rotated credentials, got %s %s", authorization, account)
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"context"
+	"time"
+
+	"github.com/checkly/checkly-go-sdk"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+func checklyDashboard(dashboard *checklyv1alpha1.Dashboard) (db checkly.Dashboard) {
+
+	db = checkly.Dashboard{
+		CustomUrl:          dashboard.Spec.CustomUrl,
+		CustomDomain:       dashboard.Spec.CustomDomain,
+		Header:             checkValueString(dashboard.Spec.Header, dashboard.Name),
+		Description:        dashboard.Spec.Description,
+		Logo:               dashboard.Spec.Logo,
+		Favicon:            dashboard.Spec.Favicon,
+		Link:               dashboard.Spec.Link,
+		Width:              checkValueString(dashboard.Spec.Width, "FULL"),
+		RefreshRate:        checkValueInt(dashboard.Spec.RefreshRate, 60),
+		Paginate:           dashboard.Spec.Paginate,
+		PaginationRate:     checkValueInt(dashboard.Spec.PaginationRate, 60),
+		ChecksPerPage:      checkValueInt(dashboard.Spec.ChecksPerPage, 15),
+		Tags:               checkValueArray(dashboard.Spec.Tags, []string{}),
+		UseTagsAndOperator: dashboard.Spec.UseTagsAndOperator,
+		HideTags:           dashboard.Spec.HideTags,
+	}
+
+	return
+}
+
+// CreateDashboard creates a new checklyhq.com dashboard
+func CreateDashboard(dashboard *checklyv1alpha1.Dashboard, client checkly.Client) (ID string, err error) {
+
+	db := checklyDashboard(dashboard)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	gotDashboard, err := client.CreateDashboard(ctx, db)
+	if err != nil {
+		return
+	}
+
+	ID = gotDashboard.DashboardID
+
+	return
+}
+
+// UpdateDashboard updates an existing checklyhq.com dashboard
+func UpdateDashboard(dashboard *checklyv1alpha1.Dashboard, client checkly.Client) (err error) {
+
+	db := checklyDashboard(dashboard)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	_, err = client.UpdateDashboard(ctx, dashboard.Status.ID, db)
+
+	return
+}
+
+// DeleteDashboard deletes an existing checklyhq.com dashboard
+func DeleteDashboard(dashboard *checklyv1alpha1.Dashboard, client checkly.Client) (err error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	err = client.DeleteDashboard(ctx, dashboard.Status.ID)
+
+	return
+}
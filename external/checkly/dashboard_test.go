@@ -0,0 +1,198 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/checkly/checkly-go-sdk"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+func TestChecklyDashboard(t *testing.T) {
+
+	data1 := &checklyv1alpha1.Dashboard{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+		Spec: checklyv1alpha1.DashboardSpec{
+			CustomUrl:      "foo",
+			CustomDomain:   "status.foo.bar",
+			Header:         "Foo status",
+			Width:          "960PX",
+			RefreshRate:    300,
+			PaginationRate: 30,
+			ChecksPerPage:  5,
+			Tags:           []string{"team:foo"},
+		},
+	}
+
+	testData := checklyDashboard(data1)
+
+	if testData.CustomUrl != data1.Spec.CustomUrl {
+		t.Errorf("Expected %s, got %s", data1.Spec.CustomUrl, testData.CustomUrl)
+	}
+
+	if testData.CustomDomain != data1.Spec.CustomDomain {
+		t.Errorf("Expected %s, got %s", data1.Spec.CustomDomain, testData.CustomDomain)
+	}
+
+	if testData.Header != data1.Spec.Header {
+		t.Errorf("Expected %s, got %s", data1.Spec.Header, testData.Header)
+	}
+
+	if testData.Width != data1.Spec.Width {
+		t.Errorf("Expected %s, got %s", data1.Spec.Width, testData.Width)
+	}
+
+	if testData.RefreshRate != data1.Spec.RefreshRate {
+		t.Errorf("Expected %d, got %d", data1.Spec.RefreshRate, testData.RefreshRate)
+	}
+
+	if testData.PaginationRate != data1.Spec.PaginationRate {
+		t.Errorf("Expected %d, got %d", data1.Spec.PaginationRate, testData.PaginationRate)
+	}
+
+	if testData.ChecksPerPage != data1.Spec.ChecksPerPage {
+		t.Errorf("Expected %d, got %d", data1.Spec.ChecksPerPage, testData.ChecksPerPage)
+	}
+
+	if len(testData.Tags) != 1 {
+		t.Errorf("Expected %d, got %d", 1, len(testData.Tags))
+	}
+
+	data2 := &checklyv1alpha1.Dashboard{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+		Spec: checklyv1alpha1.DashboardSpec{
+			CustomUrl: "foo",
+		},
+	}
+
+	testData = checklyDashboard(data2)
+
+	if testData.Header != data2.Name {
+		t.Errorf("Expected %s, got %s", data2.Name, testData.Header)
+	}
+
+	if testData.Width != "FULL" {
+		t.Errorf("Expected %s, got %s", "FULL", testData.Width)
+	}
+
+	if testData.RefreshRate != 60 {
+		t.Errorf("Expected %d, got %d", 60, testData.RefreshRate)
+	}
+
+	if testData.PaginationRate != 60 {
+		t.Errorf("Expected %d, got %d", 60, testData.PaginationRate)
+	}
+
+	if testData.ChecksPerPage != 15 {
+		t.Errorf("Expected %d, got %d", 15, testData.ChecksPerPage)
+	}
+}
+
+func TestDashboardActions(t *testing.T) {
+
+	expectedDashboardID := "abc"
+	testData := &checklyv1alpha1.Dashboard{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+		Spec: checklyv1alpha1.DashboardSpec{
+			CustomUrl: "foo",
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/dashboards", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		resp := make(map[string]string)
+		resp["dashboardId"] = expectedDashboardID
+		jsonResp, _ := json.Marshal(resp)
+		w.Write(jsonResp)
+	})
+	mux.HandleFunc("/v1/dashboards/abc", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			resp := make(map[string]string)
+			resp["dashboardId"] = expectedDashboardID
+			jsonResp, _ := json.Marshal(resp)
+			w.Write(jsonResp)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	testClient := checkly.NewClient(
+		server.URL,
+		"foobarbaz",
+		nil,
+		nil,
+	)
+	testClient.SetAccountId("1234567890")
+
+	testID, err := CreateDashboard(testData, testClient)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+
+	if testID != expectedDashboardID {
+		t.Errorf("Expected %s, got %s", expectedDashboardID, testID)
+	}
+
+	testData.Status.ID = testID
+
+	err = UpdateDashboard(testData, testClient)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+
+	err = DeleteDashboard(testData, testClient)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+
+	// Test errors
+	server.Close()
+
+	_, err = CreateDashboard(testData, testClient)
+	if err == nil {
+		t.Error("Expected error, got none")
+	}
+
+	err = UpdateDashboard(testData, testClient)
+	if err == nil {
+		t.Error("Expected error, got none")
+	}
+
+	err = DeleteDashboard(testData, testClient)
+	if err == nil {
+		t.Error("Expected error, got none")
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"context"
+	"time"
+
+	"github.com/checkly/checkly-go-sdk"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+// CheckDrift returns the fields of the checklyhq.com check which differ from the check, see dryRunDiff
+func CheckDrift(apiCheck Check, client checkly.Client) (changes []string, err error) {
+
+	check, err := checklyCheck(apiCheck)
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	current, err := client.Get(ctx, apiCheck.ID)
+	if err != nil {
+		return
+	}
+
+	return dryRunDiff(current, check), nil
+}
+
+// GroupDrift returns the fields of the checklyhq.com group which differ from the group
+func GroupDrift(group Group, client checkly.Client) (changes []string, err error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	current, err := client.GetGroup(ctx, group.ID)
+	if err != nil {
+		return
+	}
+
+	return dryRunDiff(current, checklyGroup(group)), nil
+}
+
+// AlertChannelDrift returns the fields of the checklyhq.com alert channel which differ from the alert channel
+func AlertChannelDrift(alertChannel *checklyv1alpha1.AlertChannel, config AlertChannelConfig, client checkly.Client) (changes []string, err error) {
+	ac, err := checklyAlertChannel(alertChannel, config)
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	current, err := client.GetAlertChannel(ctx, alertChannel.Status.ID)
+	if err != nil {
+		return
+	}
+
+	return dryRunDiff(current, ac), nil
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/checkly/checkly-go-sdk"
+)
+
+func TestCheckDrift(t *testing.T) {
+
+	testData := Check{
+		ID:              "2",
+		Name:            "foo",
+		Namespace:       "bar",
+		Frequency:       15,
+		MaxResponseTime: 2000,
+		Endpoint:        "https://foo.bar/baz",
+		SuccessCode:     "200",
+		Locations:       []string{"eu-west-1"},
+	}
+	check, err := checklyCheck(testData)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// checklyhq.com returns the unset lists of the check as empty arrays
+	resp := jsonFields(check)
+	resp["id"] = "2"
+	resp["environmentVariables"] = []interface{}{}
+	resp["privateLocations"] = []interface{}{}
+	resp["alertChannelSubscriptions"] = []interface{}{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/checks/2", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		jsonResp, _ := json.Marshal(resp)
+		w.Write(jsonResp)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	apiClient := checkly.NewClient(
+		server.URL,
+		"foobarbaz",
+		nil,
+		nil,
+	)
+	apiClient.SetAccountId("1234567890")
+
+	changes, err := CheckDrift(testData, apiClient)
+	if err != nil {
+		t.Fatalf("Expected no error, got %e", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("Expected no drift, got %v", changes)
+	}
+
+	resp["privateLocations"] = []interface{}{"foo"}
+	changes, err = CheckDrift(testData, apiClient)
+	if err != nil {
+		t.Fatalf("Expected no error, got %e", err)
+	}
+	if !slices.Contains(changes, `privateLocations: ["foo"] -> null`) {
+		t.Errorf("Expected the private locations to have drifted, got %v", changes)
+	}
+}
+
+func TestGroupDrift(t *testing.T) {
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/check-groups/3", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected only reads, got %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		resp := make(map[string]interface{})
+		resp["id"] = 3
+		resp["name"] = "changed-in-the-ui"
+		resp["activated"] = true
+		resp["locations"] = []string{"eu-west-1"}
+		resp["environmentVariables"] = []string{}
+		resp["privateLocations"] = []string{}
+		jsonResp, _ := json.Marshal(resp)
+		w.Write(jsonResp)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	apiClient := checkly.NewClient(
+		server.URL,
+		"foobarbaz",
+		nil,
+		nil,
+	)
+	apiClient.SetAccountId("1234567890")
+
+	testData := Group{
+		ID:        3,
+		Name:      "foo",
+		Activated: true,
+		Locations: []string{"eu-west-1"},
+	}
+
+	changes, err := GroupDrift(testData, apiClient)
+	if err != nil {
+		t.Fatalf("Expected no error, got %e", err)
+	}
+	if !slices.Contains(changes, `name: "changed-in-the-ui" -> "foo"`) {
+		t.Errorf("Expected the name to have drifted, got %v", changes)
+	}
+	for _, field := range []string{"locations:", "environmentVariables:", "privateLocations:"} {
+		if slices.ContainsFunc(changes, func(change string) bool { return strings.HasPrefix(change, field) }) {
+			t.Errorf("Expected the %s not to have drifted, got %v", strings.TrimSuffix(field, ":"), changes)
+		}
+	}
+
+	// Groups which can't be read don't report drift
+	testData.ID = 4
+	changes, err = GroupDrift(testData, apiClient)
+	if err == nil {
+		t.Error("Expected an error")
+	}
+	if len(changes) != 0 {
+		t.Errorf("Expected no changes, got %v", changes)
+	}
+}
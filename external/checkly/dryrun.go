@@ -0,0 +1,436 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/checkly/checkly-go-sdk"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var dryRunLog = log.Log.WithName("dry-run")
+
+// dryRunIgnoredFields are only set by checklyhq.com and never part of a change
+var dryRunIgnoredFields = []string{"id", "created_at", "updated_at", "createdAt", "updatedAt"}
+
+// dryRunRedactedFields may hold values read from Secrets, ex. the basic auth password, the values of
+// headers, query parameters and environment variables and the keys of alert channels. Their values are
+// replaced by redactedValue wherever they're nested before they're logged or recorded
+var dryRunRedactedFields = []string{"password", "value", "webhookSecret", "apiKey", "serviceKey", "token", "rawKey"}
+
+// redactedValue replaces the values of dryRunRedactedFields
+const redactedValue = "***"
+
+// DryRunClient is a checkly.Client which only reads from checklyhq.com, the creates, updates and
+// deletes are logged together with the fields they would change and never sent to the API.
+// Creates return no ID, so new resources never get one in their status and their create is
+// logged again on every reconcile
+type DryRunClient struct {
+	checkly.Client
+	// Recorder records the changes as Drift events of the Object, if both are set
+	Recorder record.EventRecorder
+	Object   runtime.Object
+}
+
+// NewDryRunClient wraps the client so it doesn't write to checklyhq.com
+func NewDryRunClient(client checkly.Client) checkly.Client {
+	if IsDryRun(client) {
+		return client
+	}
+
+	return &DryRunClient{Client: client}
+}
+
+// IsDryRun returns whether the client is a DryRunClient
+func IsDryRun(client checkly.Client) bool {
+	_, ok := client.(*DryRunClient)
+	return ok
+}
+
+// DryRunLike wraps the client like the default client, so the clients of the accounts follow the
+// --dry-run and --observe flags, other clients are returned as is
+func DryRunLike(defaultClient checkly.Client, client checkly.Client) checkly.Client {
+	dryRunClient, ok := defaultClient.(*DryRunClient)
+	if !ok || IsDryRun(client) {
+		return client
+	}
+
+	return &DryRunClient{Client: client, Recorder: dryRunClient.Recorder}
+}
+
+// DryRunClientFor returns a copy of the dry-run client which records the changes on the object,
+// other clients are returned as is
+func DryRunClientFor(client checkly.Client, object runtime.Object) checkly.Client {
+	dryRunClient, ok := client.(*DryRunClient)
+	if !ok {
+		return client
+	}
+
+	return &DryRunClient{Client: dryRunClient.Client, Recorder: dryRunClient.Recorder, Object: object}
+}
+
+// Create implements checkly.Client, it returns the check without an ID
+func (c *DryRunClient) Create(ctx context.Context, check checkly.Check) (*checkly.Check, error) {
+	c.logDryRunCreate("check", check)
+	return &check, nil
+}
+
+// Update implements checkly.Client
+func (c *DryRunClient) Update(ctx context.Context, ID string, check checkly.Check) (*checkly.Check, error) {
+	current, err := c.Client.Get(ctx, ID)
+	c.logDryRunUpdate("check", ID, current, err, check)
+	return &check, nil
+}
+
+// Delete implements checkly.Client
+func (c *DryRunClient) Delete(ctx context.Context, ID string) error {
+	c.logDryRunDelete("check", ID)
+	return nil
+}
+
+// CreateCheck implements checkly.Client, it returns the check without an ID
+func (c *DryRunClient) CreateCheck(ctx context.Context, check checkly.Check) (*checkly.Check, error) {
+	c.logDryRunCreate("check", check)
+	return &check, nil
+}
+
+// UpdateCheck implements checkly.Client
+func (c *DryRunClient) UpdateCheck(ctx context.Context, ID string, check checkly.Check) (*checkly.Check, error) {
+	current, err := c.Client.GetCheck(ctx, ID)
+	c.logDryRunUpdate("check", ID, current, err, check)
+	return &check, nil
+}
+
+// DeleteCheck implements checkly.Client
+func (c *DryRunClient) DeleteCheck(ctx context.Context, ID string) error {
+	c.logDryRunDelete("check", ID)
+	return nil
+}
+
+// CreateHeartbeat implements checkly.Client, it returns the check without an ID
+func (c *DryRunClient) CreateHeartbeat(ctx context.Context, check checkly.HeartbeatCheck) (*checkly.HeartbeatCheck, error) {
+	c.logDryRunCreate("heartbeat check", check)
+	return &check, nil
+}
+
+// UpdateHeartbeat implements checkly.Client
+func (c *DryRunClient) UpdateHeartbeat(ctx context.Context, ID string, check checkly.HeartbeatCheck) (*checkly.HeartbeatCheck, error) {
+	current, err := c.Client.GetHeartbeatCheck(ctx, ID)
+	c.logDryRunUpdate("heartbeat check", ID, current, err, check)
+	return &check, nil
+}
+
+// CreateGroup implements checkly.Client, it returns the group without an ID
+func (c *DryRunClient) CreateGroup(ctx context.Context, group checkly.Group) (*checkly.Group, error) {
+	c.logDryRunCreate("group", group)
+	return &group, nil
+}
+
+// UpdateGroup implements checkly.Client
+func (c *DryRunClient) UpdateGroup(ctx context.Context, ID int64, group checkly.Group) (*checkly.Group, error) {
+	current, err := c.Client.GetGroup(ctx, ID)
+	c.logDryRunUpdate("group", ID, current, err, group)
+	return &group, nil
+}
+
+// DeleteGroup implements checkly.Client
+func (c *DryRunClient) DeleteGroup(ctx context.Context, ID int64) error {
+	c.logDryRunDelete("group", ID)
+	return nil
+}
+
+// CreateSnippet implements checkly.Client, it returns the snippet without an ID
+func (c *DryRunClient) CreateSnippet(ctx context.Context, snippet checkly.Snippet) (*checkly.Snippet, error) {
+	c.logDryRunCreate("snippet", snippet)
+	return &snippet, nil
+}
+
+// UpdateSnippet implements checkly.Client
+func (c *DryRunClient) UpdateSnippet(ctx context.Context, ID int64, snippet checkly.Snippet) (*checkly.Snippet, error) {
+	current, err := c.Client.GetSnippet(ctx, ID)
+	c.logDryRunUpdate("snippet", ID, current, err, snippet)
+	return &snippet, nil
+}
+
+// DeleteSnippet implements checkly.Client
+func (c *DryRunClient) DeleteSnippet(ctx context.Context, ID int64) error {
+	c.logDryRunDelete("snippet", ID)
+	return nil
+}
+
+// CreateEnvironmentVariable implements checkly.Client
+func (c *DryRunClient) CreateEnvironmentVariable(ctx context.Context, envVar checkly.EnvironmentVariable) (*checkly.EnvironmentVariable, error) {
+	c.logDryRunCreate("environment variable", envVar)
+	return &envVar, nil
+}
+
+// UpdateEnvironmentVariable implements checkly.Client
+func (c *DryRunClient) UpdateEnvironmentVariable(ctx context.Context, key string, envVar checkly.EnvironmentVariable) (*checkly.EnvironmentVariable, error) {
+	current, err := c.Client.GetEnvironmentVariable(ctx, key)
+	c.logDryRunUpdate("environment variable", key, current, err, envVar)
+	return &envVar, nil
+}
+
+// DeleteEnvironmentVariable implements checkly.Client
+func (c *DryRunClient) DeleteEnvironmentVariable(ctx context.Context, key string) error {
+	c.logDryRunDelete("environment variable", key)
+	return nil
+}
+
+// CreateAlertChannel implements checkly.Client, it returns the alert channel without an ID
+func (c *DryRunClient) CreateAlertChannel(ctx context.Context, ac checkly.AlertChannel) (*checkly.AlertChannel, error) {
+	c.logDryRunCreate("alert channel", ac)
+	return &ac, nil
+}
+
+// UpdateAlertChannel implements checkly.Client
+func (c *DryRunClient) UpdateAlertChannel(ctx context.Context, ID int64, ac checkly.AlertChannel) (*checkly.AlertChannel, error) {
+	current, err := c.Client.GetAlertChannel(ctx, ID)
+	c.logDryRunUpdate("alert channel", ID, current, err, ac)
+	return &ac, nil
+}
+
+// DeleteAlertChannel implements checkly.Client
+func (c *DryRunClient) DeleteAlertChannel(ctx context.Context, ID int64) error {
+	c.logDryRunDelete("alert channel", ID)
+	return nil
+}
+
+// CreateDashboard implements checkly.Client, it returns the dashboard without an ID
+func (c *DryRunClient) CreateDashboard(ctx context.Context, dashboard checkly.Dashboard) (*checkly.Dashboard, error) {
+	c.logDryRunCreate("dashboard", dashboard)
+	return &dashboard, nil
+}
+
+// UpdateDashboard implements checkly.Client
+func (c *DryRunClient) UpdateDashboard(ctx context.Context, ID string, dashboard checkly.Dashboard) (*checkly.Dashboard, error) {
+	current, err := c.Client.GetDashboard(ctx, ID)
+	c.logDryRunUpdate("dashboard", ID, current, err, dashboard)
+	return &dashboard, nil
+}
+
+// DeleteDashboard implements checkly.Client
+func (c *DryRunClient) DeleteDashboard(ctx context.Context, ID string) error {
+	c.logDryRunDelete("dashboard", ID)
+	return nil
+}
+
+// CreateMaintenanceWindow implements checkly.Client, it returns the maintenance window without an ID
+func (c *DryRunClient) CreateMaintenanceWindow(ctx context.Context, mw checkly.MaintenanceWindow) (*checkly.MaintenanceWindow, error) {
+	c.logDryRunCreate("maintenance window", mw)
+	return &mw, nil
+}
+
+// UpdateMaintenanceWindow implements checkly.Client
+func (c *DryRunClient) UpdateMaintenanceWindow(ctx context.Context, ID int64, mw checkly.MaintenanceWindow) (*checkly.MaintenanceWindow, error) {
+	current, err := c.Client.GetMaintenanceWindow(ctx, ID)
+	c.logDryRunUpdate("maintenance window", ID, current, err, mw)
+	return &mw, nil
+}
+
+// DeleteMaintenanceWindow implements checkly.Client
+func (c *DryRunClient) DeleteMaintenanceWindow(ctx context.Context, ID int64) error {
+	c.logDryRunDelete("maintenance window", ID)
+	return nil
+}
+
+// CreatePrivateLocation implements checkly.Client, it returns the private location without an ID
+func (c *DryRunClient) CreatePrivateLocation(ctx context.Context, pl checkly.PrivateLocation) (*checkly.PrivateLocation, error) {
+	c.logDryRunCreate("private location", pl)
+	return &pl, nil
+}
+
+// UpdatePrivateLocation implements checkly.Client
+func (c *DryRunClient) UpdatePrivateLocation(ctx context.Context, ID string, pl checkly.PrivateLocation) (*checkly.PrivateLocation, error) {
+	current, err := c.Client.GetPrivateLocation(ctx, ID)
+	c.logDryRunUpdate("private location", ID, current, err, pl)
+	return &pl, nil
+}
+
+// DeletePrivateLocation implements checkly.Client
+func (c *DryRunClient) DeletePrivateLocation(ctx context.Context, ID string) error {
+	c.logDryRunDelete("private location", ID)
+	return nil
+}
+
+// CreateTriggerCheck implements checkly.Client, it returns a trigger without a URL
+func (c *DryRunClient) CreateTriggerCheck(ctx context.Context, checkID string) (*checkly.TriggerCheck, error) {
+	c.logDryRunCreate("check trigger", checkly.TriggerCheck{CheckId: checkID})
+	return &checkly.TriggerCheck{CheckId: checkID}, nil
+}
+
+// DeleteTriggerCheck implements checkly.Client
+func (c *DryRunClient) DeleteTriggerCheck(ctx context.Context, checkID string) error {
+	c.logDryRunDelete("check trigger", checkID)
+	return nil
+}
+
+// CreateTriggerGroup implements checkly.Client, it returns a trigger without a URL
+func (c *DryRunClient) CreateTriggerGroup(ctx context.Context, groupID int64) (*checkly.TriggerGroup, error) {
+	c.logDryRunCreate("group trigger", checkly.TriggerGroup{GroupId: groupID})
+	return &checkly.TriggerGroup{GroupId: groupID}, nil
+}
+
+// DeleteTriggerGroup implements checkly.Client
+func (c *DryRunClient) DeleteTriggerGroup(ctx context.Context, groupID int64) error {
+	c.logDryRunDelete("group trigger", groupID)
+	return nil
+}
+
+func (c *DryRunClient) logDryRunCreate(kind string, desired interface{}) {
+	dryRunLog.Info("Would create "+kind, "desired", redacted(jsonFields(desired)))
+	c.recordDrift("checklyhq.com %s doesn't exist, it would be created", kind)
+}
+
+func (c *DryRunClient) logDryRunUpdate(kind string, id interface{}, current interface{}, err error, desired interface{}) {
+	if err != nil {
+		dryRunLog.Error(err, "Failed to get the current "+kind+", logging the desired state only", "id", id, "desired", redacted(jsonFields(desired)))
+		return
+	}
+
+	changes := dryRunDiff(current, desired)
+	if len(changes) == 0 {
+		dryRunLog.V(1).Info("Would update "+kind+", nothing changed", "id", id)
+		return
+	}
+
+	dryRunLog.Info("Would update "+kind, "id", id, "changes", changes)
+	c.recordDrift("checklyhq.com %s %v differs, it would be updated: %s", kind, id, strings.Join(changes, ", "))
+}
+
+func (c *DryRunClient) logDryRunDelete(kind string, id interface{}) {
+	dryRunLog.Info("Would delete "+kind, "id", id)
+	c.recordDrift("checklyhq.com %s %v would be deleted", kind, id)
+}
+
+func (c *DryRunClient) recordDrift(messageFmt string, args ...interface{}) {
+	if c.Recorder == nil || c.Object == nil {
+		return
+	}
+
+	c.Recorder.Eventf(c.Object, corev1.EventTypeWarning, "Drift", messageFmt, args...)
+}
+
+// dryRunDiff returns the top level JSON fields of desired which differ from current as
+// "field: current -> desired", see dryRunIgnoredFields and dryRunRedactedFields. Unset, null and empty values are all
+// the same, the SDK sends nil slices as null while checklyhq.com returns them as []
+func dryRunDiff(current, desired interface{}) (changes []string) {
+	currentFields := jsonFields(current)
+	desiredFields := jsonFields(desired)
+
+	for key, desiredValue := range desiredFields {
+		if slices.Contains(dryRunIgnoredFields, key) {
+			continue
+		}
+		currentValue := currentFields[key]
+		if reflect.DeepEqual(withoutEmpty(currentValue), withoutEmpty(desiredValue)) {
+			continue
+		}
+
+		changes = append(changes, fmt.Sprintf("%s: %s -> %s", key, jsonString(redactedField(key, currentValue)), jsonString(redactedField(key, desiredValue))))
+	}
+	sort.Strings(changes)
+
+	return
+}
+
+func jsonFields(object interface{}) (fields map[string]interface{}) {
+	data, err := json.Marshal(object)
+	if err != nil {
+		return
+	}
+	_ = json.Unmarshal(data, &fields)
+
+	return
+}
+
+// withoutEmpty returns the JSON value with its empty arrays and objects as nil, and without
+// the fields of objects which are nil then
+func withoutEmpty(value interface{}) interface{} {
+	switch value := value.(type) {
+	case []interface{}:
+		if len(value) == 0 {
+			return nil
+		}
+		values := make([]interface{}, len(value))
+		for i := range value {
+			values[i] = withoutEmpty(value[i])
+		}
+		return values
+	case map[string]interface{}:
+		fields := map[string]interface{}{}
+		for key, fieldValue := range value {
+			if fieldValue = withoutEmpty(fieldValue); fieldValue != nil {
+				fields[key] = fieldValue
+			}
+		}
+		if len(fields) == 0 {
+			return nil
+		}
+		return fields
+	default:
+		return value
+	}
+}
+
+// redacted returns the JSON value with the non-empty values of dryRunRedactedFields replaced by redactedValue
+func redacted(value interface{}) interface{} {
+	switch value := value.(type) {
+	case []interface{}:
+		values := make([]interface{}, len(value))
+		for i := range value {
+			values[i] = redacted(value[i])
+		}
+		return values
+	case map[string]interface{}:
+		fields := make(map[string]interface{}, len(value))
+		for key, fieldValue := range value {
+			fields[key] = redactedField(key, fieldValue)
+		}
+		return fields
+	default:
+		return value
+	}
+}
+
+// redactedField returns the value of the JSON field, redacted, see redacted
+func redactedField(key string, value interface{}) interface{} {
+	if slices.Contains(dryRunRedactedFields, key) && value != nil && value != "" {
+		return redactedValue
+	}
+
+	return redacted(value)
+}
+
+func jsonString(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return string(data)
+}
@@ -0,0 +1,240 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/checkly/checkly-go-sdk"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestDryRunClient(t *testing.T) {
+
+	var writes []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/check-groups/3", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writes = append(writes, r.Method+" "+r.URL.Path)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		resp := make(map[string]interface{})
+		resp["id"] = 3
+		resp["name"] = "foo"
+		resp["activated"] = true
+		jsonResp, _ := json.Marshal(resp)
+		w.Write(jsonResp)
+	})
+	mux.HandleFunc("/v1/check-groups", func(w http.ResponseWriter, r *http.Request) {
+		writes = append(writes, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusCreated)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	apiClient := checkly.NewClient(
+		server.URL,
+		"foobarbaz",
+		nil,
+		nil,
+	)
+	apiClient.SetAccountId("1234567890")
+
+	testClient := NewDryRunClient(apiClient)
+	if !IsDryRun(testClient) {
+		t.Error("Expected a dry-run client")
+	}
+	if NewDryRunClient(testClient) != testClient {
+		t.Error("Expected the dry-run client not to be wrapped twice")
+	}
+	if IsDryRun(apiClient) {
+		t.Error("Expected the API client not to be a dry-run client")
+	}
+
+	testData := Group{
+		Name:      "foo",
+		Activated: true,
+		Locations: []string{"eu-west-1"},
+	}
+
+	testID, err := GroupCreate(testData, testClient)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+	if testID != 0 {
+		t.Errorf("Expected no ID, got %d", testID)
+	}
+
+	testData.ID = 3
+	_, err = GroupUpdate(testData, testClient)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+
+	err = GroupDelete(3, testClient)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+
+	if len(writes) != 0 {
+		t.Errorf("Expected no writes, got %v", writes)
+	}
+
+	// Failing reads don't fail the dry-run update
+	server.Close()
+
+	_, err = GroupUpdate(testData, testClient)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+}
+
+func TestDryRunDiff(t *testing.T) {
+
+	current := checkly.Group{
+		ID:        3,
+		Name:      "foo",
+		Activated: true,
+		Locations: []string{"eu-west-1"},
+		CreatedAt: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	desired := checkly.Group{
+		Name:      "foo",
+		Activated: false,
+		Locations: []string{"eu-west-1", "us-east-1"},
+	}
+
+	changes := dryRunDiff(current, desired)
+	expected := []string{
+		`activated: true -> false`,
+		`locations: ["eu-west-1"] -> ["eu-west-1","us-east-1"]`,
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, changes)
+	}
+	for i := range expected {
+		if changes[i] != expected[i] {
+			t.Errorf("Expected %s, got %s", expected[i], changes[i])
+		}
+	}
+
+	if changes := dryRunDiff(current, current); len(changes) != 0 {
+		t.Errorf("Expected no changes, got %v", changes)
+	}
+
+	// Unset and empty values are the same
+	current = checkly.Group{Name: "foo", Locations: []string{}, Tags: []string{}, EnvironmentVariables: []checkly.EnvironmentVariable{}}
+	desired = checkly.Group{Name: "foo", EnvironmentVariables: nil}
+	if changes := dryRunDiff(current, desired); len(changes) != 0 {
+		t.Errorf("Expected no changes, got %v", changes)
+	}
+	desired.Tags = []string{"bar"}
+	if changes := dryRunDiff(current, desired); len(changes) != 1 || changes[0] != `tags: [] -> ["bar"]` {
+		t.Errorf("Expected the tags to change, got %v", changes)
+	}
+}
+
+func TestDryRunDiffRedacted(t *testing.T) {
+
+	current := checkly.Check{
+		Name: "foo",
+		Request: checkly.Request{
+			Headers:   []checkly.KeyValue{{Key: "Authorization", Value: "Bearer old"}},
+			BasicAuth: &checkly.BasicAuth{Username: "foo", Password: "old"},
+		},
+		EnvironmentVariables: []checkly.EnvironmentVariable{{Key: "TOKEN", Value: "old"}},
+	}
+	desired := checkly.Check{
+		Name: "foo",
+		Request: checkly.Request{
+			Headers:   []checkly.KeyValue{{Key: "Authorization", Value: "Bearer new"}},
+			BasicAuth: &checkly.BasicAuth{Username: "foo", Password: "new"},
+		},
+		EnvironmentVariables: []checkly.EnvironmentVariable{{Key: "TOKEN", Value: "new"}},
+	}
+
+	changes := dryRunDiff(current, desired)
+	if len(changes) != 2 {
+		t.Fatalf("Expected the request and the environment variables to change, got %v", changes)
+	}
+	for _, change := range changes {
+		if strings.Contains(change, "old") || strings.Contains(change, "new") {
+			t.Errorf("Expected the secrets to be redacted, got %s", change)
+		}
+		if !strings.Contains(change, redactedValue) {
+			t.Errorf("Expected the redacted values in the change, got %s", change)
+		}
+	}
+
+	// Top level fields are redacted as well, unset values stay visible
+	changes = dryRunDiff(checkly.EnvironmentVariable{Key: "TOKEN"}, checkly.EnvironmentVariable{Key: "TOKEN", Value: "secret"})
+	if len(changes) != 1 || changes[0] != `value: "" -> "***"` {
+		t.Errorf("Expected the value to be redacted, got %v", changes)
+	}
+}
+
+func TestDryRunClientRecorder(t *testing.T) {
+
+	apiClient := checkly.NewClient(
+		"http://localhost:5555",
+		"foobarbaz",
+		nil,
+		nil,
+	)
+	recorder := record.NewFakeRecorder(10)
+	defaultClient := &DryRunClient{Client: apiClient, Recorder: recorder}
+
+	accountClient := DryRunLike(defaultClient, apiClient)
+	if accountClient.(*DryRunClient).Recorder != recorder {
+		t.Error("Expected the account client to use the recorder of the default client")
+	}
+	if DryRunLike(apiClient, apiClient) != apiClient {
+		t.Error("Expected the account client not to be wrapped without a dry-run default client")
+	}
+	if DryRunClientFor(apiClient, &corev1.ConfigMap{}) != apiClient {
+		t.Error("Expected the client not to be wrapped")
+	}
+
+	_, err := DryRunClientFor(defaultClient, &corev1.ConfigMap{}).CreateGroup(context.Background(), checkly.Group{Name: "foo"})
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+	event := <-recorder.Events
+	expected := "Warning Drift checklyhq.com group doesn't exist, it would be created"
+	if event != expected {
+		t.Errorf("Expected %s, got %s", expected, event)
+	}
+
+	// Without an object the changes are only logged
+	err = defaultClient.DeleteGroup(context.Background(), 3)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+	if len(recorder.Events) != 0 {
+		t.Errorf("Expected no events, got %d", len(recorder.Events))
+	}
+}
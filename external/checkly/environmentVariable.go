@@ -0,0 +1,75 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"context"
+	"time"
+
+	"github.com/checkly/checkly-go-sdk"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+func checklyEnvironmentVariable(environmentVariable *checklyv1alpha1.EnvironmentVariable, value string) (ev checkly.EnvironmentVariable) {
+
+	ev = checkly.EnvironmentVariable{
+		Key:    environmentVariable.Spec.Key,
+		Value:  value,
+		Locked: environmentVariable.Spec.Locked,
+		Secret: environmentVariable.Spec.Secret,
+	}
+
+	return
+}
+
+// CreateEnvironmentVariable creates a new checklyhq.com environment variable
+func CreateEnvironmentVariable(environmentVariable *checklyv1alpha1.EnvironmentVariable, value string, client checkly.Client) (err error) {
+
+	ev := checklyEnvironmentVariable(environmentVariable, value)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	_, err = client.CreateEnvironmentVariable(ctx, ev)
+
+	return
+}
+
+// UpdateEnvironmentVariable updates an existing checklyhq.com environment variable
+func UpdateEnvironmentVariable(environmentVariable *checklyv1alpha1.EnvironmentVariable, value string, client checkly.Client) (err error) {
+
+	ev := checklyEnvironmentVariable(environmentVariable, value)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	_, err = client.UpdateEnvironmentVariable(ctx, environmentVariable.Status.Key, ev)
+
+	return
+}
+
+// DeleteEnvironmentVariable deletes an existing checklyhq.com environment variable
+func DeleteEnvironmentVariable(key string, client checkly.Client) (err error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	err = client.DeleteEnvironmentVariable(ctx, key)
+
+	return
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/checkly/checkly-go-sdk"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+func TestChecklyEnvironmentVariable(t *testing.T) {
+
+	data := &checklyv1alpha1.EnvironmentVariable{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+		Spec: checklyv1alpha1.EnvironmentVariableSpec{
+			Key:    "FOO",
+			Value:  "plain",
+			Locked: true,
+			Secret: true,
+		},
+	}
+
+	testData := checklyEnvironmentVariable(data, "bar")
+
+	if testData.Key != data.Spec.Key {
+		t.Errorf("Expected %s, got %s", data.Spec.Key, testData.Key)
+	}
+
+	if testData.Value != "bar" {
+		t.Errorf("Expected %s, got %s", "bar", testData.Value)
+	}
+
+	if testData.Locked != data.Spec.Locked {
+		t.Errorf("Expected %t, got %t", data.Spec.Locked, testData.Locked)
+	}
+
+	if testData.Secret != data.Spec.Secret {
+		t.Errorf("Expected %t, got %t", data.Spec.Secret, testData.Secret)
+	}
+}
+
+func TestEnvironmentVariableActions(t *testing.T) {
+
+	testData := &checklyv1alpha1.EnvironmentVariable{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+		Spec: checklyv1alpha1.EnvironmentVariableSpec{
+			Key: "FOO",
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/variables", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		resp := make(map[string]interface{})
+		resp["key"] = "FOO"
+		jsonResp, _ := json.Marshal(resp)
+		w.Write(jsonResp)
+	})
+	mux.HandleFunc("/v1/variables/FOO", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			resp := make(map[string]interface{})
+			resp["key"] = "FOO"
+			jsonResp, _ := json.Marshal(resp)
+			w.Write(jsonResp)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	testClient := checkly.NewClient(
+		server.URL,
+		"foobarbaz",
+		nil,
+		nil,
+	)
+	testClient.SetAccountId("1234567890")
+
+	err := CreateEnvironmentVariable(testData, "bar", testClient)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+
+	testData.Status.Key = testData.Spec.Key
+
+	err = UpdateEnvironmentVariable(testData, "baz", testClient)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+
+	err = DeleteEnvironmentVariable(testData.Status.Key, testClient)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+
+	// Test errors
+	server.Close()
+
+	err = CreateEnvironmentVariable(testData, "bar", testClient)
+	if err == nil {
+		t.Error("Expected error, got none")
+	}
+
+	err = UpdateEnvironmentVariable(testData, "baz", testClient)
+	if err == nil {
+		t.Error("Expected error, got none")
+	}
+
+	err = DeleteEnvironmentVariable(testData.Status.Key, testClient)
+	if err == nil {
+		t.Error("Expected error, got none")
+	}
+}
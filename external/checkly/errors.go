@@ -0,0 +1,149 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// rateLimitErrorPattern matches the RateLimitErrors, the SDK only keeps the message of the errors of requests
+var rateLimitErrorPattern = regexp.MustCompile(`rate limit of checklyhq\.com reached, retry in ((?:[0-9.]+(?:ns|µs|ms|s|m|h))+)`)
+
+// apiErrorPattern matches the errors the SDK returns for unexpected response statuses, the body is quoted
+var apiErrorPattern = regexp.MustCompile(`unexpected response status (\d+): ("(?:[^"\\]|\\.)*")`)
+
+// APIError is an error response of the checklyhq.com API
+type APIError struct {
+	// StatusCode is the HTTP status code of the response
+	StatusCode int
+	// Message is the message of the response, or its body if it isn't JSON
+	Message string
+	// Fields are the fields of the payload which failed the validation
+	Fields []string
+}
+
+// Error implements error
+func (e *APIError) Error() string {
+	if len(e.Fields) > 0 {
+		return fmt.Sprintf("checklyhq.com rejected %s: %s", strings.Join(e.Fields, ", "), e.Message)
+	}
+
+	return fmt.Sprintf("checklyhq.com API error %d: %s", e.StatusCode, e.Message)
+}
+
+// IsValidation returns whether checklyhq.com rejected the payload, retrying it fails the same way
+func (e *APIError) IsValidation() bool {
+	return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+}
+
+// IsNotFound returns whether checklyhq.com responded with a 404 Not Found, ex. the resource was deleted in the UI
+func IsNotFound(err error) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+// apiErrorBody is the body of the error responses
+type apiErrorBody struct {
+	Error      string `json:"error"`
+	Message    string `json:"message"`
+	Validation struct {
+		Keys []string `json:"keys"`
+	} `json:"validation"`
+}
+
+// AsAPIError returns the checklyhq.com API error of the err, it parses the errors of the SDK which
+// only keep the status code and body of the response
+func AsAPIError(err error) (*APIError, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+
+	match := apiErrorPattern.FindStringSubmatch(err.Error())
+	if match == nil {
+		return nil, false
+	}
+	statusCode, err := strconv.Atoi(match[1])
+	if err != nil {
+		return nil, false
+	}
+	body, err := strconv.Unquote(match[2])
+	if err != nil {
+		return nil, false
+	}
+
+	apiErr = &APIError{StatusCode: statusCode, Message: strings.TrimSpace(body)}
+	parsed := apiErrorBody{}
+	if json.Unmarshal([]byte(body), &parsed) == nil {
+		switch {
+		case parsed.Message != "":
+			apiErr.Message = parsed.Message
+		case parsed.Error != "":
+			apiErr.Message = parsed.Error
+		}
+		apiErr.Fields = parsed.Validation.Keys
+	}
+
+	return apiErr, true
+}
+
+// RateLimitError is returned instead of waiting for the rate limit of the account to reset when the request
+// would reach its deadline first
+type RateLimitError struct {
+	// RetryAfter is when the account is allowed to send requests again
+	RetryAfter time.Duration
+}
+
+// Error implements error
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("rate limit of checklyhq.com reached, retry in %s", e.RetryAfter)
+}
+
+// AsRateLimitError returns the rate limit error of the err, it parses the errors of the SDK which only keep
+// the message of the errors of requests
+func AsRateLimitError(err error) (*RateLimitError, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	var rateLimitErr *RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return rateLimitErr, true
+	}
+
+	match := rateLimitErrorPattern.FindStringSubmatch(err.Error())
+	if match == nil {
+		return nil, false
+	}
+	retryAfter, err := time.ParseDuration(match[1])
+	if err != nil {
+		return nil, false
+	}
+
+	return &RateLimitError{RetryAfter: retryAfter}, true
+}
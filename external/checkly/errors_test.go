@@ -0,0 +1,73 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/checkly/checkly-go-sdk"
+)
+
+func TestAsAPIError(t *testing.T) {
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/checks", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"statusCode":400,"error":"Bad Request","message":"\"frequency\" must be one of [0, 1, 2, 5, 10]","validation":{"source":"payload","keys":["frequency"]}}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	testClient := checkly.NewClient(server.URL, "foobarbaz", nil, nil)
+
+	_, err := testClient.Create(context.Background(), checkly.Check{})
+	apiErr, ok := AsAPIError(fmt.Errorf("wrapped: %w", err))
+	if !ok {
+		t.Fatalf("Expected an API error, got %v", err)
+	}
+	if !apiErr.IsValidation() {
+		t.Errorf("Expected a validation error, got %d", apiErr.StatusCode)
+	}
+	if apiErr.Error() != `checklyhq.com rejected frequency: "frequency" must be one of [0, 1, 2, 5, 10]` {
+		t.Errorf("Unexpected message %s", apiErr.Error())
+	}
+
+	apiErr, ok = AsAPIError(errors.New(`unexpected response status 502: "Bad Gateway"`))
+	if !ok || apiErr.IsValidation() || apiErr.Error() != "checklyhq.com API error 502: Bad Gateway" {
+		t.Errorf("Expected the body as message, got %v", apiErr)
+	}
+
+	if IsNotFound(apiErr) {
+		t.Error("Expected a 502 not to be a not found error")
+	}
+	if !IsNotFound(fmt.Errorf("wrapped: %w", errors.New(`unexpected response status 404: "{\"statusCode\":404,\"error\":\"Not Found\"}"`))) {
+		t.Error("Expected a not found error")
+	}
+
+	if _, ok := AsAPIError(errors.New("connection refused")); ok {
+		t.Error("Expected no API error")
+	}
+	if _, ok := AsAPIError(apiErr); !ok {
+		t.Error("Expected the API error itself")
+	}
+}
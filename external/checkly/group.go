@@ -24,29 +24,49 @@ import (
 )
 
 type Group struct {
-	Name          string
-	ID            int64
-	Locations     []string
-	Activated     bool
-	AlertChannels []checkly.AlertChannelSubscription
-	Labels        map[string]string
+	Name      string
+	ID        int64
+	Locations []string
+	// PrivateLocations are the slug names of the private locations
+	PrivateLocations []string
+	Activated        bool
+	AlertChannels    []checkly.AlertChannelSubscription
+	Labels           map[string]string
+	Tags             []string
+	RuntimeID        string
+	Concurrency      int
+	RetryStrategy    *checkly.RetryStrategy
+	// APICheckDefaults are applied to the api checks in the group
+	APICheckDefaults checkly.APICheckDefaults
+	// AlertSettings fields which aren't set fall back to the defaults
+	AlertSettings *checkly.AlertSettings
+	// EnvironmentVariables replace the environment variables of the group
+	EnvironmentVariables []checkly.EnvironmentVariable
+	// Retained groups outlive their resource, they get no owner tag
+	Retained bool
 }
 
 func checklyGroup(group Group) (check checkly.Group) {
 
-	tags := getTags(group.Labels)
-	tags = append(tags, "checkly-operator")
+	tags := operatorTags(group.Labels, group.Tags)
+	tags = withOwnerTag(tags, "", group.Name, group.Retained)
+
+	settings := checkly.AlertSettings{}
+	if group.AlertSettings != nil {
+		settings = *group.AlertSettings
+	}
 
 	alertSettings := checkly.AlertSettings{
-		EscalationType: checkly.RunBased,
+		EscalationType: checkValueString(settings.EscalationType, checkly.RunBased),
 		RunBasedEscalation: checkly.RunBasedEscalation{
-			FailedRunThreshold: 5,
+			FailedRunThreshold: checkValueInt(settings.RunBasedEscalation.FailedRunThreshold, 5),
 		},
 		TimeBasedEscalation: checkly.TimeBasedEscalation{
-			MinutesFailingThreshold: 5,
+			MinutesFailingThreshold: checkValueInt(settings.TimeBasedEscalation.MinutesFailingThreshold, 5),
 		},
 		Reminders: checkly.Reminders{
-			Interval: 5,
+			Amount:   settings.Reminders.Amount,
+			Interval: checkValueInt(settings.Reminders.Interval, 5),
 		},
 		SSLCertificates: checkly.SSLCertificates{
 			Enabled:        false,
@@ -54,6 +74,14 @@ func checklyGroup(group Group) (check checkly.Group) {
 		},
 	}
 
+	// Groups running only on private locations don't get the default public location
+	locations := checkValueArray(group.Locations, []string{"eu-west-1"})
+	var privateLocations *[]string
+	if len(group.PrivateLocations) > 0 {
+		locations = checkValueArray(group.Locations, []string{})
+		privateLocations = &group.PrivateLocations
+	}
+
 	check = checkly.Group{
 		Name:                      group.Name,
 		Activated:                 true,
@@ -61,12 +89,17 @@ func checklyGroup(group Group) (check checkly.Group) {
 		DoubleCheck:               false,
 		LocalSetupScript:          "",
 		LocalTearDownScript:       "",
-		Concurrency:               2,
-		Locations:                 checkValueArray(group.Locations, []string{"eu-west-1"}),
+		Concurrency:               checkValueInt(group.Concurrency, 2),
+		Locations:                 locations,
+		PrivateLocations:          privateLocations,
 		Tags:                      tags,
 		AlertSettings:             alertSettings,
+		APICheckDefaults:          group.APICheckDefaults,
 		UseGlobalAlertSettings:    false,
 		AlertChannelSubscriptions: group.AlertChannels,
+		RetryStrategy:             group.RetryStrategy,
+		RuntimeID:                 runtimeID(group.RuntimeID),
+		EnvironmentVariables:      checkValueEnvironmentVariables(group.EnvironmentVariables),
 	}
 
 	return
@@ -88,19 +121,19 @@ func GroupCreate(group Group, client checkly.Client) (ID int64, err error) {
 	return
 }
 
-func GroupUpdate(group Group, client checkly.Client) (err error) {
+func GroupUpdate(group Group, client checkly.Client) (state State, err error) {
 
 	groupSetup := checklyGroup(group)
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 	defer cancel()
 
-	_, err = client.UpdateGroup(ctx, group.ID, groupSetup)
+	gotGroup, err := client.UpdateGroup(ctx, group.ID, groupSetup)
 	if err != nil {
 		return
 	}
 
-	return
+	return State{Activated: gotGroup.Activated, Muted: gotGroup.Muted, Locations: gotGroup.Locations, UpdatedAt: gotGroup.UpdatedAt}, nil
 }
 
 func GroupDelete(ID int64, client checkly.Client) (err error) {
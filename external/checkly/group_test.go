@@ -16,7 +16,11 @@ limitations under the License.
 
 package external
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/checkly/checkly-go-sdk"
+)
 
 func TestChecklyGroup(t *testing.T) {
 	data := Group{
@@ -29,4 +33,65 @@ func TestChecklyGroup(t *testing.T) {
 	if testData.Name != data.Name {
 		t.Errorf("Expected %s, got %s", data.Name, testData.Name)
 	}
+
+	if testData.Concurrency != 2 {
+		t.Errorf("Expected %d, got %d", 2, testData.Concurrency)
+	}
+
+	if testData.PrivateLocations != nil {
+		t.Errorf("Expected no private locations, got %v", *testData.PrivateLocations)
+	}
+
+	privateData := Group{
+		Name:             "foo",
+		PrivateLocations: []string{"behind-the-firewall"},
+	}
+	privateTestData := checklyGroup(privateData)
+
+	if privateTestData.PrivateLocations == nil || len(*privateTestData.PrivateLocations) != 1 {
+		t.Errorf("Expected %v, got %v", privateData.PrivateLocations, privateTestData.PrivateLocations)
+	}
+
+	if len(privateTestData.Locations) != 0 {
+		t.Errorf("Expected no public locations, got %v", privateTestData.Locations)
+	}
+
+	data.APICheckDefaults = checkly.APICheckDefaults{
+		BaseURL:    "https://foo.bar",
+		Headers:    []checkly.KeyValue{{Key: "X-Foo", Value: "bar"}},
+		Assertions: []checkly.Assertion{{Source: checkly.StatusCode, Comparison: checkly.Equals, Target: "200"}},
+	}
+	testData = checklyGroup(data)
+
+	if testData.APICheckDefaults.BaseURL != data.APICheckDefaults.BaseURL {
+		t.Errorf("Expected %s, got %s", data.APICheckDefaults.BaseURL, testData.APICheckDefaults.BaseURL)
+	}
+
+	if len(testData.APICheckDefaults.Headers) != 1 || len(testData.APICheckDefaults.Assertions) != 1 {
+		t.Errorf("Expected %v, got %v", data.APICheckDefaults, testData.APICheckDefaults)
+	}
+
+	if testData.AlertSettings.EscalationType != checkly.RunBased || testData.AlertSettings.RunBasedEscalation.FailedRunThreshold != 5 {
+		t.Errorf("Expected %s after %d runs, got %v", checkly.RunBased, 5, testData.AlertSettings)
+	}
+
+	data.AlertSettings = &checkly.AlertSettings{
+		EscalationType:      checkly.TimeBased,
+		TimeBasedEscalation: checkly.TimeBasedEscalation{MinutesFailingThreshold: 10},
+		Reminders:           checkly.Reminders{Amount: 2},
+	}
+	data.Concurrency = 10
+	testData = checklyGroup(data)
+
+	if testData.Concurrency != data.Concurrency {
+		t.Errorf("Expected %d, got %d", data.Concurrency, testData.Concurrency)
+	}
+
+	if testData.AlertSettings.EscalationType != checkly.TimeBased || testData.AlertSettings.TimeBasedEscalation.MinutesFailingThreshold != 10 {
+		t.Errorf("Expected %s after %d minutes, got %v", checkly.TimeBased, 10, testData.AlertSettings)
+	}
+
+	if testData.AlertSettings.Reminders.Amount != 2 || testData.AlertSettings.Reminders.Interval != 5 {
+		t.Errorf("Expected %d reminders every %d minutes, got %v", 2, 5, testData.AlertSettings.Reminders)
+	}
 }
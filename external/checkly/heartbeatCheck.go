@@ -0,0 +1,126 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/checkly/checkly-go-sdk"
+)
+
+const heartbeatPingURL = "https://ping.checklyhq.com"
+
+// HeartbeatCheck is a struct for the internal packages to help put together the checkly heartbeat check
+type HeartbeatCheck struct {
+	Name          string
+	Namespace     string
+	Period        int
+	PeriodUnit    string
+	Grace         int
+	GraceUnit     string
+	ID            string
+	Muted         bool
+	Paused        bool
+	AlertChannels []checkly.AlertChannelSubscription
+	Labels        map[string]string
+	// Retained checks outlive their resource, they get no owner tag
+	Retained bool
+}
+
+func checklyHeartbeatCheck(heartbeatCheck HeartbeatCheck) (check checkly.HeartbeatCheck) {
+
+	tags := operatorTags(heartbeatCheck.Labels, nil)
+	tags = withOwnerTag(tags, heartbeatCheck.Namespace, heartbeatCheck.Name, heartbeatCheck.Retained)
+	tags = append(tags, heartbeatCheck.Namespace)
+
+	check = checkly.HeartbeatCheck{
+		Name:                      heartbeatCheck.Name,
+		Activated:                 !heartbeatCheck.Paused,
+		Muted:                     heartbeatCheck.Muted,
+		Tags:                      tags,
+		AlertSettings:             defaultAlertSettings(),
+		UseGlobalAlertSettings:    false,
+		AlertChannelSubscriptions: heartbeatCheck.AlertChannels,
+		Heartbeat: checkly.Heartbeat{
+			Period:     checkValueInt(heartbeatCheck.Period, 1),
+			PeriodUnit: checkValueString(heartbeatCheck.PeriodUnit, "days"),
+			Grace:      checkValueInt(heartbeatCheck.Grace, 1),
+			GraceUnit:  checkValueString(heartbeatCheck.GraceUnit, "hours"),
+		},
+	}
+
+	return
+}
+
+// pingURL returns the URL the monitored workload has to ping
+func pingURL(pingToken string) string {
+	if pingToken == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s", heartbeatPingURL, pingToken)
+}
+
+// CreateHeartbeatCheck creates a new checklyhq.com heartbeat check
+func CreateHeartbeatCheck(heartbeatCheck HeartbeatCheck, client checkly.Client) (ID string, PingURL string, err error) {
+
+	check := checklyHeartbeatCheck(heartbeatCheck)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	gotCheck, err := client.CreateHeartbeat(ctx, check)
+	if err != nil {
+		return
+	}
+
+	ID = gotCheck.ID
+	PingURL = pingURL(gotCheck.Heartbeat.PingToken)
+
+	return
+}
+
+// UpdateHeartbeatCheck updates an existing checklyhq.com heartbeat check
+func UpdateHeartbeatCheck(heartbeatCheck HeartbeatCheck, client checkly.Client) (PingURL string, state State, err error) {
+
+	check := checklyHeartbeatCheck(heartbeatCheck)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	gotCheck, err := client.UpdateHeartbeat(ctx, heartbeatCheck.ID, check)
+	if err != nil {
+		return
+	}
+
+	PingURL = pingURL(gotCheck.Heartbeat.PingToken)
+	state = State{Activated: gotCheck.Activated, Muted: gotCheck.Muted, UpdatedAt: gotCheck.UpdatedAt}
+
+	return
+}
+
+// DeleteHeartbeatCheck deletes an existing checklyhq.com heartbeat check
+func DeleteHeartbeatCheck(ID string, client checkly.Client) (err error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	err = client.DeleteCheck(ctx, ID)
+
+	return
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/checkly/checkly-go-sdk"
+)
+
+func TestChecklyHeartbeatCheck(t *testing.T) {
+
+	data1 := HeartbeatCheck{
+		Name:       "foo",
+		Namespace:  "bar",
+		Period:     5,
+		PeriodUnit: "minutes",
+		Grace:      30,
+		GraceUnit:  "seconds",
+		Muted:      true,
+	}
+
+	testData := checklyHeartbeatCheck(data1)
+
+	if testData.Name != data1.Name {
+		t.Errorf("Expected %s, got %s", data1.Name, testData.Name)
+	}
+
+	if testData.Heartbeat.Period != data1.Period {
+		t.Errorf("Expected %d, got %d", data1.Period, testData.Heartbeat.Period)
+	}
+
+	if testData.Heartbeat.PeriodUnit != data1.PeriodUnit {
+		t.Errorf("Expected %s, got %s", data1.PeriodUnit, testData.Heartbeat.PeriodUnit)
+	}
+
+	if testData.Heartbeat.Grace != data1.Grace {
+		t.Errorf("Expected %d, got %d", data1.Grace, testData.Heartbeat.Grace)
+	}
+
+	if testData.Heartbeat.GraceUnit != data1.GraceUnit {
+		t.Errorf("Expected %s, got %s", data1.GraceUnit, testData.Heartbeat.GraceUnit)
+	}
+
+	if testData.Muted != data1.Muted {
+		t.Errorf("Expected %t, got %t", data1.Muted, testData.Muted)
+	}
+
+	data2 := HeartbeatCheck{
+		Name:      "foo",
+		Namespace: "bar",
+	}
+
+	testData = checklyHeartbeatCheck(data2)
+
+	if testData.Heartbeat.Period != 1 {
+		t.Errorf("Expected %d, got %d", 1, testData.Heartbeat.Period)
+	}
+
+	if testData.Heartbeat.PeriodUnit != "days" {
+		t.Errorf("Expected %s, got %s", "days", testData.Heartbeat.PeriodUnit)
+	}
+
+	if testData.Heartbeat.Grace != 1 {
+		t.Errorf("Expected %d, got %d", 1, testData.Heartbeat.Grace)
+	}
+
+	if testData.Heartbeat.GraceUnit != "hours" {
+		t.Errorf("Expected %s, got %s", "hours", testData.Heartbeat.GraceUnit)
+	}
+}
+
+func TestChecklyHeartbeatCheckActions(t *testing.T) {
+
+	expectedCheckID := "2"
+	expectedPingURL := "https://ping.checklyhq.com/foo-token"
+	testData := HeartbeatCheck{
+		Name:      "foo",
+		Namespace: "bar",
+	}
+
+	heartbeatResponse := func(w http.ResponseWriter, status int) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		resp := make(map[string]interface{})
+		resp["id"] = expectedCheckID
+		resp["heartbeat"] = map[string]string{"pingToken": "foo-token"}
+		jsonResp, _ := json.Marshal(resp)
+		w.Write(jsonResp)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/checks/heartbeat", func(w http.ResponseWriter, _ *http.Request) {
+		heartbeatResponse(w, http.StatusCreated)
+	})
+	mux.HandleFunc("/v1/checks/heartbeat/2", func(w http.ResponseWriter, _ *http.Request) {
+		heartbeatResponse(w, http.StatusOK)
+	})
+	mux.HandleFunc("/v1/checks/2", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	testClient := checkly.NewClient(
+		server.URL,
+		"foobarbaz",
+		nil,
+		nil,
+	)
+	testClient.SetAccountId("1234567890")
+
+	testID, testPingURL, err := CreateHeartbeatCheck(testData, testClient)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+
+	if testID != expectedCheckID {
+		t.Errorf("Expected %s, got %s", expectedCheckID, testID)
+	}
+
+	if testPingURL != expectedPingURL {
+		t.Errorf("Expected %s, got %s", expectedPingURL, testPingURL)
+	}
+
+	testData.ID = testID
+
+	testPingURL, _, err = UpdateHeartbeatCheck(testData, testClient)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+
+	if testPingURL != expectedPingURL {
+		t.Errorf("Expected %s, got %s", expectedPingURL, testPingURL)
+	}
+
+	err = DeleteHeartbeatCheck(testID, testClient)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+
+	// Test errors
+	server.Close()
+
+	_, _, err = CreateHeartbeatCheck(testData, testClient)
+	if err == nil {
+		t.Error("Expected error, got none")
+	}
+
+	_, _, err = UpdateHeartbeatCheck(testData, testClient)
+	if err == nil {
+		t.Error("Expected error, got none")
+	}
+
+	err = DeleteHeartbeatCheck(testID, testClient)
+	if err == nil {
+		t.Error("Expected error, got none")
+	}
+}
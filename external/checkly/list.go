@@ -0,0 +1,125 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/checkly/checkly-go-sdk"
+)
+
+// listPageSize is the largest page the list endpoints of the checklyhq.com API return
+const listPageSize = 100
+
+// Lister lists the resources of a checklyhq.com account, the SDK only reads them by ID
+type Lister struct {
+	// BaseURL is the URL of the checklyhq.com API, ex. https://api.checklyhq.com
+	BaseURL string
+	// HTTPClient sends the requests, it has to authenticate them
+	HTTPClient *http.Client
+}
+
+// NewLister returns a lister which authenticates with the credentials
+func NewLister(baseURL string, credentials *Credentials) *Lister {
+	return &Lister{
+		BaseURL:    baseURL,
+		HTTPClient: &http.Client{Transport: &credentialsTransport{credentials: credentials, base: NewHTTPClient().Transport}},
+	}
+}
+
+// Checks returns the checks of the account
+func (l *Lister) Checks(ctx context.Context) ([]checkly.Check, error) {
+	return listAll[checkly.Check](ctx, l, "checks")
+}
+
+// Groups returns the check groups of the account
+func (l *Lister) Groups(ctx context.Context) ([]checkly.Group, error) {
+	return listAll[checkly.Group](ctx, l, "check-groups")
+}
+
+// AlertChannelIDs returns the IDs of the alert channels of the account, the configuration of an alert
+// channel depends on its type and is only decoded by the SDK, see checkly.Client.GetAlertChannel
+func (l *Lister) AlertChannelIDs(ctx context.Context) ([]int64, error) {
+	alertChannels, err := listAll[struct {
+		ID int64 `json:"id"`
+	}](ctx, l, "alert-channels")
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(alertChannels))
+	for _, alertChannel := range alertChannels {
+		ids = append(ids, alertChannel.ID)
+	}
+
+	return ids, nil
+}
+
+// listAll reads the pages of the list endpoint until a page isn't full
+func listAll[T any](ctx context.Context, l *Lister, path string) (items []T, err error) {
+	for page := 1; ; page++ {
+		var pageItems []T
+		pageItems, err = listPage[T](ctx, l, path, page)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, pageItems...)
+		if len(pageItems) < listPageSize {
+			return items, nil
+		}
+	}
+}
+
+func listPage[T any](ctx context.Context, l *Lister, path string, page int) (items []T, err error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(listPageSize))
+	query.Set("page", strconv.Itoa(page))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/v1/%s?%s", l.BaseURL, path, query.Encode()), nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := l.HTTPClient.Do(req)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return
+	}
+	// The same format as the SDK, so AsAPIError reads the response
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response status %d: %q", resp.StatusCode, body)
+	}
+
+	err = json.Unmarshal(body, &items)
+
+	return
+}
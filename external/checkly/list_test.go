@@ -0,0 +1,87 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestLister(t *testing.T) {
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/checks", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer foobarbaz" || r.Header.Get("x-checkly-account") != "1234567890" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		// A full first page and a partial second one
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		count := map[int]int{1: listPageSize, 2: 3}[page]
+		var resp []map[string]interface{}
+		for i := 0; i < count; i++ {
+			resp = append(resp, map[string]interface{}{"id": fmt.Sprintf("check-%d-%d", page, i), "checkType": "API"})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		jsonResp, _ := json.Marshal(resp)
+		w.Write(jsonResp)
+	})
+	mux.HandleFunc("/v1/alert-channels", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"id":1,"type":"EMAIL","config":{"address":"foo@bar.baz"}},{"id":2,"type":"SLACK","config":{}}]`))
+	})
+	mux.HandleFunc("/v1/check-groups", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"statusCode":403,"error":"Forbidden","message":"Missing scope"}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	lister := NewLister(server.URL, NewCredentials("foobarbaz", "1234567890"))
+
+	checks, err := lister.Checks(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %e", err)
+	}
+	if len(checks) != listPageSize+3 {
+		t.Errorf("Expected %d checks, got %d", listPageSize+3, len(checks))
+	}
+	if checks[listPageSize].ID != "check-2-0" {
+		t.Errorf("Expected the second page after the first one, got %s", checks[listPageSize].ID)
+	}
+
+	ids, err := lister.AlertChannelIDs(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %e", err)
+	}
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Errorf("Expected [1 2], got %v", ids)
+	}
+
+	_, err = lister.Groups(context.Background())
+	apiErr, ok := AsAPIError(err)
+	if !ok || apiErr.StatusCode != http.StatusForbidden {
+		t.Errorf("Expected a 403 API error, got %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	apiRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "checkly_operator_api_requests_total",
+		Help: "Number of checklyhq.com API requests by resource, method and status code",
+	}, []string{"resource", "method", "code"})
+	apiRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "checkly_operator_api_request_duration_seconds",
+		Help:    "Latency of the checklyhq.com API requests by resource and method",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"resource", "method"})
+	apiRateLimited = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "checkly_operator_api_rate_limited_total",
+		Help: "Number of checklyhq.com API requests rejected by the rate limit",
+	}, []string{"resource", "method"})
+	apiRateLimitWait = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "checkly_operator_api_rate_limit_wait_seconds_total",
+		Help: "Seconds the checklyhq.com API requests were held back until the rate limit reset",
+	})
+	apiCacheHits = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "checkly_operator_api_cache_hits_total",
+		Help: "Number of checklyhq.com API reads answered from the cache by resource",
+	}, []string{"resource"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(apiRequests, apiRequestDuration, apiRateLimited, apiRateLimitWait, apiCacheHits)
+}
+
+// NewHTTPClient returns the http client of the checkly clients, it records the metrics of the
+// checklyhq.com API requests, backs off when the rate limit of the account is reached and shares the reads
+// of the resources used by many reconciles
+func NewHTTPClient() *http.Client {
+	return &http.Client{Transport: &readCacheTransport{
+		base:  &rateLimitTransport{base: &metricsTransport{base: http.DefaultTransport}, limits: rateLimits},
+		cache: readCache,
+	}}
+}
+
+// metricsTransport records the count, latency and status code of the requests
+type metricsTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resource := apiResource(req.URL.Path)
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	apiRequestDuration.WithLabelValues(resource, req.Method).Observe(time.Since(start).Seconds())
+
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+		if resp.StatusCode == http.StatusTooManyRequests {
+			apiRateLimited.WithLabelValues(resource, req.Method).Inc()
+		}
+	}
+	apiRequests.WithLabelValues(resource, req.Method, code).Inc()
+
+	return resp, err
+}
+
+// apiResource returns the resource of the API path without the IDs, /v1/checks/<id> is checks,
+// so the metrics have a bounded number of labels
+func apiResource(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 2 {
+		return parts[0]
+	}
+
+	return parts[1]
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/checkly/checkly-go-sdk"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetricsTransport(t *testing.T) {
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/checks/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"1"}`))
+	})
+	mux.HandleFunc("/v1/checks/2", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	// Without the rate limit transport, which sends the rate limited request again
+	testClient := checkly.NewClient(server.URL, "foobarbaz", &http.Client{Transport: &metricsTransport{base: http.DefaultTransport}}, nil)
+
+	requests := testutil.ToFloat64(apiRequests.WithLabelValues("checks", http.MethodGet, "200"))
+	rateLimited := testutil.ToFloat64(apiRateLimited.WithLabelValues("checks", http.MethodGet))
+
+	if _, err := testClient.GetCheck(context.Background(), "1"); err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+	if _, err := testClient.GetCheck(context.Background(), "2"); err == nil {
+		t.Error("Expected an error for the rate limited request")
+	}
+
+	if got := testutil.ToFloat64(apiRequests.WithLabelValues("checks", http.MethodGet, "200")); got != requests+1 {
+		t.Errorf("Expected %v requests, got %v", requests+1, got)
+	}
+	if got := testutil.ToFloat64(apiRateLimited.WithLabelValues("checks", http.MethodGet)); got != rateLimited+1 {
+		t.Errorf("Expected %v rate limited requests, got %v", rateLimited+1, got)
+	}
+}
+
+func TestApiResource(t *testing.T) {
+	for path, resource := range map[string]string{
+		"/v1/checks/1":            "checks",
+		"/v1/check-groups":        "check-groups",
+		"/v1/triggers/checks/abc": "triggers",
+		"/":                       "",
+	} {
+		if got := apiResource(path); got != resource {
+			t.Errorf("Expected %s for %s, got %s", resource, path, got)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/checkly/checkly-go-sdk"
+)
+
+// TypeMultiStep is used to identify a multi-step check, the SDK has no constant for it
+const TypeMultiStep = "MULTI_STEP"
+
+// MultiStepCheck is a struct for the internal packages to help put together the checkly multi-step check
+type MultiStepCheck struct {
+	Name      string
+	Namespace string
+	Frequency int
+	Locations []string
+	Runtime   string
+	Script    string
+	GroupID   int64
+	ID        string
+	Muted     bool
+	Paused    bool
+	Labels    map[string]string
+	Tags      []string
+	// EnvironmentVariables replace the environment variables of the check
+	EnvironmentVariables []checkly.EnvironmentVariable
+	RetryStrategy        *checkly.RetryStrategy
+	// Retained checks outlive their resource, they get no owner tag
+	Retained bool
+}
+
+func checklyMultiStepCheck(multiStepCheck MultiStepCheck) (check checkly.Check, err error) {
+
+	if multiStepCheck.Script == "" {
+		err = errors.New("multi-step check script is empty")
+		return
+	}
+
+	tags := operatorTags(multiStepCheck.Labels, multiStepCheck.Tags)
+	tags = withOwnerTag(tags, multiStepCheck.Namespace, multiStepCheck.Name, multiStepCheck.Retained)
+	tags = append(tags, multiStepCheck.Namespace)
+
+	check = checkly.Check{
+		Name:                   multiStepCheck.Name,
+		Type:                   TypeMultiStep,
+		Frequency:              checkValueInt(multiStepCheck.Frequency, 10),
+		Activated:              !multiStepCheck.Paused,
+		Muted:                  multiStepCheck.Muted,
+		ShouldFail:             false,
+		DoubleCheck:            false,
+		SSLCheck:               false,
+		Locations:              checkValueArray(multiStepCheck.Locations, []string{}),
+		Script:                 multiStepCheck.Script,
+		EnvironmentVariables:   checkValueEnvironmentVariables(multiStepCheck.EnvironmentVariables),
+		RetryStrategy:          multiStepCheck.RetryStrategy,
+		Tags:                   tags,
+		AlertSettings:          defaultAlertSettings(),
+		UseGlobalAlertSettings: false,
+		GroupID:                multiStepCheck.GroupID,
+		RuntimeID:              runtimeID(multiStepCheck.Runtime),
+	}
+
+	return
+}
+
+// CreateMultiStepCheck creates a new checklyhq.com multi-step check
+func CreateMultiStepCheck(multiStepCheck MultiStepCheck, client checkly.Client) (ID string, err error) {
+
+	check, err := checklyMultiStepCheck(multiStepCheck)
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	gotCheck, err := client.CreateCheck(ctx, check)
+	if err != nil {
+		return
+	}
+
+	ID = gotCheck.ID
+
+	return
+}
+
+// UpdateMultiStepCheck updates an existing checklyhq.com multi-step check
+func UpdateMultiStepCheck(multiStepCheck MultiStepCheck, client checkly.Client) (state State, err error) {
+
+	check, err := checklyMultiStepCheck(multiStepCheck)
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	gotCheck, err := client.UpdateCheck(ctx, multiStepCheck.ID, check)
+	if err != nil {
+		return
+	}
+
+	return checkState(gotCheck), nil
+}
+
+// DeleteMultiStepCheck deletes an existing checklyhq.com multi-step check
+func DeleteMultiStepCheck(ID string, client checkly.Client) (err error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	err = client.DeleteCheck(ctx, ID)
+
+	return
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/checkly/checkly-go-sdk"
+)
+
+func TestChecklyMultiStepCheck(t *testing.T) {
+
+	data1 := MultiStepCheck{
+		Name:      "foo",
+		Namespace: "bar",
+		Frequency: 15,
+		Locations: []string{"eu-west-1"},
+		Runtime:   "2023.09",
+		Script:    "console.log('foo')",
+		Muted:     true,
+
+		RetryStrategy: &checkly.RetryStrategy{Type: "LINEAR", BaseBackoffSeconds: 30, MaxRetries: 3, MaxDurationSeconds: 600},
+	}
+
+	testData, _ := checklyMultiStepCheck(data1)
+
+	if testData.Name != data1.Name {
+		t.Errorf("Expected %s, got %s", data1.Name, testData.Name)
+	}
+
+	if testData.Type != TypeMultiStep {
+		t.Errorf("Expected %s, got %s", TypeMultiStep, testData.Type)
+	}
+
+	if testData.Frequency != data1.Frequency {
+		t.Errorf("Expected %d, got %d", data1.Frequency, testData.Frequency)
+	}
+
+	if testData.Script != data1.Script {
+		t.Errorf("Expected %s, got %s", data1.Script, testData.Script)
+	}
+
+	if testData.RuntimeID == nil || *testData.RuntimeID != data1.Runtime {
+		t.Errorf("Expected %s, got %v", data1.Runtime, testData.RuntimeID)
+	}
+
+	if len(testData.Locations) != 1 {
+		t.Errorf("Expected %d, got %d", 1, len(testData.Locations))
+	}
+
+	if testData.Muted != data1.Muted {
+		t.Errorf("Expected %t, got %t", data1.Muted, testData.Muted)
+	}
+
+	if testData.RetryStrategy != data1.RetryStrategy {
+		t.Errorf("Expected %v, got %v", data1.RetryStrategy, testData.RetryStrategy)
+	}
+
+	data2 := MultiStepCheck{
+		Name:      "foo",
+		Namespace: "bar",
+		Script:    "console.log('foo')",
+	}
+
+	testData, _ = checklyMultiStepCheck(data2)
+
+	if testData.Frequency != 10 {
+		t.Errorf("Expected %d, got %d", 10, testData.Frequency)
+	}
+
+	if testData.RuntimeID != nil {
+		t.Errorf("Expected nil, got %s", *testData.RuntimeID)
+	}
+
+	if len(testData.Locations) != 0 {
+		t.Errorf("Expected %d, got %d", 0, len(testData.Locations))
+	}
+
+	failData := MultiStepCheck{
+		Name:      "fail",
+		Namespace: "bar",
+	}
+
+	_, err := checklyMultiStepCheck(failData)
+	if err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
+
+func TestChecklyMultiStepCheckActions(t *testing.T) {
+
+	expectedCheckID := "2"
+	testData := MultiStepCheck{
+		Name:      "foo",
+		Namespace: "bar",
+		Frequency: 15,
+		Script:    "console.log('foo')",
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/checks/multistep", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		resp := make(map[string]string)
+		resp["id"] = expectedCheckID
+		jsonResp, _ := json.Marshal(resp)
+		w.Write(jsonResp)
+	})
+	mux.HandleFunc("/v1/checks/2", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			resp := make(map[string]interface{})
+			resp["id"] = expectedCheckID
+			resp["activated"] = true
+			resp["muted"] = true
+			resp["locations"] = []string{"eu-west-1"}
+			jsonResp, _ := json.Marshal(resp)
+			w.Write(jsonResp)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	testClient := checkly.NewClient(
+		server.URL,
+		"foobarbaz",
+		nil,
+		nil,
+	)
+	testClient.SetAccountId("1234567890")
+
+	testID, err := CreateMultiStepCheck(testData, testClient)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+
+	if testID != expectedCheckID {
+		t.Errorf("Expected %s, got %s", expectedCheckID, testID)
+	}
+
+	testData.ID = testID
+
+	state, err := UpdateMultiStepCheck(testData, testClient)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+
+	if !state.Activated || !state.Muted {
+		t.Errorf("Expected activated and muted state, got %+v", state)
+	}
+
+	if len(state.Locations) != 1 || state.Locations[0] != "eu-west-1" {
+		t.Errorf("Expected locations [eu-west-1], got %v", state.Locations)
+	}
+
+	err = DeleteMultiStepCheck(testID, testClient)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+
+	// Test errors
+	server.Close()
+
+	_, err = CreateMultiStepCheck(testData, testClient)
+	if err == nil {
+		t.Error("Expected error, got none")
+	}
+
+	_, err = UpdateMultiStepCheck(testData, testClient)
+	if err == nil {
+		t.Error("Expected error, got none")
+	}
+
+	err = DeleteMultiStepCheck(testID, testClient)
+	if err == nil {
+		t.Error("Expected error, got none")
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/checkly/checkly-go-sdk"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+// CheckPayloadHash returns the hash of the payload the check is created and updated with, the payload has
+// no ID, so it's the same for both
+func CheckPayloadHash(apiCheck Check) (string, error) {
+	check, err := checklyCheck(apiCheck)
+	if err != nil {
+		return "", err
+	}
+
+	return payloadHash(check), nil
+}
+
+// GroupPayloadHash returns the hash of the payload the group is created and updated with
+func GroupPayloadHash(group Group) string {
+	return payloadHash(checklyGroup(group))
+}
+
+// AlertChannelPayloadHash returns the hash of the payload the alert channel is created and updated with
+func AlertChannelPayloadHash(alertChannel *checklyv1alpha1.AlertChannel, config AlertChannelConfig) (string, error) {
+	ac, err := checklyAlertChannel(alertChannel, config)
+	if err != nil {
+		return "", err
+	}
+
+	// The configuration of the type isn't part of the JSON of the SDK type, it's sent as the config
+	return payloadHash(struct {
+		checkly.AlertChannel
+		Config map[string]interface{} `json:"config"`
+	}{ac, ac.GetConfig()}), nil
+}
+
+// payloadHash returns the hex encoded SHA-256 of the JSON encoded payload
+func payloadHash(payload interface{}) string {
+	data, _ := json.Marshal(payload)
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
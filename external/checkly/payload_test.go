@@ -0,0 +1,77 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"testing"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+func TestPayloadHash(t *testing.T) {
+	check := Check{
+		Name:        "foo",
+		Namespace:   "bar",
+		Endpoint:    "https://foo.bar/baz",
+		SuccessCode: "200",
+		Labels:      map[string]string{"a": "1", "b": "2", "c": "3", "d": "4"},
+	}
+
+	hash, err := CheckPayloadHash(check)
+	if err != nil {
+		t.Fatalf("Expected no error, got %e", err)
+	}
+
+	// The labels are a map, the hash mustn't depend on its order
+	for i := 0; i < 10; i++ {
+		if got, _ := CheckPayloadHash(check); got != hash {
+			t.Fatalf("Expected the same hash, got %s and %s", hash, got)
+		}
+	}
+
+	check.ID = "2"
+	if got, _ := CheckPayloadHash(check); got != hash {
+		t.Errorf("Expected the ID to be left out of the hash")
+	}
+
+	check.Muted = true
+	if got, _ := CheckPayloadHash(check); got == hash {
+		t.Errorf("Expected another hash for a changed check")
+	}
+
+	if _, err := CheckPayloadHash(Check{SuccessCode: "foo"}); err == nil {
+		t.Error("Expected an error for an invalid check")
+	}
+
+	group := Group{Name: "foo", ID: 1}
+	groupHash := GroupPayloadHash(group)
+	group.ID = 2
+	if GroupPayloadHash(group) != groupHash {
+		t.Errorf("Expected the ID to be left out of the group hash")
+	}
+
+	alertChannel := &checklyv1alpha1.AlertChannel{}
+	alertChannel.Spec.Email.Address = "foo@bar.baz"
+	alertChannelHash, err := AlertChannelPayloadHash(alertChannel, AlertChannelConfig{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %e", err)
+	}
+	alertChannel.Spec.Email.Address = "bar@bar.baz"
+	if got, _ := AlertChannelPayloadHash(alertChannel, AlertChannelConfig{}); got == alertChannelHash {
+		t.Errorf("Expected another hash for a changed alert channel")
+	}
+}
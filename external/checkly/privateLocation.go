@@ -0,0 +1,82 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"context"
+	"time"
+
+	"github.com/checkly/checkly-go-sdk"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+func checklyPrivateLocation(privateLocation *checklyv1alpha1.PrivateLocation) (pl checkly.PrivateLocation) {
+
+	pl = checkly.PrivateLocation{
+		Name:     privateLocation.Name,
+		SlugName: checkValueString(privateLocation.Spec.SlugName, privateLocation.Name),
+		Icon:     checkValueString(privateLocation.Spec.Icon, "location"),
+	}
+
+	return
+}
+
+// CreatePrivateLocation creates a new checklyhq.com private location, the returned key holds the raw API key which is only available on creation
+func CreatePrivateLocation(privateLocation *checklyv1alpha1.PrivateLocation, client checkly.Client) (ID string, key checkly.PrivateLocationKey, err error) {
+
+	pl := checklyPrivateLocation(privateLocation)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	gotPrivateLocation, err := client.CreatePrivateLocation(ctx, pl)
+	if err != nil {
+		return
+	}
+
+	ID = gotPrivateLocation.ID
+	if len(gotPrivateLocation.Keys) != 0 {
+		key = gotPrivateLocation.Keys[0]
+	}
+
+	return
+}
+
+// UpdatePrivateLocation updates an existing checklyhq.com private location
+func UpdatePrivateLocation(privateLocation *checklyv1alpha1.PrivateLocation, client checkly.Client) (err error) {
+
+	pl := checklyPrivateLocation(privateLocation)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	_, err = client.UpdatePrivateLocation(ctx, privateLocation.Status.ID, pl)
+
+	return
+}
+
+// DeletePrivateLocation deletes an existing checklyhq.com private location
+func DeletePrivateLocation(privateLocation *checklyv1alpha1.PrivateLocation, client checkly.Client) (err error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	err = client.DeletePrivateLocation(ctx, privateLocation.Status.ID)
+
+	return
+}
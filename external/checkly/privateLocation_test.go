@@ -0,0 +1,160 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/checkly/checkly-go-sdk"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+func TestChecklyPrivateLocation(t *testing.T) {
+
+	data1 := &checklyv1alpha1.PrivateLocation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+		Spec: checklyv1alpha1.PrivateLocationSpec{
+			SlugName: "foo-bar",
+			Icon:     "cloud",
+		},
+	}
+
+	testData := checklyPrivateLocation(data1)
+
+	if testData.Name != data1.Name {
+		t.Errorf("Expected %s, got %s", data1.Name, testData.Name)
+	}
+
+	if testData.SlugName != data1.Spec.SlugName {
+		t.Errorf("Expected %s, got %s", data1.Spec.SlugName, testData.SlugName)
+	}
+
+	if testData.Icon != data1.Spec.Icon {
+		t.Errorf("Expected %s, got %s", data1.Spec.Icon, testData.Icon)
+	}
+
+	data2 := &checklyv1alpha1.PrivateLocation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+	}
+
+	testData = checklyPrivateLocation(data2)
+
+	if testData.SlugName != data2.Name {
+		t.Errorf("Expected %s, got %s", data2.Name, testData.SlugName)
+	}
+
+	if testData.Icon != "location" {
+		t.Errorf("Expected %s, got %s", "location", testData.Icon)
+	}
+}
+
+func TestPrivateLocationActions(t *testing.T) {
+
+	expectedID := "abc"
+	expectedRawKey := "pl_raw"
+	testData := &checklyv1alpha1.PrivateLocation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/private-locations", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		resp := make(map[string]interface{})
+		resp["id"] = expectedID
+		resp["keys"] = []map[string]string{{"rawKey": expectedRawKey, "maskedKey": "pl_***"}}
+		jsonResp, _ := json.Marshal(resp)
+		w.Write(jsonResp)
+	})
+	mux.HandleFunc("/v1/private-locations/abc", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			resp := make(map[string]string)
+			resp["id"] = expectedID
+			jsonResp, _ := json.Marshal(resp)
+			w.Write(jsonResp)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	testClient := checkly.NewClient(
+		server.URL,
+		"foobarbaz",
+		nil,
+		nil,
+	)
+	testClient.SetAccountId("1234567890")
+
+	testID, testKey, err := CreatePrivateLocation(testData, testClient)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+
+	if testID != expectedID {
+		t.Errorf("Expected %s, got %s", expectedID, testID)
+	}
+
+	if testKey.RawKey != expectedRawKey {
+		t.Errorf("Expected %s, got %s", expectedRawKey, testKey.RawKey)
+	}
+
+	testData.Status.ID = testID
+
+	err = UpdatePrivateLocation(testData, testClient)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+
+	err = DeletePrivateLocation(testData, testClient)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+
+	// Test errors
+	server.Close()
+
+	_, _, err = CreatePrivateLocation(testData, testClient)
+	if err == nil {
+		t.Error("Expected error, got none")
+	}
+
+	err = UpdatePrivateLocation(testData, testClient)
+	if err == nil {
+		t.Error("Expected error, got none")
+	}
+
+	err = DeletePrivateLocation(testData, testClient)
+	if err == nil {
+		t.Error("Expected error, got none")
+	}
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// defaultRateLimitDelay is the back off of rate limited requests without rate limit headers
+	defaultRateLimitDelay = 10 * time.Second
+	// maxRateLimitDelay caps the back off, so invalid headers don't stop the requests for long
+	maxRateLimitDelay = time.Minute
+)
+
+var rateLimitLog = log.Log.WithName("rate-limit")
+
+// rateLimits are the rate limits of the checklyhq.com accounts, they're shared by every client so the
+// controllers back off together
+var rateLimits = &rateLimiter{blockedUntil: map[string]time.Time{}}
+
+// rateLimiter holds back the requests of the accounts which reached their rate limit until it resets
+type rateLimiter struct {
+	mu           sync.Mutex
+	blockedUntil map[string]time.Time
+}
+
+// wait blocks until the account is allowed to send requests again, requests whose deadline is reached
+// before fail right away with a RateLimitError
+func (l *rateLimiter) wait(ctx context.Context, account string) error {
+	l.mu.Lock()
+	delay := time.Until(l.blockedUntil[account])
+	l.mu.Unlock()
+	if delay <= 0 {
+		return nil
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		return &RateLimitError{RetryAfter: delay.Round(time.Millisecond)}
+	}
+
+	apiRateLimitWait.Add(delay.Seconds())
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// observe reads the rate limit of the response and blocks the account when it's reached
+func (l *rateLimiter) observe(account string, resp *http.Response) {
+	delay, limited := rateLimitDelay(resp)
+	if !limited {
+		return
+	}
+
+	until := time.Now().Add(delay)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if until.After(l.blockedUntil[account]) {
+		l.blockedUntil[account] = until
+		rateLimitLog.V(1).Info("Rate limit of checklyhq.com reached, holding back the requests", "account", account, "delay", delay)
+	}
+}
+
+// rateLimitDelay returns how long the requests have to wait after the response, rejected requests wait for
+// the Retry-After header, otherwise requests wait for the X-RateLimit-Reset header once none remain
+func rateLimitDelay(resp *http.Response) (time.Duration, bool) {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if delay, ok := headerDelay(resp.Header, "Retry-After"); ok {
+			return delay, true
+		}
+		if delay, ok := headerDelay(resp.Header, "X-RateLimit-Reset"); ok {
+			return delay, true
+		}
+		return defaultRateLimitDelay, true
+	}
+
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		return headerDelay(resp.Header, "X-RateLimit-Reset")
+	}
+
+	return 0, false
+}
+
+// headerDelay returns the delay of a header in seconds or as an HTTP date, capped at maxRateLimitDelay
+func headerDelay(header http.Header, name string) (time.Duration, bool) {
+	value := header.Get(name)
+	if value == "" {
+		return 0, false
+	}
+
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		delay = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil {
+		delay = time.Until(date)
+	} else {
+		return 0, false
+	}
+
+	return min(max(delay, 0), maxRateLimitDelay), true
+}
+
+// rateLimitTransport holds back the requests of accounts which reached their rate limit, a request rejected
+// by the rate limit is sent once more after the back off
+type rateLimitTransport struct {
+	base   http.RoundTripper
+	limits *rateLimiter
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	account := req.Header.Get("x-checkly-account")
+	if err := t.limits.wait(req.Context(), account); err != nil {
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	t.limits.observe(account, resp)
+
+	// The body of the request has to be sent again
+	if resp.StatusCode != http.StatusTooManyRequests || (req.Body != nil && req.GetBody == nil) {
+		return resp, nil
+	}
+	retry := req.Clone(req.Context())
+	if req.Body != nil {
+		retry.Body, err = req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+	}
+	resp.Body.Close()
+
+	if err := t.limits.wait(req.Context(), account); err != nil {
+		return nil, err
+	}
+	resp, err = t.base.RoundTrip(retry)
+	if err != nil {
+		return resp, err
+	}
+	t.limits.observe(account, resp)
+
+	return resp, nil
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/checkly/checkly-go-sdk"
+)
+
+func TestRateLimitDelay(t *testing.T) {
+
+	for name, test := range map[string]struct {
+		code    int
+		header  map[string]string
+		delay   time.Duration
+		limited bool
+	}{
+		"ok":             {code: http.StatusOK, header: map[string]string{"X-RateLimit-Remaining": "5", "X-RateLimit-Reset": "3"}},
+		"none remaining": {code: http.StatusOK, header: map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "3"}, delay: 3 * time.Second, limited: true},
+		"retry after":    {code: http.StatusTooManyRequests, header: map[string]string{"Retry-After": "2", "X-RateLimit-Reset": "3"}, delay: 2 * time.Second, limited: true},
+		"reset":          {code: http.StatusTooManyRequests, header: map[string]string{"X-RateLimit-Reset": "3"}, delay: 3 * time.Second, limited: true},
+		"no headers":     {code: http.StatusTooManyRequests, delay: defaultRateLimitDelay, limited: true},
+		"capped":         {code: http.StatusTooManyRequests, header: map[string]string{"Retry-After": "3600"}, delay: maxRateLimitDelay, limited: true},
+		"invalid":        {code: http.StatusOK, header: map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "soon"}},
+	} {
+		resp := &http.Response{StatusCode: test.code, Header: http.Header{}}
+		for key, value := range test.header {
+			resp.Header.Set(key, value)
+		}
+
+		delay, limited := rateLimitDelay(resp)
+		if delay != test.delay || limited != test.limited {
+			t.Errorf("%s: expected %v, %v, got %v, %v", name, test.delay, test.limited, delay, limited)
+		}
+	}
+}
+
+func TestRateLimitTransport(t *testing.T) {
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The first request is rejected, the retry is the last one the rate limit allows
+		switch requests.Add(1) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", "1")
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id":"1","name":"foo"}`))
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id":"1","name":"foo"}`))
+		}
+	}))
+	defer server.Close()
+
+	limits := &rateLimiter{blockedUntil: map[string]time.Time{}}
+	testClient := checkly.NewClient(server.URL, "foobarbaz", &http.Client{Transport: &rateLimitTransport{base: http.DefaultTransport, limits: limits}}, nil)
+	testClient.SetAccountId("rate-limited")
+
+	check := checkly.Check{Name: "foo", Frequency: 15, Locations: []string{"eu-west-1"}, Request: checkly.Request{Method: http.MethodGet, URL: "https://foo.bar"}}
+	if _, err := testClient.Update(context.Background(), "1", check); err != nil {
+		t.Fatalf("Expected the rate limited update to be sent again, got %v", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("Expected 2 requests, got %d", got)
+	}
+
+	start := time.Now()
+	if _, err := testClient.GetCheck(context.Background(), "1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if waited := time.Since(start); waited < 500*time.Millisecond {
+		t.Errorf("Expected the request to wait for the rate limit reset, it waited %v", waited)
+	}
+
+	limits.observe("rate-limited", &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": {"60"}}})
+	if err := limits.wait(context.Background(), "another-account"); err != nil {
+		t.Errorf("Expected other accounts not to wait, got %v", err)
+	}
+
+	// Requests which would reach their deadline first fail right away
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	start = time.Now()
+	_, err := testClient.GetCheck(ctx, "1")
+	if waited := time.Since(start); waited > time.Second {
+		t.Errorf("Expected the request not to wait for the rate limit reset, it waited %v", waited)
+	}
+	rateLimitErr, ok := AsRateLimitError(err)
+	if !ok {
+		t.Fatalf("Expected a rate limit error, got %v", err)
+	}
+	if rateLimitErr.RetryAfter <= 55*time.Second || rateLimitErr.RetryAfter > time.Minute {
+		t.Errorf("Expected to retry in about a minute, got %v", rateLimitErr.RetryAfter)
+	}
+}
+
+func TestAsRateLimitError(t *testing.T) {
+
+	for name, test := range map[string]struct {
+		err        error
+		retryAfter time.Duration
+		ok         bool
+	}{
+		"typed":   {err: &RateLimitError{RetryAfter: time.Second}, retryAfter: time.Second, ok: true},
+		"wrapped": {err: fmt.Errorf("failed: %w", &RateLimitError{RetryAfter: time.Second}), retryAfter: time.Second, ok: true},
+		"sdk":     {err: errors.New(`HTTP request failed with: Get "https://api.checklyhq.com/v1/checks/1": rate limit of checklyhq.com reached, retry in 1m2.5s`), retryAfter: 62500 * time.Millisecond, ok: true},
+		"other":   {err: errors.New("unexpected response status 500: \"\"")},
+		"nil":     {},
+	} {
+		rateLimitErr, ok := AsRateLimitError(test.err)
+		if ok != test.ok || (ok && rateLimitErr.RetryAfter != test.retryAfter) {
+			t.Errorf("%s: expected %v, %v, got %v, %v", name, test.retryAfter, test.ok, rateLimitErr, ok)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ReadCacheTTL is how long the reads of cachedResources are shared by the reconciles, 0 disables the cache
+var ReadCacheTTL = 30 * time.Second
+
+// cachedResources are the API resources read by the reconciles of many resources, ex. every check using
+// a runtime reads it
+var cachedResources = []string{"check-groups", "alert-channels", "runtimes"}
+
+// readCache is shared by every client, so the reconcilers share the reads
+var readCache = &responseCache{entries: map[string]cachedResponse{}}
+
+// cachedResponse is a successful read of a resource of the account and when it expires
+type cachedResponse struct {
+	account  string
+	resource string
+	header   http.Header
+	body     []byte
+	expires  time.Time
+}
+
+// responseCache holds the reads of the accounts by account and URL
+type responseCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedResponse
+}
+
+// get returns the unexpired read of the key
+func (c *responseCache) get(key string) (cachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, found := c.entries[key]
+	if found && time.Now().After(entry.expires) {
+		delete(c.entries, key)
+		return entry, false
+	}
+
+	return entry, found
+}
+
+// set stores the read of the key until it expires
+func (c *responseCache) set(key string, entry cachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = entry
+}
+
+// invalidate drops the reads of the resource, so the changes made through the API are read back
+func (c *responseCache) invalidate(account, resource string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, entry := range c.entries {
+		if entry.account == account && entry.resource == resource {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// readCacheTransport answers the reads of cachedResources from the cache, the writes invalidate the reads
+// of their resource
+type readCacheTransport struct {
+	base  http.RoundTripper
+	cache *responseCache
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *readCacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resource := apiResource(req.URL.Path)
+	if ReadCacheTTL <= 0 || !slices.Contains(cachedResources, resource) {
+		return t.base.RoundTrip(req)
+	}
+
+	account := req.Header.Get("x-checkly-account")
+	if req.Method != http.MethodGet {
+		t.cache.invalidate(account, resource)
+		return t.base.RoundTrip(req)
+	}
+
+	// Only reads of a single resource, ex. /v1/runtimes/<id>, are cached, not the lists
+	if strings.Count(strings.Trim(req.URL.Path, "/"), "/") != 2 || req.URL.RawQuery != "" {
+		return t.base.RoundTrip(req)
+	}
+
+	key := account + " " + req.URL.String()
+	if entry, found := t.cache.get(key); found {
+		apiCacheHits.WithLabelValues(resource).Inc()
+		return &http.Response{
+			Status:        "200 OK",
+			StatusCode:    http.StatusOK,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        entry.header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(entry.body)),
+			ContentLength: int64(len(entry.body)),
+			Request:       req,
+		}, nil
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	t.cache.set(key, cachedResponse{
+		account:  account,
+		resource: resource,
+		header:   resp.Header.Clone(),
+		body:     body,
+		expires:  time.Now().Add(ReadCacheTTL),
+	})
+
+	return resp, nil
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/checkly/checkly-go-sdk"
+)
+
+func TestReadCacheTransport(t *testing.T) {
+
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.Method+" "+r.URL.Path]++
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/runtimes/2024.02":
+			w.Write([]byte(`{"name":"2024.02"}`))
+		case "/v1/checks/1":
+			w.Write([]byte(`{"id":"1","name":"foo"}`))
+		default:
+			w.Write([]byte(`{"id":1,"name":"foo"}`))
+		}
+	}))
+	defer server.Close()
+
+	newClient := func(account string) checkly.Client {
+		client := checkly.NewClient(server.URL, "foobarbaz", &http.Client{Transport: &readCacheTransport{base: http.DefaultTransport, cache: &responseCache{entries: map[string]cachedResponse{}}}}, nil)
+		client.SetAccountId(account)
+		return client
+	}
+	testClient := newClient("1")
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		runtime, err := testClient.GetRuntime(ctx, "2024.02")
+		if err != nil || runtime.Name != "2024.02" {
+			t.Fatalf("Expected the runtime, got %v, %v", runtime, err)
+		}
+	}
+	if got := requests["GET /v1/runtimes/2024.02"]; got != 1 {
+		t.Errorf("Expected 1 runtime request, got %d", got)
+	}
+
+	// The updates invalidate the reads
+	if _, err := testClient.GetGroup(ctx, 1); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := testClient.UpdateGroup(ctx, 1, checkly.Group{Name: "foo"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := testClient.GetGroup(ctx, 1); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := requests["GET /v1/check-groups/1"]; got != 2 {
+		t.Errorf("Expected 2 group requests, got %d", got)
+	}
+
+	// The checks aren't cached
+	for i := 0; i < 2; i++ {
+		if _, err := testClient.GetCheck(ctx, "1"); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	if got := requests["GET /v1/checks/1"]; got != 2 {
+		t.Errorf("Expected 2 check requests, got %d", got)
+	}
+
+	// The reads expire
+	defaultTTL := ReadCacheTTL
+	defer func() { ReadCacheTTL = defaultTTL }()
+	ReadCacheTTL = time.Millisecond
+	testClient = newClient("1")
+	testClient.GetRuntime(ctx, "2024.02")
+	time.Sleep(5 * time.Millisecond)
+	testClient.GetRuntime(ctx, "2024.02")
+	if got := requests["GET /v1/runtimes/2024.02"]; got != 3 {
+		t.Errorf("Expected 3 runtime requests, got %d", got)
+	}
+
+	// Without a TTL nothing is cached
+	ReadCacheTTL = 0
+	testClient.GetRuntime(ctx, "2024.02")
+	testClient.GetRuntime(ctx, "2024.02")
+	if got := requests["GET /v1/runtimes/2024.02"]; got != 5 {
+		t.Errorf("Expected 5 runtime requests, got %d", got)
+	}
+}
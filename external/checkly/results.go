@@ -0,0 +1,57 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"context"
+	"time"
+
+	"github.com/checkly/checkly-go-sdk"
+)
+
+// checkResultsLimit is the number of recent results read to find the latest result of every location
+const checkResultsLimit = 100
+
+// LatestCheckResults returns the latest result of the check in every location it ran in the last hour
+func LatestCheckResults(ID string, client checkly.Client) (results []checkly.CheckResult, err error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	recent, err := client.GetCheckResults(ctx, ID, &checkly.CheckResultsFilter{
+		Limit: checkResultsLimit,
+		From:  time.Now().Add(-time.Hour).Unix(),
+	})
+	if err != nil {
+		return
+	}
+
+	latest := make(map[string]int)
+	for _, result := range recent {
+		i, ok := latest[result.RunLocation]
+		if !ok {
+			latest[result.RunLocation] = len(results)
+			results = append(results, result)
+			continue
+		}
+		if result.StartedAt.After(results[i].StartedAt) {
+			results[i] = result
+		}
+	}
+
+	return
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/checkly/checkly-go-sdk"
+)
+
+func TestLatestCheckResults(t *testing.T) {
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/check-results/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("limit") != "100" || r.URL.Query().Get("from") == "" {
+			t.Errorf("Expected the limit and from parameters, got %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[
+			{"id":"a","runLocation":"eu-west-1","hasFailures":true,"startedAt":"2024-01-01T10:00:00Z"},
+			{"id":"b","runLocation":"us-east-1","responseTime":120,"startedAt":"2024-01-01T10:00:00Z"},
+			{"id":"c","runLocation":"eu-west-1","startedAt":"2024-01-01T10:05:00Z"}
+		]`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	testClient := checkly.NewClient(
+		server.URL,
+		"foobarbaz",
+		nil,
+		nil,
+	)
+	testClient.SetAccountId("1234567890")
+
+	results, err := LatestCheckResults("1", testClient)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected a result per location, got %v", results)
+	}
+	if results[0].ID != "c" || results[1].ID != "b" {
+		t.Errorf("Expected the latest results, got %s and %s", results[0].ID, results[1].ID)
+	}
+
+	_, err = LatestCheckResults("2", testClient)
+	if err == nil {
+		t.Error("Expected an error for an unknown check")
+	}
+}
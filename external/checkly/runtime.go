@@ -0,0 +1,48 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/checkly/checkly-go-sdk"
+)
+
+// GetRuntime returns the checklyhq.com runtime, it errors if the account doesn't support the runtime
+func GetRuntime(ID string, client checkly.Client) (runtime *checkly.Runtime, err error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	runtime, err = client.GetRuntime(ctx, ID)
+	if err != nil {
+		err = fmt.Errorf("runtime %s is not supported: %w", ID, err)
+	}
+
+	return
+}
+
+// runtimeID returns the runtime ID of the checkly resources, nil lets checkly pick the default runtime
+func runtimeID(ID string) *string {
+	if ID == "" {
+		return nil
+	}
+
+	return &ID
+}
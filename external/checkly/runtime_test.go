@@ -0,0 +1,75 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/checkly/checkly-go-sdk"
+)
+
+func TestGetRuntime(t *testing.T) {
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/runtimes/2024.02", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		resp := make(map[string]interface{})
+		resp["name"] = "2024.02"
+		resp["multiStepSupport"] = true
+		jsonResp, _ := json.Marshal(resp)
+		w.Write(jsonResp)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	testClient := checkly.NewClient(
+		server.URL,
+		"foobarbaz",
+		nil,
+		nil,
+	)
+	testClient.SetAccountId("1234567890")
+
+	runtime, err := GetRuntime("2024.02", testClient)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+
+	if runtime == nil || !runtime.MultiStepSupport {
+		t.Errorf("Expected a runtime with multi step support, got %v", runtime)
+	}
+
+	_, err = GetRuntime("2019.01", testClient)
+	if err == nil {
+		t.Error("Expected error, got none")
+	}
+}
+
+func TestRuntimeID(t *testing.T) {
+
+	if runtimeID("") != nil {
+		t.Error("Expected nil for an empty runtime")
+	}
+
+	if ID := runtimeID("2024.02"); ID == nil || *ID != "2024.02" {
+		t.Errorf("Expected %s, got %v", "2024.02", ID)
+	}
+}
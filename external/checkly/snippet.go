@@ -0,0 +1,90 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/checkly/checkly-go-sdk"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+func checklySnippet(snippet *checklyv1alpha1.Snippet, script string) (s checkly.Snippet, err error) {
+
+	if script == "" {
+		err = errors.New("snippet script is empty")
+		return
+	}
+
+	s = checkly.Snippet{
+		Name:   snippet.Name,
+		Script: script,
+	}
+
+	return
+}
+
+// CreateSnippet creates a new checklyhq.com snippet
+func CreateSnippet(snippet *checklyv1alpha1.Snippet, script string, client checkly.Client) (ID int64, err error) {
+
+	s, err := checklySnippet(snippet, script)
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	gotSnippet, err := client.CreateSnippet(ctx, s)
+	if err != nil {
+		return
+	}
+
+	ID = gotSnippet.ID
+
+	return
+}
+
+// UpdateSnippet updates an existing checklyhq.com snippet
+func UpdateSnippet(snippet *checklyv1alpha1.Snippet, script string, client checkly.Client) (err error) {
+
+	s, err := checklySnippet(snippet, script)
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	_, err = client.UpdateSnippet(ctx, snippet.Status.ID, s)
+
+	return
+}
+
+// DeleteSnippet deletes an existing checklyhq.com snippet
+func DeleteSnippet(snippet *checklyv1alpha1.Snippet, client checkly.Client) (err error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	err = client.DeleteSnippet(ctx, snippet.Status.ID)
+
+	return
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/checkly/checkly-go-sdk"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+func TestChecklySnippet(t *testing.T) {
+
+	data := &checklyv1alpha1.Snippet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+	}
+
+	testData, err := checklySnippet(data, "console.log('foo')")
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+
+	if testData.Name != data.Name {
+		t.Errorf("Expected %s, got %s", data.Name, testData.Name)
+	}
+
+	if testData.Script != "console.log('foo')" {
+		t.Errorf("Expected %s, got %s", "console.log('foo')", testData.Script)
+	}
+
+	_, err = checklySnippet(data, "")
+	if err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
+
+func TestSnippetActions(t *testing.T) {
+
+	expectedSnippetID := 6
+	testScript := "console.log('foo')"
+	testData := &checklyv1alpha1.Snippet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/snippets", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		resp := make(map[string]interface{})
+		resp["id"] = expectedSnippetID
+		jsonResp, _ := json.Marshal(resp)
+		w.Write(jsonResp)
+	})
+	mux.HandleFunc("/v1/snippets/6", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			resp := make(map[string]interface{})
+			resp["id"] = expectedSnippetID
+			jsonResp, _ := json.Marshal(resp)
+			w.Write(jsonResp)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	testClient := checkly.NewClient(
+		server.URL,
+		"foobarbaz",
+		nil,
+		nil,
+	)
+	testClient.SetAccountId("1234567890")
+
+	testID, err := CreateSnippet(testData, testScript, testClient)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+
+	if testID != int64(expectedSnippetID) {
+		t.Errorf("Expected %d, got %d", expectedSnippetID, testID)
+	}
+
+	testData.Status.ID = testID
+
+	err = UpdateSnippet(testData, testScript, testClient)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+
+	err = DeleteSnippet(testData, testClient)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+
+	// Test errors
+	server.Close()
+
+	_, err = CreateSnippet(testData, testScript, testClient)
+	if err == nil {
+		t.Error("Expected error, got none")
+	}
+
+	err = UpdateSnippet(testData, testScript, testClient)
+	if err == nil {
+		t.Error("Expected error, got none")
+	}
+
+	err = DeleteSnippet(testData, testClient)
+	if err == nil {
+		t.Error("Expected error, got none")
+	}
+}
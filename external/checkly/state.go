@@ -0,0 +1,35 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"time"
+
+	"github.com/checkly/checkly-go-sdk"
+)
+
+// State is the state of a check or group as returned by checklyhq.com
+type State struct {
+	Activated bool
+	Muted     bool
+	Locations []string
+	UpdatedAt time.Time
+}
+
+func checkState(check *checkly.Check) State {
+	return State{Activated: check.Activated, Muted: check.Muted, Locations: check.Locations, UpdatedAt: check.UpdatedAt}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"context"
+	"time"
+
+	"github.com/checkly/checkly-go-sdk"
+)
+
+// CreateCheckTrigger creates a new checklyhq.com trigger for a check
+func CreateCheckTrigger(checkID string, client checkly.Client) (URL string, err error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	trigger, err := client.CreateTriggerCheck(ctx, checkID)
+	if err != nil {
+		return
+	}
+
+	URL = trigger.URL
+
+	return
+}
+
+// DeleteCheckTrigger deletes the checklyhq.com trigger of a check
+func DeleteCheckTrigger(checkID string, client checkly.Client) (err error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	err = client.DeleteTriggerCheck(ctx, checkID)
+
+	return
+}
+
+// CreateGroupTrigger creates a new checklyhq.com trigger for a group
+func CreateGroupTrigger(groupID int64, client checkly.Client) (URL string, err error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	trigger, err := client.CreateTriggerGroup(ctx, groupID)
+	if err != nil {
+		return
+	}
+
+	URL = trigger.URL
+
+	return
+}
+
+// DeleteGroupTrigger deletes the checklyhq.com trigger of a group
+func DeleteGroupTrigger(groupID int64, client checkly.Client) (err error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	err = client.DeleteTriggerGroup(ctx, groupID)
+
+	return
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/checkly/checkly-go-sdk"
+)
+
+func TestTriggerActions(t *testing.T) {
+
+	expectedToken := "abc"
+	checkID := "2"
+	var groupID int64 = 3
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/triggers/checks/2", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			resp := make(map[string]interface{})
+			resp["token"] = expectedToken
+			jsonResp, _ := json.Marshal(resp)
+			w.Write(jsonResp)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	mux.HandleFunc("/v1/triggers/check-groups/3", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			resp := make(map[string]interface{})
+			resp["token"] = expectedToken
+			jsonResp, _ := json.Marshal(resp)
+			w.Write(jsonResp)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	testClient := checkly.NewClient(
+		server.URL,
+		"foobarbaz",
+		nil,
+		nil,
+	)
+	testClient.SetAccountId("1234567890")
+
+	expectedCheckURL := fmt.Sprintf("%s/checks/%s/trigger/%s", server.URL, checkID, expectedToken)
+	testURL, err := CreateCheckTrigger(checkID, testClient)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+
+	if testURL != expectedCheckURL {
+		t.Errorf("Expected %s, got %s", expectedCheckURL, testURL)
+	}
+
+	err = DeleteCheckTrigger(checkID, testClient)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+
+	expectedGroupURL := fmt.Sprintf("%s/check-groups/%d/trigger/%s", server.URL, groupID, expectedToken)
+	testURL, err = CreateGroupTrigger(groupID, testClient)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+
+	if testURL != expectedGroupURL {
+		t.Errorf("Expected %s, got %s", expectedGroupURL, testURL)
+	}
+
+	err = DeleteGroupTrigger(groupID, testClient)
+	if err != nil {
+		t.Errorf("Expected no error, got %e", err)
+	}
+
+	// Test errors
+	server.Close()
+
+	_, err = CreateCheckTrigger(checkID, testClient)
+	if err == nil {
+		t.Error("Expected error, got none")
+	}
+
+	err = DeleteCheckTrigger(checkID, testClient)
+	if err == nil {
+		t.Error("Expected error, got none")
+	}
+
+	_, err = CreateGroupTrigger(groupID, testClient)
+	if err == nil {
+		t.Error("Expected error, got none")
+	}
+
+	err = DeleteGroupTrigger(groupID, testClient)
+	if err == nil {
+		t.Error("Expected error, got none")
+	}
+}
@@ -18,14 +18,17 @@ package checkly
 
 import (
 	"context"
-	errs "errors"
 	"fmt"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -35,12 +38,19 @@ import (
 	external "github.com/checkly/checkly-operator/external/checkly"
 )
 
+// alertChannelResyncPeriod determines how often the alert channel is pushed to checklyhq.com again without a
+// ResyncInterval, which reverts changes made in the checklyhq.com UI and picks up rotated secrets
+const alertChannelResyncPeriod = 10 * time.Minute
+
 // AlertChannelReconciler reconciles a AlertChannel object
 type AlertChannelReconciler struct {
 	client.Client
 	Scheme           *runtime.Scheme
 	ApiClient        checkly.Client
 	ControllerDomain string
+	ResourceSelector labels.Selector
+	Recorder         record.EventRecorder
+	ResyncInterval   time.Duration
 }
 
 //+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=alertchannels,verbs=get;list;watch;create;update;patch;delete
@@ -53,7 +63,7 @@ type AlertChannelReconciler struct {
 //
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.12.1/pkg/reconcile
-func (r *AlertChannelReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *AlertChannelReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	logger := log.FromContext(ctx)
 
 	logger.V(1).Info("Reconciler started")
@@ -62,7 +72,7 @@ func (r *AlertChannelReconciler) Reconcile(ctx context.Context, req ctrl.Request
 
 	ac := &checklyv1alpha1.AlertChannel{}
 
-	err := r.Get(ctx, req.NamespacedName, ac)
+	err = r.Get(ctx, req.NamespacedName, ac)
 
 	// ////////////////////////////////
 	// Delete Logic
@@ -71,6 +81,7 @@ func (r *AlertChannelReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		if errors.IsNotFound(err) {
 			// The resource has been deleted
 			logger.V(1).Info("Deleted", "checkly AlertChannel ID", ac.Status.ID)
+			forgetFailures(ac, req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		// Error reading the object
@@ -78,21 +89,45 @@ func (r *AlertChannelReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{}, nil
 	}
 
+	// /////////////////////////////
+	// Account lookup
+	// ////////////////////////////
+	// A failed lookup fails the delete of a deleted resource like an API error would, so the force-delete
+	// annotation still removes its finalizer once the account or its Secret is gone
+	apiClient, accountErr := apiClientForAccount(ctx, r.Client, r.ApiClient, ac.Spec.Account, ac)
+	if accountErr != nil {
+		logger.Error(accountErr, "Unable to read credentials of the account", "account", ac.Spec.Account)
+		if ac.GetDeletionTimestamp() == nil {
+			return ctrl.Result{}, accountErr
+		}
+	}
+
 	// ////////////////////////////////
 	// Remove Finalizer Logic
 	// ///////////////////////////////
 
 	if ac.GetDeletionTimestamp() != nil {
 		if controllerutil.ContainsFinalizer(ac, acFinalizer) {
-			logger.V(1).Info("Finalizer is present, trying to delete Checkly AlertChannel", "ID", ac.Status.ID)
-			err := external.DeleteAlertChannel(ac, r.ApiClient)
-			if err != nil {
-				logger.Error(err, "Failed to delete checkly AlertChannel")
-				return ctrl.Result{}, err
+			if alertChannelRetained(ac) {
+				logger.Info("Checkly AlertChannel is retained, leaving it in place", "ID", ac.Status.ID)
+			} else {
+				logger.V(1).Info("Finalizer is present, trying to delete Checkly AlertChannel", "ID", ac.Status.ID)
+				err := accountErr
+				if err == nil {
+					err = external.DeleteAlertChannel(ac, apiClient)
+				}
+				if err != nil {
+					recordSyncFailed(r.Recorder, ac, "delete", "alert channel", err)
+					logger.Error(err, "Failed to delete checkly AlertChannel")
+					forced, retry, err := forceDelete(ctx, r.Recorder, r.ControllerDomain, ac, "alert channel", ac.Status.ID, err)
+					if !forced {
+						return retry, err
+					}
+				} else {
+					logger.V(1).Info("Successfully deleted checkly AlertChannel", "ID", ac.Status.ID)
+				}
 			}
 
-			logger.V(1).Info("Successfully deleted checkly AlertChannel", "ID", ac.Status.ID)
-
 			controllerutil.RemoveFinalizer(ac, acFinalizer)
 			err = r.Update(ctx, ac)
 			if err != nil {
@@ -100,6 +135,7 @@ func (r *AlertChannelReconciler) Reconcile(ctx context.Context, req ctrl.Request
 				return ctrl.Result{}, err
 			}
 			logger.V(1).Info("Successfully deleted finalizer from AlertChannel")
+			forgetFailures(ac, client.ObjectKeyFromObject(ac))
 		}
 		return ctrl.Result{}, nil
 	}
@@ -118,30 +154,37 @@ func (r *AlertChannelReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{}, nil
 	}
 
+	// /////////////////////////////
+	// Conditions
+	// ////////////////////////////
+	defer func() {
+		changed := setConditions(&ac.Status.Conditions, ac.Generation, ac.Status.ID != 0, result, err, alertChannelDegradation(ac))
+		if setApplied(ac.Status.Conditions, ac.Generation, ac.Spec, &ac.Status.ObservedGeneration, &ac.Status.LastAppliedSpecHash) {
+			changed = true
+		}
+		if setStalled(&ac.Status.Conditions, ac, "alert channel", err) {
+			changed = true
+		}
+		if changed {
+			statusErr := r.Status().Update(ctx, ac)
+			if statusErr != nil {
+				logger.Error(statusErr, "Failed to update AlertChannel conditions")
+			}
+		}
+	}()
+
 	// /////////////////////////////
 	// OpsGenie logic + secret retrieval
 	// ////////////////////////////
-	opsGenieConfig := checkly.AlertChannelOpsgenie{}
+	config := external.AlertChannelConfig{}
 	if ac.Spec.OpsGenie.APISecret != (corev1.ObjectReference{}) {
-		secret := &corev1.Secret{}
-		err := r.Get(ctx,
-			types.NamespacedName{
-				Name:      ac.Spec.OpsGenie.APISecret.Name,
-				Namespace: ac.Spec.OpsGenie.APISecret.Namespace},
-			secret)
+		secretValue, err := alertChannelSecretValue(ctx, r.Client, ac.Spec.OpsGenie.APISecret)
 		if err != nil {
 			logger.Error(err, "Unable to read secret for API Key")
 			return ctrl.Result{}, err
 		}
 
-		secretValue := string(secret.Data[ac.Spec.OpsGenie.APISecret.FieldPath])
-		if secretValue == "" {
-			secretErr := errs.New("secret value is empty")
-			logger.Error(secretErr, "Please add Opsgenie secret")
-			return ctrl.Result{}, err
-		}
-
-		opsGenieConfig = checkly.AlertChannelOpsgenie{
+		config.OpsGenie = checkly.AlertChannelOpsgenie{
 			Name:     ac.Name,
 			APIKey:   secretValue,
 			Region:   ac.Spec.OpsGenie.Region,
@@ -150,6 +193,106 @@ func (r *AlertChannelReconciler) Reconcile(ctx context.Context, req ctrl.Request
 
 	}
 
+	// /////////////////////////////
+	// Slack logic + secret retrieval
+	// ////////////////////////////
+	if ac.Spec.Slack != nil {
+		secretValue, err := alertChannelSecretValue(ctx, r.Client, ac.Spec.Slack.WebhookSecret)
+		if err != nil {
+			logger.Error(err, "Unable to read secret for the Slack webhook URL")
+			return ctrl.Result{}, err
+		}
+
+		config.Slack = checkly.AlertChannelSlack{
+			WebhookURL: secretValue,
+			Channel:    ac.Spec.Slack.Channel,
+		}
+	}
+
+	// /////////////////////////////
+	// Pagerduty logic + secret retrieval
+	// ////////////////////////////
+	if ac.Spec.Pagerduty != nil {
+		secretValue, err := alertChannelSecretValue(ctx, r.Client, ac.Spec.Pagerduty.ServiceKeySecret)
+		if err != nil {
+			logger.Error(err, "Unable to read secret for the PagerDuty service key")
+			return ctrl.Result{}, err
+		}
+
+		config.Pagerduty = checkly.AlertChannelPagerduty{
+			ServiceKey:  secretValue,
+			Account:     ac.Spec.Pagerduty.Account,
+			ServiceName: ac.Spec.Pagerduty.ServiceName,
+		}
+	}
+
+	// /////////////////////////////
+	// Webhook logic + secret retrieval
+	// ////////////////////////////
+	if ac.Spec.Webhook != nil {
+		webhookSecret := ""
+		if ac.Spec.Webhook.WebhookSecret != nil {
+			webhookSecret, err = alertChannelSecretValue(ctx, r.Client, *ac.Spec.Webhook.WebhookSecret)
+			if err != nil {
+				logger.Error(err, "Unable to read secret for the webhook secret")
+				return ctrl.Result{}, err
+			}
+		}
+
+		config.Webhook = &checkly.AlertChannelWebhook{
+			Name:            ac.Name,
+			URL:             ac.Spec.Webhook.URL,
+			Method:          ac.Spec.Webhook.Method,
+			Headers:         queryParameters(ac.Spec.Webhook.Headers),
+			QueryParameters: queryParameters(ac.Spec.Webhook.QueryParameters),
+			Template:        ac.Spec.Webhook.Template,
+			WebhookSecret:   webhookSecret,
+			WebhookType:     ac.Spec.Webhook.WebhookType,
+		}
+	}
+
+	// /////////////////////////////
+	// Telegram logic + secret retrieval
+	// ////////////////////////////
+	if ac.Spec.Telegram != nil {
+		secretValue, err := alertChannelSecretValue(ctx, r.Client, ac.Spec.Telegram.BotTokenSecret)
+		if err != nil {
+			logger.Error(err, "Unable to read secret for the Telegram bot token")
+			return ctrl.Result{}, err
+		}
+
+		config.Webhook = external.TelegramWebhook(ac.Name, secretValue, ac.Spec.Telegram.ChatID, ac.Spec.Telegram.Message)
+	}
+
+	// /////////////////////////////
+	// Microsoft Teams logic + secret retrieval
+	// ////////////////////////////
+	if ac.Spec.MSTeams != nil {
+		secretValue, err := alertChannelSecretValue(ctx, r.Client, ac.Spec.MSTeams.WebhookSecret)
+		if err != nil {
+			logger.Error(err, "Unable to read secret for the Microsoft Teams webhook URL")
+			return ctrl.Result{}, err
+		}
+
+		config.Webhook = external.MSTeamsWebhook(ac.Name, secretValue, ac.Spec.MSTeams.Template)
+	}
+
+	// /////////////////////////////
+	// incident.io logic + secret retrieval
+	// ////////////////////////////
+	if ac.Spec.IncidentIO != nil {
+		secretValue, err := alertChannelSecretValue(ctx, r.Client, ac.Spec.IncidentIO.APIKeySecret)
+		if err != nil {
+			logger.Error(err, "Unable to read secret for the incident.io API key")
+			return ctrl.Result{}, err
+		}
+
+		config.Webhook = external.IncidentIOWebhook(ac.Name, ac.Spec.IncidentIO.URL, secretValue, ac.Spec.IncidentIO.Template)
+	}
+
+	// An invalid alert channel has no hash, its update fails below
+	payloadHash, _ := external.AlertChannelPayloadHash(ac, config)
+
 	// /////////////////////////////
 	// Update logic
 	// ////////////////////////////
@@ -158,26 +301,86 @@ func (r *AlertChannelReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	if ac.Status.ID != 0 {
 		// Existing object, we need to update it
 		logger.V(1).Info("Existing object, with ID", "checkly AlertChannel ID", ac.Status.ID)
-		err := external.UpdateAlertChannel(ac, opsGenieConfig, r.ApiClient)
+
+		// The spec didn't change since the last sync, differences are changes made outside of the operator
+		upToDate := false
+		if !external.IsDryRun(apiClient) && specApplied(ac.Generation, ac.Spec, ac.Status.ObservedGeneration, ac.Status.LastAppliedSpecHash) {
+			changes, driftErr := external.AlertChannelDrift(ac, config, apiClient)
+			if driftErr != nil {
+				logger.Error(driftErr, "Failed to read the checkly AlertChannel, skipping drift detection")
+			}
+			recordDrift(r.Recorder, ac, "alert channel", ac.Status.ID, changes)
+			upToDate = driftErr == nil && len(changes) == 0 && payloadApplied(payloadHash, ac.Status.LastAppliedPayloadHash)
+		}
+		if upToDate {
+			logger.V(1).Info("Checkly AlertChannel is up to date, skipping the update", "ID", ac.Status.ID)
+			return ctrl.Result{RequeueAfter: resyncAfter(r.resyncInterval())}, nil
+		}
+
+		err := external.UpdateAlertChannel(ac, config, apiClient)
 		if err != nil {
+			recordSyncFailed(r.Recorder, ac, "update", "alert channel", err)
 			logger.Error(err, "Failed to update checkly AlertChannel")
 			return ctrl.Result{}, err
 		}
+		recordUpdated(r.Recorder, apiClient, ac, "alert channel", ac.Status.ID)
 		logger.V(1).Info("Updated checkly AlertChannel", "ID", ac.Status.ID)
-		return ctrl.Result{}, nil
+
+		// Record the pushed payload, alert channels created before the URL was recorded get it on their next update
+		if ac.Status.LastAppliedPayloadHash != payloadHash || ac.Status.ChecklyURL != external.AlertChannelURL(ac.Status.ID) {
+			ac.Status.LastAppliedPayloadHash = payloadHash
+			ac.Status.ChecklyURL = external.AlertChannelURL(ac.Status.ID)
+			err = r.Status().Update(ctx, ac)
+			if err != nil {
+				logger.Error(err, "Failed to update AlertChannel status")
+				return ctrl.Result{}, err
+			}
+		}
+		return ctrl.Result{RequeueAfter: resyncAfter(r.resyncInterval())}, nil
+	}
+
+	// /////////////////////////////
+	// Adopt logic
+	// ////////////////////////////
+	if ac.Spec.Adopt != nil {
+		// Updating the existing alert channel takes over its configuration and fails if it doesn't exist
+		ac.Status.ID = ac.Spec.Adopt.ID
+		ac.Status.ChecklyURL = external.AlertChannelURL(ac.Status.ID)
+		err := external.UpdateAlertChannel(ac, config, apiClient)
+		if err != nil {
+			recordSyncFailed(r.Recorder, ac, "adopt", "alert channel", err)
+			// The conditions are stored on the way out, the alert channel isn't adopted yet
+			ac.Status.ID, ac.Status.ChecklyURL = 0, ""
+			logger.Error(err, "Failed to adopt checkly AlertChannel", "ID", ac.Spec.Adopt.ID)
+			return ctrl.Result{}, err
+		}
+		recordUpdated(r.Recorder, apiClient, ac, "alert channel", ac.Status.ID)
+
+		ac.Status.LastAppliedPayloadHash = payloadHash
+		err = r.Status().Update(ctx, ac)
+		if err != nil {
+			logger.Error(err, "Failed to update AlertChannel status", "ID", ac.Status.ID)
+			return ctrl.Result{}, err
+		}
+		logger.V(1).Info("Adopted checkly AlertChannel", "ID", ac.Status.ID)
+		return ctrl.Result{RequeueAfter: resyncAfter(r.resyncInterval())}, nil
 	}
 
 	// /////////////////////////////
 	// Create logic
 	// ////////////////////////////
-	acID, err := external.CreateAlertChannel(ac, opsGenieConfig, r.ApiClient)
+	acID, err := external.CreateAlertChannel(ac, config, apiClient)
 	if err != nil {
+		recordSyncFailed(r.Recorder, ac, "create", "alert channel", err)
 		logger.Error(err, "Failed to create checkly AlertChannel")
 		return ctrl.Result{}, err
 	}
+	recordCreated(r.Recorder, apiClient, ac, "alert channel", acID)
 
 	// Update the custom resource Status with the returned ID
 	ac.Status.ID = acID
+	ac.Status.ChecklyURL = external.AlertChannelURL(acID)
+	ac.Status.LastAppliedPayloadHash = payloadHash
 	err = r.Status().Update(ctx, ac)
 	if err != nil {
 		logger.Error(err, "Failed to update AlertChannel status", "ID", ac.Status.ID)
@@ -185,12 +388,53 @@ func (r *AlertChannelReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	}
 	logger.V(1).Info("New checkly AlertChannel created", "ID", ac.Status.ID)
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: resyncAfter(r.resyncInterval())}, nil
+}
+
+// resyncInterval returns how often the alert channel is pushed to checklyhq.com again
+func (r *AlertChannelReconciler) resyncInterval() time.Duration {
+	if r.ResyncInterval == 0 {
+		return alertChannelResyncPeriod
+	}
+
+	return r.ResyncInterval
+}
+
+// alertChannelDegradation returns why the alert channel doesn't alert as usual
+func alertChannelDegradation(ac *checklyv1alpha1.AlertChannel) degradation {
+	if !ac.Spec.SendFailure && !ac.Spec.SendRecovery && !ac.Spec.SendDegraded {
+		return degradation{reason: "NoAlerts", message: "The alert channel sends no failure, recovery or degraded alerts"}
+	}
+
+	return degradation{}
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *AlertChannelReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&checklyv1alpha1.AlertChannel{}).
-		Complete(r)
+		For(&checklyv1alpha1.AlertChannel{}, builder.WithPredicates(ResourceSelectorPredicate(r.ResourceSelector))).
+		Complete(rateLimitRequeue{r})
+}
+
+// alertChannelRetained returns true if the checklyhq.com alert channel is kept when the resource is deleted,
+// which is the case with the Retain deletion policy and for adopted alert channels unless the resource took ownership
+func alertChannelRetained(ac *checklyv1alpha1.AlertChannel) bool {
+	return ac.Spec.DeletionPolicy == checklyv1alpha1.DeletionPolicyRetain || (ac.Spec.Adopt != nil && !ac.Spec.Adopt.TakeOwnership)
+}
+
+// alertChannelSecretValue returns the value of the key referenced by fieldPath in the Secret, alert channels are
+// cluster scoped so the reference holds the namespace of the Secret
+func alertChannelSecretValue(ctx context.Context, c client.Client, ref corev1.ObjectReference) (string, error) {
+	secret := &corev1.Secret{}
+	err := c.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, secret)
+	if err != nil {
+		return "", err
+	}
+
+	value := string(secret.Data[ref.FieldPath])
+	if value == "" {
+		return "", fmt.Errorf("secret %s/%s has no value for %s", ref.Namespace, ref.Name, ref.FieldPath)
+	}
+
+	return value, nil
 }
@@ -194,6 +194,56 @@ var _ = Describe("ApiCheck Controller", func() {
 				return k8sClient.Delete(context.Background(), f)
 			}, timeout, interval).Should(Succeed())
 		})
+
+		It("Reads the values of the channel from a Secret", func() {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-slack-webhook",
+					Namespace: "default",
+				},
+				Data: map[string][]byte{
+					"url": []byte("https://hooks.slack.com/services/foo"),
+				},
+			}
+			ref := corev1.ObjectReference{
+				Namespace: secret.Namespace,
+				Name:      secret.Name,
+				FieldPath: "url",
+			}
+
+			By("Expecting an error for a missing Secret")
+			_, err := alertChannelSecretValue(context.Background(), k8sClient, ref)
+			Expect(err).To(HaveOccurred())
+
+			Expect(k8sClient.Create(context.Background(), secret)).Should(Succeed())
+
+			value, err := alertChannelSecretValue(context.Background(), k8sClient, ref)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal("https://hooks.slack.com/services/foo"))
+
+			By("Expecting an error for a missing key")
+			ref.FieldPath = "does-not-exist"
+			_, err = alertChannelSecretValue(context.Background(), k8sClient, ref)
+			Expect(err).To(HaveOccurred())
+
+			Expect(k8sClient.Delete(context.Background(), secret)).Should(Succeed())
+		})
+
+		It("Retains adopted alert channels", func() {
+			ac := &checklyv1alpha1.AlertChannel{}
+			Expect(alertChannelRetained(ac)).To(BeFalse())
+
+			ac.Spec.Adopt = &checklyv1alpha1.AlertChannelAdoption{ID: 42}
+			Expect(alertChannelRetained(ac)).To(BeTrue())
+
+			By("Expecting ownership to delete the alert channel")
+			ac.Spec.Adopt.TakeOwnership = true
+			Expect(alertChannelRetained(ac)).To(BeFalse())
+
+			By("Expecting the Retain deletion policy to keep the alert channel")
+			ac.Spec.DeletionPolicy = checklyv1alpha1.DeletionPolicyRetain
+			Expect(alertChannelRetained(ac)).To(BeTrue())
+		})
 		// return
 	})
 })
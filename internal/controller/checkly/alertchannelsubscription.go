@@ -0,0 +1,173 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/checkly/checkly-go-sdk"
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=alertchannelsubscriptions,verbs=get;list;watch
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=alertchannels,verbs=get;list
+
+// alertChannelSubscriptions resolves the alert channel names and the AlertChannelSubscription resources
+// into checkly subscriptions, a subscription resource overrides the spec entry of the same alert channel.
+// ready is false if the ID of an alert channel has not been populated yet.
+func alertChannelSubscriptions(ctx context.Context, c client.Client, alertChannels []string, subscriptions []checklyv1alpha1.AlertChannelSubscription) (subs []checkly.AlertChannelSubscription, ready bool, err error) {
+	activated := make(map[string]bool)
+	var names []string
+	for _, alertChannel := range alertChannels {
+		if _, ok := activated[alertChannel]; !ok {
+			names = append(names, alertChannel)
+		}
+		activated[alertChannel] = true
+	}
+	for _, subscription := range subscriptions {
+		if _, ok := activated[subscription.Spec.AlertChannel]; !ok {
+			names = append(names, subscription.Spec.AlertChannel)
+		}
+		activated[subscription.Spec.AlertChannel] = subscription.Spec.Activated
+	}
+
+	for _, name := range names {
+		ac := &checklyv1alpha1.AlertChannel{}
+		err = c.Get(ctx, types.NamespacedName{Name: name}, ac)
+		if err != nil {
+			return
+		}
+		if ac.Status.ID == 0 {
+			return nil, false, nil
+		}
+		subs = append(subs, checkly.AlertChannelSubscription{
+			ChannelID: ac.Status.ID,
+			Activated: activated[name],
+		})
+	}
+
+	return subs, true, nil
+}
+
+// groupSpecSubscriptions turns the alert channel subscriptions of the group spec into AlertChannelSubscription
+// resources, so they're resolved by alertChannelSubscriptions between the alert channel names and the resources
+func groupSpecSubscriptions(group *checklyv1alpha1.Group) (subscriptions []checklyv1alpha1.AlertChannelSubscription) {
+	for _, sub := range group.Spec.AlertChannelSubscriptions {
+		subscriptions = append(subscriptions, checklyv1alpha1.AlertChannelSubscription{
+			Spec: checklyv1alpha1.AlertChannelSubscriptionSpec{
+				AlertChannel: sub.AlertChannel,
+				Group:        group.Name,
+				Activated:    sub.Activated,
+			},
+		})
+	}
+
+	return
+}
+
+// unsubscribeRemoved deactivates the subscriptions of previously subscribed alert channels which are no longer
+// referenced, checkly keeps the subscriptions which are left out of an update
+func unsubscribeRemoved(subs []checkly.AlertChannelSubscription, previous []int64) []checkly.AlertChannelSubscription {
+	current := make(map[int64]bool)
+	for _, sub := range subs {
+		current[sub.ChannelID] = true
+	}
+
+	for _, ID := range previous {
+		if !current[ID] {
+			subs = append(subs, checkly.AlertChannelSubscription{
+				ChannelID: ID,
+				Activated: false,
+			})
+		}
+	}
+
+	return subs
+}
+
+// subscriptionIDs returns the alert channel IDs of the subscriptions
+func subscriptionIDs(subs []checkly.AlertChannelSubscription) (IDs []int64) {
+	for _, sub := range subs {
+		IDs = append(IDs, sub.ChannelID)
+	}
+
+	return
+}
+
+// groupSubscriptions returns the AlertChannelSubscription resources referencing the group
+func groupSubscriptions(ctx context.Context, c client.Client, group string) (subscriptions []checklyv1alpha1.AlertChannelSubscription, err error) {
+	list := &checklyv1alpha1.AlertChannelSubscriptionList{}
+	err = c.List(ctx, list)
+	if err != nil {
+		return
+	}
+
+	for _, item := range list.Items {
+		if item.Spec.Group == group {
+			subscriptions = append(subscriptions, item)
+		}
+	}
+
+	return
+}
+
+// checkSubscriptions returns the AlertChannelSubscription resources referencing the check of the given kind
+func checkSubscriptions(ctx context.Context, c client.Client, kind string, check types.NamespacedName) (subscriptions []checklyv1alpha1.AlertChannelSubscription, err error) {
+	list := &checklyv1alpha1.AlertChannelSubscriptionList{}
+	err = c.List(ctx, list, client.InNamespace(check.Namespace))
+	if err != nil {
+		return
+	}
+
+	for _, item := range list.Items {
+		if item.Spec.Group == "" && item.Spec.Check == check.Name && item.Spec.CheckKind == kind {
+			subscriptions = append(subscriptions, item)
+		}
+	}
+
+	return
+}
+
+// findGroupForSubscription returns a reconcile request for the group referenced by the AlertChannelSubscription
+func findGroupForSubscription(_ context.Context, obj client.Object) []reconcile.Request {
+	subscription := obj.(*checklyv1alpha1.AlertChannelSubscription)
+	if subscription.Spec.Group == "" {
+		return []reconcile.Request{}
+	}
+
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: subscription.Spec.Group}}}
+}
+
+// findCheckForSubscription returns a function which maps an AlertChannelSubscription to a reconcile
+// request for the referenced check of the given kind
+func findCheckForSubscription(kind string) func(context.Context, client.Object) []reconcile.Request {
+	return func(_ context.Context, obj client.Object) []reconcile.Request {
+		subscription := obj.(*checklyv1alpha1.AlertChannelSubscription)
+		if subscription.Spec.Group != "" || subscription.Spec.Check == "" || subscription.Spec.CheckKind != kind {
+			return []reconcile.Request{}
+		}
+
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{
+			Name:      subscription.Spec.Check,
+			Namespace: subscription.GetNamespace(),
+		}}}
+	}
+}
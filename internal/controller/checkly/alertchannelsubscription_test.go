@@ -0,0 +1,138 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/checkly/checkly-go-sdk"
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("AlertChannelSubscription", func() {
+
+	// Define utility constants for object names and testing timeouts/durations and intervals.
+	const (
+		timeout  = time.Second * 10
+		interval = time.Millisecond * 250
+	)
+
+	Context("alertChannelSubscriptions", func() {
+		It("Merges subscriptions", func() {
+
+			acKey := types.NamespacedName{
+				Name: "test-subscription-alert-channel",
+			}
+
+			alertChannel := &checklyv1alpha1.AlertChannel{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: acKey.Name,
+				},
+				Spec: checklyv1alpha1.AlertChannelSpec{
+					Email: checkly.AlertChannelEmail{
+						Address: "foo@bar.baz",
+					},
+				},
+			}
+
+			subscription := &checklyv1alpha1.AlertChannelSubscription{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-subscription",
+					Namespace: "default",
+				},
+				Spec: checklyv1alpha1.AlertChannelSubscriptionSpec{
+					AlertChannel: acKey.Name,
+					Group:        "test-subscription-group",
+					Activated:    false,
+				},
+			}
+
+			Expect(k8sClient.Create(context.Background(), alertChannel)).Should(Succeed())
+			Expect(k8sClient.Create(context.Background(), subscription)).Should(Succeed())
+
+			By("Expecting AlertChannel ID")
+			Eventually(func() bool {
+				f := &checklyv1alpha1.AlertChannel{}
+				err := k8sClient.Get(context.Background(), acKey, f)
+				return err == nil && f.Status.ID == 3
+			}, timeout, interval).Should(BeTrue())
+
+			By("Expecting group subscriptions")
+			subscriptions, err := groupSubscriptions(context.Background(), k8sClient, "test-subscription-group")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(subscriptions).To(HaveLen(1))
+
+			subscriptions, err = checkSubscriptions(context.Background(), k8sClient, "HeartbeatCheck", types.NamespacedName{Name: "test-subscription-group", Namespace: "default"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(subscriptions).To(BeEmpty())
+
+			By("Expecting the subscription to override the spec")
+			subs, ready, err := alertChannelSubscriptions(context.Background(), k8sClient, []string{acKey.Name}, []checklyv1alpha1.AlertChannelSubscription{*subscription})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ready).To(BeTrue())
+			Expect(subs).To(Equal([]checkly.AlertChannelSubscription{{ChannelID: 3, Activated: false}}))
+
+			By("Expecting a deactivated subscription of the group spec")
+			group := &checklyv1alpha1.Group{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-subscription-group",
+				},
+				Spec: checklyv1alpha1.GroupSpec{
+					AlertChannels: []string{acKey.Name},
+					AlertChannelSubscriptions: []checklyv1alpha1.GroupAlertChannelSubscription{
+						{AlertChannel: acKey.Name, Activated: false},
+					},
+				},
+			}
+			subs, ready, err = alertChannelSubscriptions(context.Background(), k8sClient, group.Spec.AlertChannels, groupSpecSubscriptions(group))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ready).To(BeTrue())
+			Expect(subs).To(Equal([]checkly.AlertChannelSubscription{{ChannelID: 3, Activated: false}}))
+
+			By("Expecting an error for a missing alert channel")
+			_, _, err = alertChannelSubscriptions(context.Background(), k8sClient, []string{"does-not-exist"}, nil)
+			Expect(err).To(HaveOccurred())
+
+			Expect(k8sClient.Delete(context.Background(), subscription)).Should(Succeed())
+			Expect(k8sClient.Delete(context.Background(), alertChannel)).Should(Succeed())
+		})
+	})
+
+	Context("unsubscribeRemoved", func() {
+		It("Deactivates removed alert channels", func() {
+			subs := []checkly.AlertChannelSubscription{{ChannelID: 3, Activated: true}}
+
+			By("Expecting the subscribed alert channel IDs")
+			Expect(subscriptionIDs(subs)).To(Equal([]int64{3}))
+
+			By("Expecting the removed alert channel to be deactivated")
+			Expect(unsubscribeRemoved(subs, []int64{3, 4})).To(Equal([]checkly.AlertChannelSubscription{
+				{ChannelID: 3, Activated: true},
+				{ChannelID: 4, Activated: false},
+			}))
+
+			By("Expecting nothing to change without previous subscriptions")
+			Expect(unsubscribeRemoved(subs, nil)).To(Equal(subs))
+		})
+	})
+})
@@ -19,30 +19,52 @@ package checkly
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/checkly/checkly-go-sdk"
 	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
 	external "github.com/checkly/checkly-operator/external/checkly"
 )
 
+// apiCheckGroupField is the field index used to find the ApiChecks of a Group
+const apiCheckGroupField = ".spec.group"
+
 // ApiCheckReconciler reconciles a ApiCheck object
 type ApiCheckReconciler struct {
 	client.Client
 	Scheme           *runtime.Scheme
 	ApiClient        checkly.Client
 	ControllerDomain string
+	ResourceSelector labels.Selector
+	Recorder         record.EventRecorder
+	ResyncInterval   time.Duration
 }
 
 //+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=apichecks,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=apichecks/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=snippets,verbs=get;list
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=privatelocations,verbs=get;list
 //+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=apichecks/finalizers,verbs=update
 //+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=groups,verbs=get;list
 
@@ -57,8 +79,6 @@ type ApiCheckReconciler struct {
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.0/pkg/reconcile
 func (r *ApiCheckReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
-
-	apiCheckFinalizer := fmt.Sprintf("%s/finalizer", r.ControllerDomain)
 	logger.V(1).Info("Reconciler started")
 
 	apiCheck := &checklyv1alpha1.ApiCheck{}
@@ -71,6 +91,7 @@ func (r *ApiCheckReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		if errors.IsNotFound(err) {
 			// The resource has been deleted
 			logger.V(1).Info("Deleted", "checkly ID", apiCheck.Status.ID, "endpoint", apiCheck.Spec.Endpoint, "name", apiCheck.Name)
+			forgetFailures(apiCheck, req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		// Error reading the object
@@ -78,54 +99,120 @@ func (r *ApiCheckReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return ctrl.Result{}, nil
 	}
 
+	return reconcileApiCheck(ctx, r.Client, r.ApiClient, r.Recorder, r.ControllerDomain, r.ResyncInterval, apiCheck, &apiCheck.Spec, &apiCheck.Status)
+}
+
+// reconcileApiCheck holds the reconciliation logic shared by the namespaced ApiCheck and the cluster scoped ClusterApiCheck
+func reconcileApiCheck(ctx context.Context, c client.Client, defaultClient checkly.Client, recorder record.EventRecorder, controllerDomain string, resyncInterval time.Duration, apiCheck client.Object, spec *checklyv1alpha1.ApiCheckSpec, status *checklyv1alpha1.ApiCheckStatus) (result ctrl.Result, err error) {
+	logger := log.FromContext(ctx)
+
+	apiCheckFinalizer := fmt.Sprintf("%s/finalizer", controllerDomain)
+
+	// /////////////////////////////
+	// Account lookup
+	// ////////////////////////////
+	// A failed lookup fails the delete of a deleted resource like an API error would, so the force-delete
+	// annotation still removes its finalizer once the account or its Secret is gone
+	apiClient, credentials, accountErr := apiClientForCheck(ctx, c, defaultClient, spec.Account, apiCheck, status.ID, status.Credentials)
+	if accountErr != nil {
+		logger.Error(accountErr, "Unable to read credentials of the account", "account", spec.Account)
+		if apiCheck.GetDeletionTimestamp() == nil {
+			updateConditions(ctx, c, apiCheck, &status.Conditions, status.ID != "", accountErr, checkDegradation(spec.Paused, spec.Muted))
+			return ctrl.Result{}, accountErr
+		}
+	}
+	credentialsChanged := accountErr == nil && status.Credentials != credentials
+	if credentialsChanged {
+		status.Credentials = credentials
+	}
+
 	if apiCheck.GetDeletionTimestamp() != nil {
 		if controllerutil.ContainsFinalizer(apiCheck, apiCheckFinalizer) {
-			logger.V(1).Info("Finalizer is present, trying to delete Checkly check", "checkly ID", apiCheck.Status.ID)
-			err := external.Delete(apiCheck.Status.ID, r.ApiClient)
-			if err != nil {
-				logger.Error(err, "Failed to delete checkly API check")
-				return ctrl.Result{}, err
+			if checkRetained(spec.DeletionPolicy, spec.Adopt) {
+				logger.Info("Checkly API check is retained, leaving it in place", "checkly ID", status.ID)
+			} else {
+				logger.V(1).Info("Finalizer is present, trying to delete Checkly check", "checkly ID", status.ID)
+				err := accountErr
+				if err == nil {
+					err = external.Delete(status.ID, apiClient)
+				}
+				if err != nil {
+					recordSyncFailed(recorder, apiCheck, "delete", "check", err)
+					logger.Error(err, "Failed to delete checkly API check")
+					forced, retry, err := forceDelete(ctx, recorder, controllerDomain, apiCheck, "check", status.ID, err)
+					if !forced {
+						return retry, err
+					}
+				} else {
+					logger.Info("Successfully deleted checkly API check", "checkly ID", status.ID)
+				}
 			}
 
-			logger.Info("Successfully deleted checkly API check", "checkly ID", apiCheck.Status.ID)
-
 			controllerutil.RemoveFinalizer(apiCheck, apiCheckFinalizer)
-			err = r.Update(ctx, apiCheck)
+			err = c.Update(ctx, apiCheck)
 			if err != nil {
 				logger.Error(err, "Failed to delete finalizer")
 				return ctrl.Result{}, err
 			}
 			logger.V(1).Info("Successfully deleted finalizer")
+			forgetFailures(apiCheck, client.ObjectKeyFromObject(apiCheck))
 		}
 		return ctrl.Result{}, nil
 	}
 
 	// Object found, let's do something with it. It's either updated, or it's new.
-	logger.V(1).Info("Object found", "endpoint", apiCheck.Spec.Endpoint)
+	logger.V(1).Info("Object found", "endpoint", spec.Endpoint)
 
 	// /////////////////////////////
 	// Finalizer logic
 	// ////////////////////////////
 	if !controllerutil.ContainsFinalizer(apiCheck, apiCheckFinalizer) {
 		controllerutil.AddFinalizer(apiCheck, apiCheckFinalizer)
-		err = r.Update(ctx, apiCheck)
+		err = c.Update(ctx, apiCheck)
 		if err != nil {
 			logger.Error(err, "Failed to update ApiCheck status")
 			return ctrl.Result{}, err
 		}
-		logger.V(1).Info("Added finalizer", "checkly ID", apiCheck.Status.ID, "endpoint", apiCheck.Spec.Endpoint)
+		logger.V(1).Info("Added finalizer", "checkly ID", status.ID, "endpoint", spec.Endpoint)
 		return ctrl.Result{}, nil
 	}
 
+	// /////////////////////////////
+	// Conditions
+	// ////////////////////////////
+	defer func() {
+		changed := setConditions(&status.Conditions, apiCheck.GetGeneration(), status.ID != "", result, err, checkDegradation(spec.Paused, spec.Muted))
+		if setApplied(status.Conditions, apiCheck.GetGeneration(), spec, &status.ObservedGeneration, &status.LastAppliedSpecHash) {
+			changed = true
+		}
+		if setStalled(&status.Conditions, apiCheck, "check", err) {
+			changed = true
+		}
+		if credentialsChanged {
+			changed = true
+		}
+		if changed {
+			// Only set when the conditions change, every status update triggers another reconcile
+			if status.ID != "" && meta.IsStatusConditionTrue(status.Conditions, checklyv1alpha1.ConditionSynced) {
+				now := metav1.Now()
+				status.LastSyncTime = &now
+			}
+			statusErr := c.Status().Update(ctx, apiCheck)
+			if statusErr != nil {
+				logger.Error(statusErr, "Failed to update ApiCheck conditions")
+			}
+		}
+	}()
+
 	// /////////////////////////////
 	// Lookup group ID
 	// ////////////////////////////
 	group := &checklyv1alpha1.Group{}
-	err = r.Get(ctx, types.NamespacedName{Name: apiCheck.Spec.Group}, group)
+	err = c.Get(ctx, types.NamespacedName{Name: spec.Group}, group)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			// The resource has been deleted
-			logger.Error(err, "Group not found, probably deleted or does not exist", "name", apiCheck.Spec.Group)
+			logger.Error(err, "Group not found, probably deleted or does not exist", "name", spec.Group)
 			return ctrl.Result{}, err
 		}
 		// Error reading the object
@@ -133,70 +220,433 @@ func (r *ApiCheckReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return ctrl.Result{}, err
 	}
 
+	if !groupAllowsNamespace(group, apiCheck.GetNamespace()) {
+		groupErr := fmt.Errorf("group %s doesn't allow checks from namespace %s", spec.Group, apiCheck.GetNamespace())
+		logger.Error(groupErr, "Please add the namespace to the allowedNamespaces of the group")
+		return ctrl.Result{}, groupErr
+	}
+
 	if group.Status.ID == 0 {
-		logger.V(1).Info("Group ID has not been populated, we're too quick, requeining for retry", "group name", apiCheck.Spec.Group)
+		logger.V(1).Info("Group ID has not been populated, we're too quick, requeining for retry", "group name", spec.Group)
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	// /////////////////////////////
+	// Secret lookup
+	// ////////////////////////////
+	headers, err := apiCheckHeaders(ctx, c, apiCheck.GetNamespace(), spec)
+	if err != nil {
+		logger.Error(err, "Unable to read the header values")
+		return ctrl.Result{}, err
+	}
+	basicAuth, err := apiCheckBasicAuth(ctx, c, apiCheck.GetNamespace(), spec)
+	if err != nil {
+		logger.Error(err, "Unable to read the basic authentication credentials")
+		return ctrl.Result{}, err
+	}
+
+	// /////////////////////////////
+	// Script lookup
+	// ////////////////////////////
+	setupScript, setupSnippetID, setupReady, err := checkScript(ctx, c, apiCheck.GetNamespace(), spec.SetupScript)
+	if err != nil {
+		logger.Error(err, "Unable to read the setup script")
+		return ctrl.Result{}, err
+	}
+	teardownScript, teardownSnippetID, teardownReady, err := checkScript(ctx, c, apiCheck.GetNamespace(), spec.TeardownScript)
+	if err != nil {
+		logger.Error(err, "Unable to read the teardown script")
+		return ctrl.Result{}, err
+	}
+	if !setupReady || !teardownReady {
+		logger.V(1).Info("Snippet ID has not been populated, we're too quick, requeining for retry")
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	// /////////////////////////////
+	// Private location lookup
+	// ////////////////////////////
+	privateLocations, privateLocationsReady, err := privateLocationSlugs(ctx, c, spec.PrivateLocations)
+	if err != nil {
+		logger.Error(err, "Unable to read the private locations", "names", spec.PrivateLocations)
+		return ctrl.Result{}, err
+	}
+	if !privateLocationsReady {
+		logger.V(1).Info("Private location ID has not been populated, we're too quick, requeining for retry")
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	// The group holds the defaults of the response times
+	maxResponseTime := spec.MaxResponseTime
+	if maxResponseTime == 0 {
+		maxResponseTime = group.Spec.MaxResponseTime
+	}
+	degradedResponseTime := spec.DegradedResponseTime
+	if degradedResponseTime == 0 {
+		degradedResponseTime = group.Spec.DegradedResponseTime
+	}
+
+	// The group holds the default of the retry strategy
+	checkRetryStrategy := spec.RetryStrategy
+	if checkRetryStrategy == nil {
+		checkRetryStrategy = group.Spec.RetryStrategy
+	}
+
+	// /////////////////////////////
+	// AlertChannelsSubscription logic
+	// ////////////////////////////
+	var subscriptions []checklyv1alpha1.AlertChannelSubscription
+	// AlertChannelSubscription resources are namespaced, they can't reference cluster scoped checks
+	if apiCheck.GetNamespace() != "" {
+		subscriptions, err = checkSubscriptions(ctx, c, "ApiCheck", client.ObjectKeyFromObject(apiCheck))
+		if err != nil {
+			logger.Error(err, "Could not list AlertChannelSubscription resources")
+			return ctrl.Result{}, err
+		}
+	}
+
+	err = sameAccount(ctx, c, spec.Account, apiCheck.GetNamespace(), accountReferences{
+		group:            spec.Group,
+		alertChannels:    spec.AlertChannels,
+		subscriptions:    subscriptions,
+		snippets:         scriptSnippets(spec.SetupScript, spec.TeardownScript),
+		privateLocations: spec.PrivateLocations,
+	})
+	if err != nil {
+		logger.Error(err, "Please use the same checklyhq.com account for the check and the resources it references")
+		return ctrl.Result{}, err
+	}
+
+	alertChannels, ready, err := alertChannelSubscriptions(ctx, c, spec.AlertChannels, subscriptions)
+	if err != nil {
+		logger.Error(err, "Could not find alertChannel resource")
+		return ctrl.Result{}, err
+	}
+	if !ready {
+		logger.Info("AlertChannel ID not yet populated, we'll retry")
 		return ctrl.Result{Requeue: true}, nil
 	}
+	alertChannelIDs := subscriptionIDs(alertChannels)
+
+	// /////////////////////////////
+	// Runtime lookup
+	// ////////////////////////////
+	// The group holds the default of the runtime
+	runtimeID := spec.RuntimeID
+	if runtimeID == "" {
+		runtimeID = group.Spec.RuntimeID
+	}
+	if runtimeID != "" {
+		_, err := external.GetRuntime(runtimeID, apiClient)
+		if err != nil {
+			logger.Error(err, "Runtime is not supported by the account", "runtime", runtimeID)
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Without a frequency the check runs every 5 minutes, a frequency of 0 runs it every frequencyOffset seconds
+	frequency, frequencyOffset, frequencySeconds := 5, spec.FrequencyOffset, 0
+	if spec.Frequency != nil {
+		frequency = *spec.Frequency
+	}
+	if frequency == 0 {
+		frequencyOffset, frequencySeconds = 0, spec.FrequencyOffset
+	}
 
 	// Create internal Check type
 	internalCheck := external.Check{
-		Name:            apiCheck.Name,
-		Namespace:       apiCheck.Namespace,
-		Frequency:       apiCheck.Spec.Frequency,
-		MaxResponseTime: apiCheck.Spec.MaxResponseTime,
-		Endpoint:        apiCheck.Spec.Endpoint,
-		SuccessCode:     apiCheck.Spec.Success,
-		ID:              apiCheck.Status.ID,
+		Name:            apiCheck.GetName(),
+		Namespace:       apiCheck.GetNamespace(),
+		Frequency:       frequency,
+		MaxResponseTime: maxResponseTime,
+		Endpoint:        spec.Endpoint,
+		SuccessCode:     spec.Success,
+		Method:          spec.Method,
+		Body:            spec.Body,
+		BodyType:        spec.BodyType,
+		SkipSSL:         spec.SkipSSL,
+		FollowRedirects: spec.FollowRedirects,
+		IPFamily:        spec.IPFamily,
+		ID:              status.ID,
 		GroupID:         group.Status.ID,
-		Muted:           apiCheck.Spec.Muted,
-		Labels:          apiCheck.Labels,
+		Muted:           spec.Muted,
+		Paused:          spec.Paused,
+		ShouldFail:      spec.ShouldFail,
+		Labels:          apiCheck.GetLabels(),
+		Tags:            append(groupTags(group), spec.Tags...),
+		RuntimeID:       runtimeID,
+		AlertChannels:   unsubscribeRemoved(alertChannels, status.AlertChannelIDs),
+		Locations:       spec.Locations,
+
+		PrivateLocations:     privateLocations,
+		FrequencyOffset:      frequencyOffset,
+		FrequencySeconds:     frequencySeconds,
+		DegradedResponseTime: degradedResponseTime,
+		SSLCertificateExpiry: spec.SSLCertificateExpiry,
+		Headers:              headers,
+		QueryParameters:      queryParameters(spec.QueryParameters),
+		BasicAuth:            basicAuth,
+		SetupScript:          setupScript,
+		SetupSnippetID:       setupSnippetID,
+		TeardownScript:       teardownScript,
+		TeardownSnippetID:    teardownSnippetID,
+		RetryStrategy:        retryStrategy(checkRetryStrategy),
+		Assertions:           assertions(spec.Assertions),
+		Retained:             checkRetained(spec.DeletionPolicy, spec.Adopt),
 	}
 
+	// An invalid check has no hash, its update fails below
+	payloadHash, _ := external.CheckPayloadHash(internalCheck)
+
 	// /////////////////////////////
 	// Update logic
 	// ////////////////////////////
 
 	// Determine if it's a new object or if it's an update to an existing object
-	if apiCheck.Status.ID != "" {
+	var state external.State
+	if status.ID != "" {
 		// Existing object, we need to update it
-		logger.V(1).Info("Existing object, with ID", "checkly ID", apiCheck.Status.ID, "endpoint", apiCheck.Spec.Endpoint)
-		err := external.Update(internalCheck, r.ApiClient)
-		// err :=
-		if err != nil {
+		logger.V(1).Info("Existing object, with ID", "checkly ID", status.ID, "endpoint", spec.Endpoint)
+
+		// The spec didn't change since the last sync, differences are changes made outside of the operator
+		upToDate := false
+		if !external.IsDryRun(apiClient) && specApplied(apiCheck.GetGeneration(), spec, status.ObservedGeneration, status.LastAppliedSpecHash) {
+			changes, driftErr := external.CheckDrift(internalCheck, apiClient)
+			if driftErr != nil && !external.IsNotFound(driftErr) {
+				logger.Error(driftErr, "Failed to read the checkly check, skipping drift detection")
+			}
+			recordDrift(recorder, apiCheck, "check", status.ID, changes)
+			upToDate = driftErr == nil && len(changes) == 0 && payloadApplied(payloadHash, status.LastAppliedPayloadHash)
+		}
+		if upToDate {
+			logger.V(1).Info("Checkly check is up to date, skipping the update", "checkly ID", status.ID)
+			return ctrl.Result{RequeueAfter: resyncAfter(resyncInterval)}, nil
+		}
+
+		state, err = external.Update(internalCheck, apiClient)
+		if external.IsNotFound(err) {
+			// The check was deleted outside of the operator, it's created again with a new ID
+			recordNotFound(recorder, apiCheck, "check", status.ID)
+			logger.Info("Checkly check not found, recreating it", "checkly ID", status.ID)
+			status.ID, internalCheck.ID = "", ""
+		} else if err != nil {
+			recordSyncFailed(recorder, apiCheck, "update", "check", err)
 			logger.Error(err, "Failed to update the checkly check")
 			return ctrl.Result{}, err
 		}
-		logger.Info("Updated checkly check", "checkly ID", apiCheck.Status.ID)
-		return ctrl.Result{}, nil
+	}
+
+	if status.ID != "" {
+		recordUpdated(recorder, apiClient, apiCheck, "check", status.ID)
+		logger.Info("Updated checkly check", "checkly ID", status.ID)
+
+		stateChanged := setChecklyState(&status.Checkly, apiClient, state)
+
+		// Keep track of the subscribed alert channels, removed ones are unsubscribed on the next update
+		if stateChanged || status.LastAppliedPayloadHash != payloadHash || !slices.Equal(status.AlertChannelIDs, alertChannelIDs) || status.GroupID != group.Status.ID || status.ChecklyURL != external.CheckURL(status.ID) {
+			status.LastAppliedPayloadHash = payloadHash
+			status.AlertChannelIDs = alertChannelIDs
+			status.GroupID = group.Status.ID
+			status.ChecklyURL = external.CheckURL(status.ID)
+			err = c.Status().Update(ctx, apiCheck)
+			if err != nil {
+				logger.Error(err, "Failed to update ApiCheck status")
+				return ctrl.Result{}, err
+			}
+		}
+		return ctrl.Result{RequeueAfter: resyncAfter(resyncInterval)}, nil
+	}
+
+	// /////////////////////////////
+	// Adopt logic
+	// ////////////////////////////
+	if spec.Adopt != nil {
+		// Updating the existing check takes over its configuration and fails if it doesn't exist
+		internalCheck.ID = spec.Adopt.ID
+		state, err := external.Update(internalCheck, apiClient)
+		if err != nil {
+			recordSyncFailed(recorder, apiCheck, "adopt", "check", err)
+			logger.Error(err, "Failed to adopt checkly check", "checkly ID", spec.Adopt.ID)
+			return ctrl.Result{}, err
+		}
+		recordUpdated(recorder, apiClient, apiCheck, "check", spec.Adopt.ID)
+
+		status.ID = spec.Adopt.ID
+		status.ChecklyURL = external.CheckURL(status.ID)
+		status.GroupID = group.Status.ID
+		status.AlertChannelIDs = alertChannelIDs
+		status.LastAppliedPayloadHash = payloadHash
+		setChecklyState(&status.Checkly, apiClient, state)
+		err = c.Status().Update(ctx, apiCheck)
+		if err != nil {
+			logger.Error(err, "Failed to update ApiCheck status")
+			return ctrl.Result{}, err
+		}
+		logger.V(1).Info("Adopted checkly check", "checkly ID", status.ID)
+		return ctrl.Result{RequeueAfter: resyncAfter(resyncInterval)}, nil
 	}
 
 	// /////////////////////////////
 	// Create logic
 	// ////////////////////////////
 
-	checklyID, err := external.Create(internalCheck, r.ApiClient)
+	checklyID, err := external.Create(internalCheck, apiClient)
 	if err != nil {
+		recordSyncFailed(recorder, apiCheck, "create", "check", err)
 		logger.Error(err, "Failed to create checkly alert")
 		return ctrl.Result{}, err
 	}
+	recordCreated(recorder, apiClient, apiCheck, "check", checklyID)
 
 	// Update the custom resource Status with the returned ID
 
-	apiCheck.Status.ID = checklyID
-	apiCheck.Status.GroupID = group.Status.ID
-	err = r.Status().Update(ctx, apiCheck)
+	status.ID = checklyID
+	status.ChecklyURL = external.CheckURL(checklyID)
+	status.GroupID = group.Status.ID
+	status.AlertChannelIDs = alertChannelIDs
+	status.LastAppliedPayloadHash = payloadHash
+	err = c.Status().Update(ctx, apiCheck)
 	if err != nil {
 		logger.Error(err, "Failed to update ApiCheck status")
 		return ctrl.Result{}, err
 	}
-	logger.V(1).Info("New checkly check created with", "checkly ID", apiCheck.Status.ID, "spec", apiCheck.Spec)
+	logger.V(1).Info("New checkly check created with", "checkly ID", status.ID, "spec", spec)
+
+	return ctrl.Result{RequeueAfter: resyncAfter(resyncInterval)}, nil
+}
+
+// checkRetained returns true if the checklyhq.com check is kept when the resource is deleted, which is the case
+// with the Retain deletion policy and for adopted checks unless the resource took ownership
+func checkRetained(deletionPolicy string, adopt *checklyv1alpha1.CheckAdoption) bool {
+	return deletionPolicy == checklyv1alpha1.DeletionPolicyRetain || (adopt != nil && !adopt.TakeOwnership)
+}
+
+// checkDegradation returns why a check doesn't run or alert as usual
+func checkDegradation(paused, muted bool) degradation {
+	if paused {
+		return degradation{reason: "Paused", message: "The check is paused, it doesn't run"}
+	}
+	if muted {
+		return degradation{reason: "Muted", message: "The check is muted, it doesn't send alerts"}
+	}
 
-	return ctrl.Result{}, nil
+	return degradation{}
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ApiCheckReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Index ApiChecks by the Secrets and ConfigMaps they read values from so
+	// changes to them trigger a reconciliation of the check
+	err := mgr.GetFieldIndexer().IndexField(context.Background(), &checklyv1alpha1.ApiCheck{}, apiCheckSecretField, func(rawObj client.Object) []string {
+		apiCheck := rawObj.(*checklyv1alpha1.ApiCheck)
+		return apiCheckSecretNames(&apiCheck.Spec)
+	})
+	if err != nil {
+		return err
+	}
+	// Index ApiChecks by their Group so the defaults of the Group are applied when it changes
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &checklyv1alpha1.ApiCheck{}, apiCheckGroupField, func(rawObj client.Object) []string {
+		apiCheck := rawObj.(*checklyv1alpha1.ApiCheck)
+		return []string{apiCheck.Spec.Group}
+	})
+	if err != nil {
+		return err
+	}
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &checklyv1alpha1.ApiCheck{}, apiCheckConfigMapField, func(rawObj client.Object) []string {
+		apiCheck := rawObj.(*checklyv1alpha1.ApiCheck)
+		return apiCheckConfigMapNames(&apiCheck.Spec)
+	})
+	if err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&checklyv1alpha1.ApiCheck{}).
-		Complete(r)
+		For(&checklyv1alpha1.ApiCheck{}, builder.WithPredicates(ResourceSelectorPredicate(r.ResourceSelector))).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.findApiChecksForSecret),
+		).
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.findApiChecksForConfigMap),
+		).
+		Watches(
+			&checklyv1alpha1.Group{},
+			handler.EnqueueRequestsFromMapFunc(r.findApiChecksForGroup),
+		).
+		Watches(
+			&checklyv1alpha1.AlertChannelSubscription{},
+			handler.EnqueueRequestsFromMapFunc(findCheckForSubscription("ApiCheck")),
+		).
+		Complete(rateLimitRequeue{r})
+}
+
+// findApiChecksForGroup returns a reconcile request for every ApiCheck in the Group
+func (r *ApiCheckReconciler) findApiChecksForGroup(ctx context.Context, group client.Object) []reconcile.Request {
+	apiChecks := &checklyv1alpha1.ApiCheckList{}
+	listOps := &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(apiCheckGroupField, group.GetName()),
+	}
+	err := r.List(ctx, apiChecks, listOps)
+	if err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, len(apiChecks.Items))
+	for i, item := range apiChecks.Items {
+		requests[i] = reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      item.GetName(),
+				Namespace: item.GetNamespace(),
+			},
+		}
+	}
+	return requests
+}
+
+// retryStrategy turns the retry strategy of the spec into the Checkly retry strategy, nil if not set
+func retryStrategy(strategy *checklyv1alpha1.RetryStrategy) *checkly.RetryStrategy {
+	if strategy == nil {
+		return nil
+	}
+
+	return &checkly.RetryStrategy{
+		Type:               strategy.Type,
+		BaseBackoffSeconds: strategy.BaseBackoffSeconds,
+		MaxRetries:         strategy.MaxRetries,
+		MaxDurationSeconds: strategy.MaxDurationSeconds,
+		SameRegion:         strategy.SameRegion,
+	}
+}
+
+// assertions turns the assertions of the spec into Checkly assertions
+func assertions(specAssertions []checklyv1alpha1.Assertion) []checkly.Assertion {
+	var checklyAssertions []checkly.Assertion
+	for _, assertion := range specAssertions {
+		checklyAssertions = append(checklyAssertions, checkly.Assertion{
+			Source:     assertion.Source,
+			Property:   assertion.Property,
+			Comparison: assertion.Comparison,
+			Target:     assertion.Target,
+		})
+	}
+
+	return checklyAssertions
+}
+
+// queryParameters turns a key value map, ex. query parameters or headers, into a list sorted by key,
+// so the order doesn't change between reconciliations
+func queryParameters(parameters map[string]string) []checkly.KeyValue {
+	keys := make([]string, 0, len(parameters))
+	for key := range parameters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var keyValues []checkly.KeyValue
+	for _, key := range keys {
+		keyValues = append(keyValues, checkly.KeyValue{Key: key, Value: parameters[key]})
+	}
+
+	return keyValues
 }
@@ -25,6 +25,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/checkly/checkly-go-sdk"
 	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -144,5 +145,93 @@ var _ = Describe("ApiCheck Controller", func() {
 				return k8sClient.Get(context.Background(), key, f)
 			}, timeout, interval).ShouldNot(Succeed())
 		})
+
+		It("Retain deletion policy", func() {
+
+			key := types.NamespacedName{
+				Name:      "test-apicheck-retain",
+				Namespace: "default",
+			}
+
+			groupKey := types.NamespacedName{
+				Name: "test-apicheck-retain-group",
+			}
+
+			group := &checklyv1alpha1.Group{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: groupKey.Name,
+				},
+			}
+
+			apiCheck := &checklyv1alpha1.ApiCheck{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: checklyv1alpha1.ApiCheckSpec{
+					Endpoint:       "http://bar.baz/quoz",
+					Success:        "200",
+					Group:          groupKey.Name,
+					DeletionPolicy: checklyv1alpha1.DeletionPolicyRetain,
+				},
+			}
+
+			// Create
+			Expect(k8sClient.Create(context.Background(), group)).Should(Succeed())
+			Expect(k8sClient.Create(context.Background(), apiCheck)).Should(Succeed())
+
+			By("Expecting finalizer")
+			Eventually(func() bool {
+				f := &checklyv1alpha1.ApiCheck{}
+				err := k8sClient.Get(context.Background(), key, f)
+				if err != nil {
+					return false
+				}
+
+				return len(f.Finalizers) == 1
+			}, timeout, interval).Should(BeTrue())
+
+			// Delete
+			Expect(k8sClient.Delete(context.Background(), group)).Should(Succeed())
+
+			By("Expecting to delete successfully")
+			Eventually(func() error {
+				f := &checklyv1alpha1.ApiCheck{}
+				k8sClient.Get(context.Background(), key, f)
+				return k8sClient.Delete(context.Background(), f)
+			}, timeout, interval).Should(Succeed())
+
+			By("Expecting the finalizer to be removed without deleting the checkly check")
+			Eventually(func() error {
+				f := &checklyv1alpha1.ApiCheck{}
+				return k8sClient.Get(context.Background(), key, f)
+			}, timeout, interval).ShouldNot(Succeed())
+		})
+	})
+
+	Context("checkRetained", func() {
+		It("Retains adopted checks and checks with the Retain policy", func() {
+			Expect(checkRetained("", nil)).To(BeFalse())
+			Expect(checkRetained(checklyv1alpha1.DeletionPolicyDelete, nil)).To(BeFalse())
+			Expect(checkRetained(checklyv1alpha1.DeletionPolicyRetain, nil)).To(BeTrue())
+
+			adopt := &checklyv1alpha1.CheckAdoption{ID: "73d29ea2-6540-4bb5-967e-e07fa2c9465e"}
+			Expect(checkRetained("", adopt)).To(BeTrue())
+
+			By("Expecting ownership to delete the check")
+			adopt.TakeOwnership = true
+			Expect(checkRetained("", adopt)).To(BeFalse())
+			Expect(checkRetained(checklyv1alpha1.DeletionPolicyRetain, adopt)).To(BeTrue())
+		})
+	})
+
+	Context("queryParameters", func() {
+		It("Sorts the parameters by key", func() {
+			Expect(queryParameters(nil)).To(BeEmpty())
+			Expect(queryParameters(map[string]string{"b": "2", "a": "1"})).To(Equal([]checkly.KeyValue{
+				{Key: "a", Value: "1"},
+				{Key: "b", Value: "2"},
+			}))
+		})
 	})
 })
@@ -0,0 +1,128 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+// apiCheckConfigMapField is the field index used to find ApiChecks referencing a ConfigMap
+const apiCheckConfigMapField = ".spec.configmaps"
+
+// apiCheckConfigMapNames returns the names of the ConfigMaps the ApiCheck reads scripts from
+func apiCheckConfigMapNames(spec *checklyv1alpha1.ApiCheckSpec) []string {
+	var names []string
+	for _, script := range []*checklyv1alpha1.Script{spec.SetupScript, spec.TeardownScript} {
+		if script != nil && script.ConfigMap != nil {
+			names = append(names, script.ConfigMap.Name)
+		}
+	}
+
+	return names
+}
+
+// scriptSnippets returns the names of the Snippets the scripts reference
+func scriptSnippets(scripts ...*checklyv1alpha1.Script) []string {
+	var names []string
+	for _, script := range scripts {
+		if script != nil && script.Snippet != "" {
+			names = append(names, script.Snippet)
+		}
+	}
+
+	return names
+}
+
+// checkScript resolves a setup or teardown script, inline and ConfigMap scripts are returned as
+// script, Snippets as snippetID. ready is false if the Snippet hasn't been created in checklyhq.com yet.
+func checkScript(ctx context.Context, c client.Client, namespace string, script *checklyv1alpha1.Script) (inline string, snippetID int64, ready bool, err error) {
+	ready = true
+
+	if script == nil {
+		return
+	}
+
+	switch {
+	case script.ConfigMap != nil:
+		inline, err = configMapKeyValue(ctx, c, namespace, script.ConfigMap)
+	case script.Snippet != "":
+		snippet := &checklyv1alpha1.Snippet{}
+		err = c.Get(ctx, types.NamespacedName{Name: script.Snippet}, snippet)
+		if err != nil {
+			return
+		}
+		snippetID = snippet.Status.ID
+		ready = snippetID != 0
+	default:
+		inline = script.Inline
+	}
+
+	return
+}
+
+// configMapKeyValue reads the value of the selected key of a ConfigMap in the namespace
+func configMapKeyValue(ctx context.Context, c client.Client, namespace string, selector *corev1.ConfigMapKeySelector) (string, error) {
+	if namespace == "" {
+		return "", fmt.Errorf("configmap %s can't be read, ConfigMap references are only supported on namespaced resources", selector.Name)
+	}
+
+	configMap := &corev1.ConfigMap{}
+	err := c.Get(ctx, types.NamespacedName{Name: selector.Name, Namespace: namespace}, configMap)
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := configMap.Data[selector.Key]
+	if !ok {
+		return "", fmt.Errorf("configmap %s/%s has no %s key", namespace, selector.Name, selector.Key)
+	}
+
+	return value, nil
+}
+
+// findApiChecksForConfigMap returns a reconcile request for every ApiCheck which references the ConfigMap
+func (r *ApiCheckReconciler) findApiChecksForConfigMap(ctx context.Context, configMap client.Object) []reconcile.Request {
+	apiChecks := &checklyv1alpha1.ApiCheckList{}
+	listOps := &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(apiCheckConfigMapField, configMap.GetName()),
+		Namespace:     configMap.GetNamespace(),
+	}
+	err := r.List(ctx, apiChecks, listOps)
+	if err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, len(apiChecks.Items))
+	for i, item := range apiChecks.Items {
+		requests[i] = reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      item.GetName(),
+				Namespace: item.GetNamespace(),
+			},
+		}
+	}
+	return requests
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("ApiCheck Scripts", func() {
+
+	const (
+		timeout  = time.Second * 10
+		interval = time.Millisecond * 250
+	)
+
+	Context("checkScript", func() {
+		It("Resolves scripts", func() {
+
+			configMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-apicheck-scripts-configmap",
+					Namespace: "default",
+				},
+				Data: map[string]string{
+					"setup.js": "console.log('setup')",
+				},
+			}
+
+			snippet := &checklyv1alpha1.Snippet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-apicheck-scripts-snippet",
+				},
+				Spec: checklyv1alpha1.SnippetSpec{
+					Script: "console.log('teardown')",
+				},
+			}
+
+			By("Expecting nothing without a script")
+			inline, snippetID, ready, err := checkScript(context.Background(), k8sClient, "default", nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inline).To(BeEmpty())
+			Expect(snippetID).To(BeZero())
+			Expect(ready).To(BeTrue())
+
+			By("Expecting the inline script")
+			inline, _, _, err = checkScript(context.Background(), k8sClient, "default", &checklyv1alpha1.Script{Inline: "console.log('inline')"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inline).To(Equal("console.log('inline')"))
+
+			By("Expecting the ConfigMap script")
+			configMapScript := &checklyv1alpha1.Script{
+				ConfigMap: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: configMap.Name},
+					Key:                  "setup.js",
+				},
+			}
+			Expect(apiCheckConfigMapNames(&checklyv1alpha1.ApiCheckSpec{SetupScript: configMapScript})).To(Equal([]string{configMap.Name}))
+			_, _, _, err = checkScript(context.Background(), k8sClient, "default", configMapScript)
+			Expect(err).To(HaveOccurred())
+			Expect(k8sClient.Create(context.Background(), configMap)).Should(Succeed())
+			inline, _, _, err = checkScript(context.Background(), k8sClient, "default", configMapScript)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inline).To(Equal("console.log('setup')"))
+			_, _, _, err = checkScript(context.Background(), k8sClient, "", configMapScript)
+			Expect(err).To(HaveOccurred())
+
+			By("Expecting the Snippet ID")
+			Expect(k8sClient.Create(context.Background(), snippet)).Should(Succeed())
+			Eventually(func() bool {
+				_, snippetID, ready, err := checkScript(context.Background(), k8sClient, "default", &checklyv1alpha1.Script{Snippet: snippet.Name})
+				return err == nil && ready && snippetID == 6
+			}, timeout, interval).Should(BeTrue())
+
+			Expect(k8sClient.Delete(context.Background(), configMap)).Should(Succeed())
+			Expect(k8sClient.Delete(context.Background(), snippet)).Should(Succeed())
+		})
+	})
+})
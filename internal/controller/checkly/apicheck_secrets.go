@@ -0,0 +1,155 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/checkly/checkly-go-sdk"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+// apiCheckSecretField is the field index used to find ApiChecks referencing a Secret
+const apiCheckSecretField = ".spec.secrets"
+
+// authorizationHeader is the header holding the bearer token
+const authorizationHeader = "Authorization"
+
+// apiCheckSecretNames returns the names of the Secrets the ApiCheck reads values from
+func apiCheckSecretNames(spec *checklyv1alpha1.ApiCheckSpec) []string {
+	var names []string
+	for _, header := range spec.Headers {
+		if header.ValueFrom != nil && header.ValueFrom.SecretKeyRef != nil {
+			names = append(names, header.ValueFrom.SecretKeyRef.Name)
+		}
+	}
+	if spec.BasicAuth != nil {
+		names = append(names, spec.BasicAuth.SecretRef.Name)
+	}
+	if spec.BearerTokenSecretRef != nil {
+		names = append(names, spec.BearerTokenSecretRef.Name)
+	}
+
+	return names
+}
+
+// secretKeyValue reads the value of the selected key of a Secret in the namespace
+func secretKeyValue(ctx context.Context, c client.Client, namespace string, selector *corev1.SecretKeySelector) (string, error) {
+	if namespace == "" {
+		return "", fmt.Errorf("secret %s can't be read, Secret references are only supported on namespaced resources", selector.Name)
+	}
+
+	secret := &corev1.Secret{}
+	err := c.Get(ctx, types.NamespacedName{Name: selector.Name, Namespace: namespace}, secret)
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := secret.Data[selector.Key]
+	if !ok {
+		return "", fmt.Errorf("secret %s/%s has no %s key", namespace, selector.Name, selector.Key)
+	}
+
+	return string(value), nil
+}
+
+// apiCheckHeaders returns the headers of the ApiCheck with the values read from Secrets,
+// the bearer token replaces any Authorization header of the spec
+func apiCheckHeaders(ctx context.Context, c client.Client, namespace string, spec *checklyv1alpha1.ApiCheckSpec) (headers []checkly.KeyValue, err error) {
+	for _, header := range spec.Headers {
+		if spec.BearerTokenSecretRef != nil && strings.EqualFold(header.Key, authorizationHeader) {
+			continue
+		}
+		value := header.Value
+		if header.ValueFrom != nil && header.ValueFrom.SecretKeyRef != nil {
+			value, err = secretKeyValue(ctx, c, namespace, header.ValueFrom.SecretKeyRef)
+			if err != nil {
+				return
+			}
+		}
+		headers = append(headers, checkly.KeyValue{Key: header.Key, Value: value})
+	}
+
+	if spec.BearerTokenSecretRef != nil {
+		var token string
+		token, err = secretKeyValue(ctx, c, namespace, spec.BearerTokenSecretRef)
+		if err != nil {
+			return
+		}
+		// Tokens written with echo or from files often end with a newline
+		headers = append(headers, checkly.KeyValue{Key: authorizationHeader, Value: fmt.Sprintf("Bearer %s", strings.TrimSpace(token))})
+	}
+
+	return
+}
+
+// apiCheckBasicAuth returns the basic authentication credentials of the ApiCheck, nil if it has none
+func apiCheckBasicAuth(ctx context.Context, c client.Client, namespace string, spec *checklyv1alpha1.ApiCheckSpec) (basicAuth *checkly.BasicAuth, err error) {
+	if spec.BasicAuth == nil {
+		return
+	}
+
+	basicAuth = &checkly.BasicAuth{}
+	basicAuth.Username, err = secretKeyValue(ctx, c, namespace, &corev1.SecretKeySelector{
+		LocalObjectReference: spec.BasicAuth.SecretRef,
+		Key:                  corev1.BasicAuthUsernameKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	basicAuth.Password, err = secretKeyValue(ctx, c, namespace, &corev1.SecretKeySelector{
+		LocalObjectReference: spec.BasicAuth.SecretRef,
+		Key:                  corev1.BasicAuthPasswordKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// findApiChecksForSecret returns a reconcile request for every ApiCheck which references the Secret
+func (r *ApiCheckReconciler) findApiChecksForSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	apiChecks := &checklyv1alpha1.ApiCheckList{}
+	listOps := &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(apiCheckSecretField, secret.GetName()),
+		Namespace:     secret.GetNamespace(),
+	}
+	err := r.List(ctx, apiChecks, listOps)
+	if err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, len(apiChecks.Items))
+	for i, item := range apiChecks.Items {
+		requests[i] = reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      item.GetName(),
+				Namespace: item.GetNamespace(),
+			},
+		}
+	}
+	return requests
+}
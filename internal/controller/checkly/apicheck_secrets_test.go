@@ -0,0 +1,146 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/checkly/checkly-go-sdk"
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("ApiCheck Secrets", func() {
+
+	Context("apiCheckHeaders", func() {
+		It("Resolves header values", func() {
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-apicheck-headers-secret",
+					Namespace: "default",
+				},
+				Data: map[string][]byte{
+					"token": []byte("Bearer foobarbaz"),
+				},
+			}
+
+			spec := &checklyv1alpha1.ApiCheckSpec{
+				Headers: []checklyv1alpha1.HTTPHeader{
+					{
+						Key:   "Accept",
+						Value: "application/json",
+					},
+					{
+						Key: "Authorization",
+						ValueFrom: &checklyv1alpha1.HeaderValueSource{
+							SecretKeyRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name},
+								Key:                  "token",
+							},
+						},
+					},
+				},
+			}
+
+			Expect(apiCheckSecretNames(spec)).To(Equal([]string{secret.Name}))
+
+			By("Expecting an error for a missing secret")
+			_, err := apiCheckHeaders(context.Background(), k8sClient, "default", spec)
+			Expect(err).To(HaveOccurred())
+
+			Expect(k8sClient.Create(context.Background(), secret)).Should(Succeed())
+
+			By("Expecting the header values")
+			headers, err := apiCheckHeaders(context.Background(), k8sClient, "default", spec)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(headers).To(Equal([]checkly.KeyValue{
+				{Key: "Accept", Value: "application/json"},
+				{Key: "Authorization", Value: "Bearer foobarbaz"},
+			}))
+
+			By("Expecting the bearer token to replace the Authorization header")
+			secret.Data["bearer"] = []byte("foobarbaz\n")
+			Expect(k8sClient.Update(context.Background(), secret)).Should(Succeed())
+			bearerSpec := spec.DeepCopy()
+			bearerSpec.BearerTokenSecretRef = &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name},
+				Key:                  "bearer",
+			}
+			Expect(apiCheckSecretNames(bearerSpec)).To(Equal([]string{secret.Name, secret.Name}))
+			headers, err = apiCheckHeaders(context.Background(), k8sClient, "default", bearerSpec)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(headers).To(Equal([]checkly.KeyValue{
+				{Key: "Accept", Value: "application/json"},
+				{Key: "Authorization", Value: "Bearer foobarbaz"},
+			}))
+
+			By("Expecting an error for cluster scoped checks")
+			_, err = apiCheckHeaders(context.Background(), k8sClient, "", spec)
+			Expect(err).To(HaveOccurred())
+
+			By("Expecting an error for a missing key")
+			spec.Headers[1].ValueFrom.SecretKeyRef.Key = "does-not-exist"
+			_, err = apiCheckHeaders(context.Background(), k8sClient, "default", spec)
+			Expect(err).To(HaveOccurred())
+
+			Expect(k8sClient.Delete(context.Background(), secret)).Should(Succeed())
+		})
+	})
+
+	Context("apiCheckBasicAuth", func() {
+		It("Resolves the credentials", func() {
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-apicheck-basicauth-secret",
+					Namespace: "default",
+				},
+				Type: corev1.SecretTypeBasicAuth,
+				Data: map[string][]byte{
+					corev1.BasicAuthUsernameKey: []byte("foo"),
+					corev1.BasicAuthPasswordKey: []byte("bar"),
+				},
+			}
+
+			spec := &checklyv1alpha1.ApiCheckSpec{}
+
+			By("Expecting no credentials without basic auth")
+			basicAuth, err := apiCheckBasicAuth(context.Background(), k8sClient, "default", spec)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(basicAuth).To(BeNil())
+
+			spec.BasicAuth = &checklyv1alpha1.BasicAuth{
+				SecretRef: corev1.LocalObjectReference{Name: secret.Name},
+			}
+			Expect(apiCheckSecretNames(spec)).To(Equal([]string{secret.Name}))
+
+			Expect(k8sClient.Create(context.Background(), secret)).Should(Succeed())
+
+			By("Expecting the credentials")
+			basicAuth, err = apiCheckBasicAuth(context.Background(), k8sClient, "default", spec)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(basicAuth).To(Equal(&checkly.BasicAuth{Username: "foo", Password: "bar"}))
+
+			Expect(k8sClient.Delete(context.Background(), secret)).Should(Succeed())
+		})
+	})
+})
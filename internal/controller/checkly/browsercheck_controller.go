@@ -0,0 +1,548 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+	errs "errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/checkly/checkly-go-sdk"
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+	external "github.com/checkly/checkly-operator/external/checkly"
+)
+
+// browserCheckGroupField is the field index used to find the BrowserChecks of a Group
+const browserCheckGroupField = ".spec.group"
+
+// browserCheckSecretField is the field index used to find BrowserChecks referencing a Secret
+const browserCheckSecretField = ".spec.secrets"
+
+// browserCheckConfigMapField is the field index used to find BrowserChecks referencing a ConfigMap
+const browserCheckConfigMapField = ".spec.configmap.name"
+
+// BrowserCheckReconciler reconciles a BrowserCheck object
+type BrowserCheckReconciler struct {
+	client.Client
+	Scheme           *runtime.Scheme
+	ApiClient        checkly.Client
+	ControllerDomain string
+	ResourceSelector labels.Selector
+	Recorder         record.EventRecorder
+	ResyncInterval   time.Duration
+}
+
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=browserchecks,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=browserchecks/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=browserchecks/finalizers,verbs=update
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=groups,verbs=get;list
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.0/pkg/reconcile
+func (r *BrowserCheckReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	logger := log.FromContext(ctx)
+
+	browserCheckFinalizer := fmt.Sprintf("%s/finalizer", r.ControllerDomain)
+	logger.V(1).Info("Reconciler started")
+
+	browserCheck := &checklyv1alpha1.BrowserCheck{}
+
+	// ////////////////////////////////
+	// Delete Logic
+	// ///////////////////////////////
+	err = r.Get(ctx, req.NamespacedName, browserCheck)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// The resource has been deleted
+			logger.V(1).Info("Deleted", "checkly ID", browserCheck.Status.ID, "name", browserCheck.Name)
+			forgetFailures(browserCheck, req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object
+		logger.Error(err, "can't read the object")
+		return ctrl.Result{}, nil
+	}
+
+	// /////////////////////////////
+	// Account lookup
+	// ////////////////////////////
+	// A failed lookup fails the delete of a deleted resource like an API error would, so the force-delete
+	// annotation still removes its finalizer once the account or its Secret is gone
+	apiClient, credentials, accountErr := apiClientForCheck(ctx, r.Client, r.ApiClient, browserCheck.Spec.Account, browserCheck, browserCheck.Status.ID, browserCheck.Status.Credentials)
+	if accountErr != nil {
+		logger.Error(accountErr, "Unable to read credentials of the account", "account", browserCheck.Spec.Account)
+		if browserCheck.GetDeletionTimestamp() == nil {
+			updateConditions(ctx, r.Client, browserCheck, &browserCheck.Status.Conditions, browserCheck.Status.ID != "", accountErr, checkDegradation(browserCheck.Spec.Paused, browserCheck.Spec.Muted))
+			return ctrl.Result{}, accountErr
+		}
+	}
+	credentialsChanged := accountErr == nil && browserCheck.Status.Credentials != credentials
+	if credentialsChanged {
+		browserCheck.Status.Credentials = credentials
+	}
+
+	if browserCheck.GetDeletionTimestamp() != nil {
+		if controllerutil.ContainsFinalizer(browserCheck, browserCheckFinalizer) {
+			if checkRetained(browserCheck.Spec.DeletionPolicy, browserCheck.Spec.Adopt) {
+				logger.Info("Checkly browser check is retained, leaving it in place", "checkly ID", browserCheck.Status.ID)
+			} else if browserCheck.Status.ID == "" {
+				logger.Info("Checkly browser check was never created, nothing to delete")
+			} else {
+				logger.V(1).Info("Finalizer is present, trying to delete Checkly browser check", "checkly ID", browserCheck.Status.ID)
+				err := accountErr
+				if err == nil {
+					err = external.DeleteBrowserCheck(browserCheck.Status.ID, apiClient)
+				}
+				if external.IsNotFound(err) {
+					logger.Info("Checkly browser check was already deleted", "checkly ID", browserCheck.Status.ID)
+				} else if err != nil {
+					recordSyncFailed(r.Recorder, browserCheck, "delete", "browser check", err)
+					logger.Error(err, "Failed to delete checkly browser check")
+					forced, retry, err := forceDelete(ctx, r.Recorder, r.ControllerDomain, browserCheck, "browser check", browserCheck.Status.ID, err)
+					if !forced {
+						return retry, err
+					}
+				} else {
+					logger.Info("Successfully deleted checkly browser check", "checkly ID", browserCheck.Status.ID)
+				}
+			}
+
+			controllerutil.RemoveFinalizer(browserCheck, browserCheckFinalizer)
+			err = r.Update(ctx, browserCheck)
+			if err != nil {
+				logger.Error(err, "Failed to delete finalizer")
+				return ctrl.Result{}, err
+			}
+			logger.V(1).Info("Successfully deleted finalizer")
+			forgetFailures(browserCheck, client.ObjectKeyFromObject(browserCheck))
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Object found, let's do something with it. It's either updated, or it's new.
+	logger.V(1).Info("Object found", "name", browserCheck.Name)
+
+	// /////////////////////////////
+	// Finalizer logic
+	// ////////////////////////////
+	if !controllerutil.ContainsFinalizer(browserCheck, browserCheckFinalizer) {
+		controllerutil.AddFinalizer(browserCheck, browserCheckFinalizer)
+		err = r.Update(ctx, browserCheck)
+		if err != nil {
+			logger.Error(err, "Failed to update BrowserCheck status")
+			return ctrl.Result{}, err
+		}
+		logger.V(1).Info("Added finalizer", "checkly ID", browserCheck.Status.ID)
+		return ctrl.Result{}, nil
+	}
+
+	// /////////////////////////////
+	// Conditions
+	// ////////////////////////////
+	defer func() {
+		changed := setConditions(&browserCheck.Status.Conditions, browserCheck.Generation, browserCheck.Status.ID != "", result, err, checkDegradation(browserCheck.Spec.Paused, browserCheck.Spec.Muted))
+		if setStalled(&browserCheck.Status.Conditions, browserCheck, "browser check", err) {
+			changed = true
+		}
+		if credentialsChanged {
+			changed = true
+		}
+		if changed {
+			statusErr := r.Status().Update(ctx, browserCheck)
+			if statusErr != nil {
+				logger.Error(statusErr, "Failed to update BrowserCheck conditions")
+			}
+		}
+	}()
+
+	// /////////////////////////////
+	// Script lookup
+	// ////////////////////////////
+	script := browserCheck.Spec.Script
+	if browserCheck.Spec.ConfigMap != nil {
+		configMap := &corev1.ConfigMap{}
+		err = r.Get(ctx, types.NamespacedName{Name: browserCheck.Spec.ConfigMap.Name, Namespace: browserCheck.Namespace}, configMap)
+		if err != nil {
+			logger.Error(err, "Unable to read configmap for script", "name", browserCheck.Spec.ConfigMap.Name)
+			return ctrl.Result{}, err
+		}
+
+		script = configMap.Data[browserCheck.Spec.ConfigMap.Key]
+	}
+
+	if script == "" {
+		scriptErr := errs.New("script is empty")
+		logger.Error(scriptErr, "Please add a script inline or through a configmap")
+		return ctrl.Result{}, scriptErr
+	}
+
+	// /////////////////////////////
+	// Environment variables lookup
+	// ////////////////////////////
+	environmentVariables, err := checkEnvironmentVariables(ctx, r.Client, browserCheck.Namespace, browserCheck.Spec.EnvironmentVariables)
+	if err != nil {
+		logger.Error(err, "Unable to read the values of the environment variables")
+		return ctrl.Result{}, err
+	}
+
+	// /////////////////////////////
+	// Lookup group ID
+	// ////////////////////////////
+	group := &checklyv1alpha1.Group{}
+	err = r.Get(ctx, types.NamespacedName{Name: browserCheck.Spec.Group}, group)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// The resource has been deleted
+			logger.Error(err, "Group not found, probably deleted or does not exist", "name", browserCheck.Spec.Group)
+			return ctrl.Result{}, err
+		}
+		// Error reading the object
+		logger.Error(err, "can't read the group object")
+		return ctrl.Result{}, err
+	}
+
+	if !groupAllowsNamespace(group, browserCheck.Namespace) {
+		groupErr := fmt.Errorf("group %s doesn't allow checks from namespace %s", browserCheck.Spec.Group, browserCheck.Namespace)
+		logger.Error(groupErr, "Please add the namespace to the allowedNamespaces of the group")
+		return ctrl.Result{}, groupErr
+	}
+
+	err = sameAccount(ctx, r.Client, browserCheck.Spec.Account, browserCheck.Namespace, accountReferences{group: browserCheck.Spec.Group})
+	if err != nil {
+		logger.Error(err, "Please use the same checklyhq.com account for the check and its group")
+		return ctrl.Result{}, err
+	}
+
+	if group.Status.ID == 0 {
+		logger.V(1).Info("Group ID has not been populated, we're too quick, requeining for retry", "group name", browserCheck.Spec.Group)
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	// The browser check defaults of the group are applied to settings the check doesn't set
+	defaults := group.Spec.BrowserCheckDefaults
+	if defaults == nil {
+		defaults = &checklyv1alpha1.BrowserCheckDefaults{}
+	}
+
+	defaultEnvironmentVariables, err := checkEnvironmentVariables(ctx, r.Client, "", defaults.EnvironmentVariables)
+	if err != nil {
+		logger.Error(err, "Unable to read the values of the environment variables of the group", "group name", browserCheck.Spec.Group)
+		return ctrl.Result{}, err
+	}
+	environmentVariables = mergeEnvironmentVariables(defaultEnvironmentVariables, environmentVariables)
+
+	frequency := browserCheck.Spec.Frequency
+	if frequency == 0 {
+		frequency = defaults.Frequency
+	}
+
+	// /////////////////////////////
+	// Runtime lookup
+	// ////////////////////////////
+	// The group holds the default of the runtime
+	runtimeID := browserCheck.Spec.RuntimeID
+	if runtimeID == "" {
+		runtimeID = defaults.RuntimeID
+	}
+	if runtimeID == "" {
+		runtimeID = group.Spec.RuntimeID
+	}
+	if runtimeID != "" {
+		_, err := external.GetRuntime(runtimeID, apiClient)
+		if err != nil {
+			logger.Error(err, "Runtime is not supported by the account", "runtime", runtimeID)
+			return ctrl.Result{}, err
+		}
+	}
+
+	// The group holds the default of the retry strategy
+	checkRetryStrategy := browserCheck.Spec.RetryStrategy
+	if checkRetryStrategy == nil {
+		checkRetryStrategy = group.Spec.RetryStrategy
+	}
+
+	// Create internal BrowserCheck type
+	internalCheck := external.BrowserCheck{
+		Name:      browserCheck.Name,
+		Namespace: browserCheck.Namespace,
+		Frequency: frequency,
+		Locations: browserCheck.Spec.Locations,
+		Script:    script,
+		ID:        browserCheck.Status.ID,
+		GroupID:   group.Status.ID,
+		Muted:     browserCheck.Spec.Muted,
+		Paused:    browserCheck.Spec.Paused,
+		Labels:    browserCheck.Labels,
+		Tags:      groupTags(group),
+		RuntimeID: runtimeID,
+
+		EnvironmentVariables: environmentVariables,
+		RetryStrategy:        retryStrategy(checkRetryStrategy),
+		Retained:             checkRetained(browserCheck.Spec.DeletionPolicy, browserCheck.Spec.Adopt),
+	}
+
+	// /////////////////////////////
+	// Update logic
+	// ////////////////////////////
+
+	// Determine if it's a new object or if it's an update to an existing object
+	var state external.State
+	if browserCheck.Status.ID != "" {
+		// Existing object, we need to update it
+		logger.V(1).Info("Existing object, with ID", "checkly ID", browserCheck.Status.ID)
+		state, err = external.UpdateBrowserCheck(internalCheck, apiClient)
+		if external.IsNotFound(err) {
+			// The check was deleted outside of the operator, it's created again with a new ID
+			recordNotFound(r.Recorder, browserCheck, "browser check", browserCheck.Status.ID)
+			logger.Info("Checkly browser check not found, recreating it", "checkly ID", browserCheck.Status.ID)
+			browserCheck.Status.ID, internalCheck.ID = "", ""
+		} else if err != nil {
+			recordSyncFailed(r.Recorder, browserCheck, "update", "browser check", err)
+			logger.Error(err, "Failed to update the checkly browser check")
+			return ctrl.Result{}, err
+		}
+	}
+
+	if browserCheck.Status.ID != "" {
+		recordUpdated(r.Recorder, apiClient, browserCheck, "browser check", browserCheck.Status.ID)
+		logger.Info("Updated checkly browser check", "checkly ID", browserCheck.Status.ID)
+
+		stateChanged := setChecklyState(&browserCheck.Status.Checkly, apiClient, state)
+
+		// Checks created before the URL was recorded get it on their next update
+		if stateChanged || browserCheck.Status.ChecklyURL != external.CheckURL(browserCheck.Status.ID) {
+			browserCheck.Status.ChecklyURL = external.CheckURL(browserCheck.Status.ID)
+			err = r.Status().Update(ctx, browserCheck)
+			if err != nil {
+				logger.Error(err, "Failed to update BrowserCheck status")
+				return ctrl.Result{}, err
+			}
+		}
+		return ctrl.Result{RequeueAfter: resyncAfter(r.ResyncInterval)}, nil
+	}
+
+	// /////////////////////////////
+	// Adopt logic
+	// ////////////////////////////
+	if browserCheck.Spec.Adopt != nil {
+		// Updating the existing check takes over its configuration and fails if it doesn't exist
+		internalCheck.ID = browserCheck.Spec.Adopt.ID
+		state, err := external.UpdateBrowserCheck(internalCheck, apiClient)
+		if err != nil {
+			recordSyncFailed(r.Recorder, browserCheck, "adopt", "browser check", err)
+			logger.Error(err, "Failed to adopt checkly browser check", "checkly ID", browserCheck.Spec.Adopt.ID)
+			return ctrl.Result{}, err
+		}
+		recordUpdated(r.Recorder, apiClient, browserCheck, "browser check", browserCheck.Spec.Adopt.ID)
+
+		browserCheck.Status.ID = browserCheck.Spec.Adopt.ID
+		browserCheck.Status.ChecklyURL = external.CheckURL(browserCheck.Status.ID)
+		browserCheck.Status.GroupID = group.Status.ID
+		setChecklyState(&browserCheck.Status.Checkly, apiClient, state)
+		err = r.Status().Update(ctx, browserCheck)
+		if err != nil {
+			logger.Error(err, "Failed to update BrowserCheck status")
+			return ctrl.Result{}, err
+		}
+		logger.V(1).Info("Adopted checkly browser check", "checkly ID", browserCheck.Status.ID)
+		return ctrl.Result{RequeueAfter: resyncAfter(r.ResyncInterval)}, nil
+	}
+
+	// /////////////////////////////
+	// Create logic
+	// ////////////////////////////
+
+	checklyID, err := external.CreateBrowserCheck(internalCheck, apiClient)
+	if err != nil {
+		recordSyncFailed(r.Recorder, browserCheck, "create", "browser check", err)
+		logger.Error(err, "Failed to create checkly browser check")
+		return ctrl.Result{}, err
+	}
+	recordCreated(r.Recorder, apiClient, browserCheck, "browser check", checklyID)
+
+	// Update the custom resource Status with the returned ID
+
+	browserCheck.Status.ID = checklyID
+	browserCheck.Status.ChecklyURL = external.CheckURL(checklyID)
+	browserCheck.Status.GroupID = group.Status.ID
+	err = r.Status().Update(ctx, browserCheck)
+	if err != nil {
+		logger.Error(err, "Failed to update BrowserCheck status")
+		return ctrl.Result{}, err
+	}
+	logger.V(1).Info("New checkly browser check created with", "checkly ID", browserCheck.Status.ID, "spec", browserCheck.Spec)
+
+	return ctrl.Result{RequeueAfter: resyncAfter(r.ResyncInterval)}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *BrowserCheckReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Index BrowserChecks by the ConfigMaps and Secrets they read their script and environment
+	// variables from so changes to them trigger a reconciliation of the check
+	err := mgr.GetFieldIndexer().IndexField(context.Background(), &checklyv1alpha1.BrowserCheck{}, browserCheckConfigMapField, func(rawObj client.Object) []string {
+		browserCheck := rawObj.(*checklyv1alpha1.BrowserCheck)
+		names := checkEnvironmentConfigMapNames(browserCheck.Spec.EnvironmentVariables)
+		if browserCheck.Spec.ConfigMap != nil && browserCheck.Spec.ConfigMap.Name != "" {
+			names = append(names, browserCheck.Spec.ConfigMap.Name)
+		}
+		return names
+	})
+	if err != nil {
+		return err
+	}
+	// Index BrowserChecks by their Group so the defaults of the Group are applied when it changes
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &checklyv1alpha1.BrowserCheck{}, browserCheckGroupField, func(rawObj client.Object) []string {
+		browserCheck := rawObj.(*checklyv1alpha1.BrowserCheck)
+		return []string{browserCheck.Spec.Group}
+	})
+	if err != nil {
+		return err
+	}
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &checklyv1alpha1.BrowserCheck{}, browserCheckSecretField, func(rawObj client.Object) []string {
+		browserCheck := rawObj.(*checklyv1alpha1.BrowserCheck)
+		return checkEnvironmentSecretNames(browserCheck.Spec.EnvironmentVariables)
+	})
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&checklyv1alpha1.BrowserCheck{}, builder.WithPredicates(ResourceSelectorPredicate(r.ResourceSelector))).
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.findBrowserChecksForConfigMap),
+		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.findBrowserChecksForSecret),
+		).
+		Watches(
+			&checklyv1alpha1.Group{},
+			handler.EnqueueRequestsFromMapFunc(r.findBrowserChecksForGroup),
+		).
+		Complete(rateLimitRequeue{r})
+}
+
+// findBrowserChecksForConfigMap returns a reconcile request for every BrowserCheck which references the ConfigMap
+func (r *BrowserCheckReconciler) findBrowserChecksForConfigMap(ctx context.Context, configMap client.Object) []reconcile.Request {
+	browserChecks := &checklyv1alpha1.BrowserCheckList{}
+	listOps := &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(browserCheckConfigMapField, configMap.GetName()),
+		Namespace:     configMap.GetNamespace(),
+	}
+	err := r.List(ctx, browserChecks, listOps)
+	if err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, len(browserChecks.Items))
+	for i, item := range browserChecks.Items {
+		requests[i] = reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      item.GetName(),
+				Namespace: item.GetNamespace(),
+			},
+		}
+	}
+	return append(requests, r.findBrowserChecksForGroupSource(ctx, groupConfigMapField, configMap)...)
+}
+
+// findBrowserChecksForSecret returns a reconcile request for every BrowserCheck which references the Secret
+func (r *BrowserCheckReconciler) findBrowserChecksForSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	browserChecks := &checklyv1alpha1.BrowserCheckList{}
+	listOps := &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(browserCheckSecretField, secret.GetName()),
+		Namespace:     secret.GetNamespace(),
+	}
+	err := r.List(ctx, browserChecks, listOps)
+	if err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, len(browserChecks.Items))
+	for i, item := range browserChecks.Items {
+		requests[i] = reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      item.GetName(),
+				Namespace: item.GetNamespace(),
+			},
+		}
+	}
+	return append(requests, r.findBrowserChecksForGroupSource(ctx, groupSecretField, secret)...)
+}
+
+// findBrowserChecksForGroup returns a reconcile request for every BrowserCheck in the Group
+func (r *BrowserCheckReconciler) findBrowserChecksForGroup(ctx context.Context, group client.Object) []reconcile.Request {
+	browserChecks := &checklyv1alpha1.BrowserCheckList{}
+	listOps := &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(browserCheckGroupField, group.GetName()),
+	}
+	err := r.List(ctx, browserChecks, listOps)
+	if err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, len(browserChecks.Items))
+	for i, item := range browserChecks.Items {
+		requests[i] = reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      item.GetName(),
+				Namespace: item.GetNamespace(),
+			},
+		}
+	}
+	return requests
+}
+
+// findBrowserChecksForGroupSource returns a reconcile request for every BrowserCheck in a Group which
+// references the Secret or ConfigMap through the given field index, ex. in its browser check defaults
+func (r *BrowserCheckReconciler) findBrowserChecksForGroupSource(ctx context.Context, field string, obj client.Object) []reconcile.Request {
+	groups := &checklyv1alpha1.GroupList{}
+	listOps := &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(field, client.ObjectKeyFromObject(obj).String()),
+	}
+	err := r.List(ctx, groups, listOps)
+	if err != nil {
+		return []reconcile.Request{}
+	}
+
+	var requests []reconcile.Request
+	for i := range groups.Items {
+		requests = append(requests, r.findBrowserChecksForGroup(ctx, &groups.Items[i])...)
+	}
+	return requests
+}
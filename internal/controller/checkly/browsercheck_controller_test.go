@@ -0,0 +1,214 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/checkly/checkly-go-sdk"
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("BrowserCheck Controller", func() {
+
+	// Define utility constants for object names and testing timeouts/durations and intervals.
+	const (
+		timeout  = time.Second * 10
+		duration = time.Second * 10
+		interval = time.Millisecond * 250
+	)
+
+	BeforeEach(func() {
+		// Add any setup steps that needs to be executed before each test
+	})
+
+	AfterEach(func() {
+		// Add any teardown steps that needs to be executed after each test
+	})
+
+	Context("BrowserCheck", func() {
+		It("Full reconciliation", func() {
+
+			key := types.NamespacedName{
+				Name:      "test-browsercheck",
+				Namespace: "default",
+			}
+
+			groupKey := types.NamespacedName{
+				Name: "test-browsercheck-group",
+			}
+
+			configMapKey := types.NamespacedName{
+				Name:      "test-browsercheck-script",
+				Namespace: "default",
+			}
+
+			group := &checklyv1alpha1.Group{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: groupKey.Name,
+				},
+			}
+
+			configMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      configMapKey.Name,
+					Namespace: configMapKey.Namespace,
+				},
+				Data: map[string]string{
+					"script.js": "console.log('foo')",
+				},
+			}
+
+			browserCheck := &checklyv1alpha1.BrowserCheck{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: checklyv1alpha1.BrowserCheckSpec{
+					ConfigMap: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: configMapKey.Name,
+						},
+						Key: "script.js",
+					},
+					Group: groupKey.Name,
+					Muted: true,
+				},
+			}
+
+			// Create
+			Expect(k8sClient.Create(context.Background(), group)).Should(Succeed())
+			Expect(k8sClient.Create(context.Background(), configMap)).Should(Succeed())
+			Expect(k8sClient.Create(context.Background(), browserCheck)).Should(Succeed())
+
+			By("Expecting submitted")
+			Eventually(func() bool {
+				f := &checklyv1alpha1.BrowserCheck{}
+				err := k8sClient.Get(context.Background(), key, f)
+				if err != nil {
+					return false
+				}
+				return true
+			}, timeout, interval).Should(BeTrue())
+
+			// Status.ID should be present
+			By("Expecting check ID")
+			Eventually(func() bool {
+				f := &checklyv1alpha1.BrowserCheck{}
+				err := k8sClient.Get(context.Background(), key, f)
+				if f.Status.ID == "2" && err == nil {
+					return true
+				}
+				return false
+			}, timeout, interval).Should(BeTrue())
+
+			// Finalizer should be present
+			By("Expecting finalizer")
+			Eventually(func() bool {
+				f := &checklyv1alpha1.BrowserCheck{}
+				err := k8sClient.Get(context.Background(), key, f)
+				if err != nil {
+					return false
+				}
+
+				for _, finalizer := range f.Finalizers {
+					Expect(finalizer).To(Equal("testing.domain.tld/finalizer"), "Finalizer should match")
+				}
+
+				return true
+			}, timeout, interval).Should(BeTrue())
+
+			// Delete
+			Expect(k8sClient.Delete(context.Background(), group)).Should(Succeed())
+
+			By("Expecting to delete successfully")
+			Eventually(func() error {
+				f := &checklyv1alpha1.BrowserCheck{}
+				k8sClient.Get(context.Background(), key, f)
+				return k8sClient.Delete(context.Background(), f)
+			}, timeout, interval).Should(Succeed())
+
+			By("Expecting delete to finish")
+			Eventually(func() error {
+				f := &checklyv1alpha1.BrowserCheck{}
+				return k8sClient.Get(context.Background(), key, f)
+			}, timeout, interval).ShouldNot(Succeed())
+
+			Expect(k8sClient.Delete(context.Background(), configMap)).Should(Succeed())
+		})
+	})
+
+	Context("Deletion", func() {
+		It("Removes the finalizer of checks which don't exist in checklyhq.com", func() {
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("/v1/checks/gone", func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"statusCode":404,"error":"Not Found","message":"Not Found"}`))
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			deletion := metav1.Now()
+			neverCreated := &checklyv1alpha1.BrowserCheck{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "test-browsercheck-never-created",
+					Namespace:         "default",
+					Finalizers:        []string{"testing.domain.tld/finalizer"},
+					DeletionTimestamp: &deletion,
+				},
+			}
+			gone := &checklyv1alpha1.BrowserCheck{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "test-browsercheck-gone",
+					Namespace:         "default",
+					Finalizers:        []string{"testing.domain.tld/finalizer"},
+					DeletionTimestamp: &deletion,
+				},
+				Status: checklyv1alpha1.BrowserCheckStatus{ID: "gone"},
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(neverCreated, gone).Build()
+
+			r := &BrowserCheckReconciler{
+				Client:           fakeClient,
+				ApiClient:        checkly.NewClient(server.URL, "foobarbaz", nil, nil),
+				ControllerDomain: "testing.domain.tld",
+			}
+
+			for _, browserCheck := range []*checklyv1alpha1.BrowserCheck{neverCreated, gone} {
+				key := client.ObjectKeyFromObject(browserCheck)
+				_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(apierrors.IsNotFound(fakeClient.Get(context.Background(), key, &checklyv1alpha1.BrowserCheck{}))).To(BeTrue())
+			}
+		})
+	})
+})
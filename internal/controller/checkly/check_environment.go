@@ -0,0 +1,126 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/checkly/checkly-go-sdk"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+// checkEnvironmentSecretNames returns the names of the Secrets the environment variables read values from
+func checkEnvironmentSecretNames(variables []checklyv1alpha1.CheckEnvironmentVariable) []string {
+	var names []string
+	for _, variable := range variables {
+		if variable.ValueFrom != nil && variable.ValueFrom.SecretKeyRef != nil {
+			names = append(names, variable.ValueFrom.SecretKeyRef.Name)
+		}
+	}
+
+	return names
+}
+
+// checkEnvironmentConfigMapNames returns the names of the ConfigMaps the environment variables read values from
+func checkEnvironmentConfigMapNames(variables []checklyv1alpha1.CheckEnvironmentVariable) []string {
+	var names []string
+	for _, variable := range variables {
+		if variable.ValueFrom != nil && variable.ValueFrom.ConfigMapKeyRef != nil {
+			names = append(names, variable.ValueFrom.ConfigMapKeyRef.Name)
+		}
+	}
+
+	return names
+}
+
+// groupEnvironmentSources returns the namespace/name keys of the Secrets and ConfigMaps the environment
+// variables of a Group read values from
+func groupEnvironmentSources(variables []checklyv1alpha1.CheckEnvironmentVariable) (secrets []string, configMaps []string) {
+	for _, variable := range variables {
+		if variable.ValueFrom == nil {
+			continue
+		}
+		if variable.ValueFrom.SecretKeyRef != nil {
+			secrets = append(secrets, types.NamespacedName{Namespace: variable.ValueFrom.Namespace, Name: variable.ValueFrom.SecretKeyRef.Name}.String())
+		}
+		if variable.ValueFrom.ConfigMapKeyRef != nil {
+			configMaps = append(configMaps, types.NamespacedName{Namespace: variable.ValueFrom.Namespace, Name: variable.ValueFrom.ConfigMapKeyRef.Name}.String())
+		}
+	}
+
+	return
+}
+
+// mergeEnvironmentVariables returns the default environment variables followed by the variables, a variable
+// replaces the default with the same key
+func mergeEnvironmentVariables(defaults []checkly.EnvironmentVariable, variables []checkly.EnvironmentVariable) (envs []checkly.EnvironmentVariable) {
+	keys := make(map[string]bool, len(variables))
+	for _, variable := range variables {
+		keys[variable.Key] = true
+	}
+
+	for _, env := range defaults {
+		if !keys[env.Key] {
+			envs = append(envs, env)
+		}
+	}
+	envs = append(envs, variables...)
+
+	return
+}
+
+// checkEnvironmentVariables returns the environment variables of a check or group with the values read from
+// Secrets and ConfigMaps, values read from a Secret are locked so they're hidden in the checklyhq.com UI.
+// Cluster scoped resources pass an empty namespace, the namespace of the source is used instead.
+func checkEnvironmentVariables(ctx context.Context, c client.Client, namespace string, variables []checklyv1alpha1.CheckEnvironmentVariable) (envs []checkly.EnvironmentVariable, err error) {
+	for _, variable := range variables {
+		env := checkly.EnvironmentVariable{
+			Key:    variable.Key,
+			Value:  variable.Value,
+			Locked: variable.Locked,
+			Secret: variable.Secret,
+		}
+		if variable.ValueFrom != nil {
+			sourceNamespace := namespace
+			if sourceNamespace == "" {
+				sourceNamespace = variable.ValueFrom.Namespace
+			}
+			if sourceNamespace == "" {
+				return nil, fmt.Errorf("environment variable %s has no namespace set for its valueFrom", variable.Key)
+			}
+			switch {
+			case variable.ValueFrom.SecretKeyRef != nil:
+				env.Value, err = secretKeyValue(ctx, c, sourceNamespace, variable.ValueFrom.SecretKeyRef)
+				env.Locked = true
+			case variable.ValueFrom.ConfigMapKeyRef != nil:
+				env.Value, err = configMapKeyValue(ctx, c, sourceNamespace, variable.ValueFrom.ConfigMapKeyRef)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		envs = append(envs, env)
+	}
+
+	return
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/checkly/checkly-go-sdk"
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Check environment variables", func() {
+
+	Context("checkEnvironmentVariables", func() {
+		It("Resolves the values", func() {
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-check-environment-secret",
+					Namespace: "default",
+				},
+				Data: map[string][]byte{
+					"password": []byte("foobarbaz"),
+				},
+			}
+
+			configMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-check-environment-configmap",
+					Namespace: "default",
+				},
+				Data: map[string]string{
+					"user": "foo",
+				},
+			}
+
+			variables := []checklyv1alpha1.CheckEnvironmentVariable{
+				{
+					Key:   "BASE_URL",
+					Value: "https://foo.bar",
+				},
+				{
+					Key: "USER",
+					ValueFrom: &checklyv1alpha1.EnvironmentVariableSource{
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: configMap.Name},
+							Key:                  "user",
+						},
+					},
+				},
+				{
+					Key: "PASSWORD",
+					ValueFrom: &checklyv1alpha1.EnvironmentVariableSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name},
+							Key:                  "password",
+						},
+					},
+				},
+			}
+
+			Expect(checkEnvironmentSecretNames(variables)).To(Equal([]string{secret.Name}))
+			Expect(checkEnvironmentConfigMapNames(variables)).To(Equal([]string{configMap.Name}))
+
+			By("Expecting an error for a missing source")
+			_, err := checkEnvironmentVariables(context.Background(), k8sClient, "default", variables)
+			Expect(err).To(HaveOccurred())
+
+			Expect(k8sClient.Create(context.Background(), secret)).Should(Succeed())
+			Expect(k8sClient.Create(context.Background(), configMap)).Should(Succeed())
+
+			By("Expecting the values with the Secret value locked")
+			envs, err := checkEnvironmentVariables(context.Background(), k8sClient, "default", variables)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(envs).To(Equal([]checkly.EnvironmentVariable{
+				{Key: "BASE_URL", Value: "https://foo.bar"},
+				{Key: "USER", Value: "foo"},
+				{Key: "PASSWORD", Value: "foobarbaz", Locked: true},
+			}))
+
+			By("Expecting the namespace of the source for cluster scoped resources")
+			_, err = checkEnvironmentVariables(context.Background(), k8sClient, "", variables)
+			Expect(err).To(HaveOccurred())
+			groupVariables := []checklyv1alpha1.CheckEnvironmentVariable{*variables[2].DeepCopy()}
+			groupVariables[0].ValueFrom.Namespace = "default"
+			secrets, configMaps := groupEnvironmentSources(groupVariables)
+			Expect(secrets).To(Equal([]string{"default/" + secret.Name}))
+			Expect(configMaps).To(BeEmpty())
+			envs, err = checkEnvironmentVariables(context.Background(), k8sClient, "", groupVariables)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(envs).To(Equal([]checkly.EnvironmentVariable{{Key: "PASSWORD", Value: "foobarbaz", Locked: true}}))
+
+			By("Expecting an error for a missing key")
+			variables[1].ValueFrom.ConfigMapKeyRef.Key = "does-not-exist"
+			_, err = checkEnvironmentVariables(context.Background(), k8sClient, "default", variables)
+			Expect(err).To(HaveOccurred())
+
+			Expect(k8sClient.Delete(context.Background(), secret)).Should(Succeed())
+			Expect(k8sClient.Delete(context.Background(), configMap)).Should(Succeed())
+		})
+	})
+
+	Context("mergeEnvironmentVariables", func() {
+		It("Lets the variables replace the defaults", func() {
+			defaults := []checkly.EnvironmentVariable{
+				{Key: "BASE_URL", Value: "https://foo.bar"},
+				{Key: "USER", Value: "foo"},
+			}
+			variables := []checkly.EnvironmentVariable{
+				{Key: "USER", Value: "bar"},
+				{Key: "PASSWORD", Value: "baz", Locked: true},
+			}
+
+			Expect(mergeEnvironmentVariables(defaults, variables)).To(Equal([]checkly.EnvironmentVariable{
+				{Key: "BASE_URL", Value: "https://foo.bar"},
+				{Key: "USER", Value: "bar"},
+				{Key: "PASSWORD", Value: "baz", Locked: true},
+			}))
+			Expect(mergeEnvironmentVariables(nil, variables)).To(Equal(variables))
+			Expect(mergeEnvironmentVariables(defaults, nil)).To(Equal(defaults))
+		})
+	})
+})
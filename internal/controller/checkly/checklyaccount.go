@@ -0,0 +1,330 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/checkly/checkly-go-sdk"
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+	external "github.com/checkly/checkly-operator/external/checkly"
+)
+
+const (
+	// accountAPIKeyField is the key of the Secret holding the checklyhq.com API key
+	accountAPIKeyField = "CHECKLY_API_KEY"
+	// accountIDField is the key of the Secret holding the checklyhq.com account ID
+	accountIDField = "CHECKLY_ACCOUNT_ID"
+	// defaultBaseURL is the base URL of the checklyhq.com API
+	defaultBaseURL = "https://api.checklyhq.com"
+	// namespaceCredentialsSecret is the Secret holding the credentials of the checks in its
+	// namespace which don't reference an account
+	namespaceCredentialsSecret = "checkly-credentials"
+)
+
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=checklyaccounts,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list
+
+// apiClientForAccount returns a checkly client with the credentials of the referenced ChecklyAccount,
+// if no account is referenced the credentials of the namespace of the object are used and without
+// those the default client is returned. Dry-run clients record the changes on the object
+func apiClientForAccount(ctx context.Context, c client.Client, defaultClient checkly.Client, account string, obj client.Object) (checkly.Client, error) {
+	apiClient, _, err := apiClientForAccountName(ctx, c, defaultClient, account, obj.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+
+	return external.DryRunClientFor(apiClient, obj), nil
+}
+
+// apiClientForCheck returns the client of apiClientForAccount for a check and where its credentials come from,
+// see credentialsSource. A check with an ID which was synced with the credentials of its namespace doesn't fall
+// back to the operator credentials once they're gone, its ID doesn't exist in the account of the operator
+func apiClientForCheck(ctx context.Context, c client.Client, defaultClient checkly.Client, account string, obj client.Object, id string, credentials string) (checkly.Client, string, error) {
+	apiClient, source, err := apiClientForAccountName(ctx, c, defaultClient, account, obj.GetNamespace())
+	if err != nil {
+		return nil, "", err
+	}
+
+	if id != "" && source == "" && credentials == namespaceCredentialsSource(obj.GetNamespace()) {
+		return nil, "", &credentialsMissingError{credentials: credentials, id: id}
+	}
+
+	return external.DryRunClientFor(apiClient, obj), source, nil
+}
+
+// apiClientForAccountName returns the client of the account and where its credentials come from, see
+// credentialsSource. Cluster scoped resources pass an empty namespace
+func apiClientForAccountName(ctx context.Context, c client.Client, defaultClient checkly.Client, account string, namespace string) (checkly.Client, string, error) {
+	if account == "" {
+		return apiClientForNamespace(ctx, c, defaultClient, namespace)
+	}
+
+	checklyAccount, err := allowedAccount(ctx, c, account, namespace)
+	if err != nil {
+		return nil, "", err
+	}
+
+	baseURL := checklyAccount.Spec.URL
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+
+	accountClient, err := NewAPIClientFromSecret(ctx, c, types.NamespacedName{
+		Name:      checklyAccount.Spec.SecretRef.Name,
+		Namespace: checklyAccount.Spec.SecretRef.Namespace,
+	}, baseURL)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return external.DryRunLike(defaultClient, accountClient), accountCredentialsSource(account), nil
+}
+
+// allowedAccount returns the ChecklyAccount, or an error if it doesn't allow resources from the namespace
+func allowedAccount(ctx context.Context, c client.Client, account string, namespace string) (*checklyv1alpha1.ChecklyAccount, error) {
+	checklyAccount := &checklyv1alpha1.ChecklyAccount{}
+	err := c.Get(ctx, types.NamespacedName{Name: account}, checklyAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	if !accountAllowsNamespace(checklyAccount, namespace) {
+		return nil, fmt.Errorf("account %s doesn't allow resources from namespace %s", account, namespace)
+	}
+
+	return checklyAccount, nil
+}
+
+// accountAllowsNamespace returns whether resources from the namespace can use the account, cluster
+// scoped resources pass an empty namespace and can always use it
+func accountAllowsNamespace(account *checklyv1alpha1.ChecklyAccount, namespace string) bool {
+	if len(account.Spec.AllowedNamespaces) == 0 || namespace == "" {
+		return true
+	}
+
+	return slices.Contains(account.Spec.AllowedNamespaces, namespace)
+}
+
+// apiClientForNamespace returns a checkly client with the credentials of the checkly-credentials
+// Secret of the namespace, if the namespace has none the default client is returned
+func apiClientForNamespace(ctx context.Context, c client.Client, defaultClient checkly.Client, namespace string) (checkly.Client, string, error) {
+	if namespace == "" {
+		return defaultClient, "", nil
+	}
+
+	namespaceClient, err := NewAPIClientFromSecret(ctx, c, types.NamespacedName{
+		Name:      namespaceCredentialsSecret,
+		Namespace: namespace,
+	}, defaultBaseURL)
+	if errors.IsNotFound(err) {
+		return defaultClient, "", nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	return external.DryRunLike(defaultClient, namespaceClient), namespaceCredentialsSource(namespace), nil
+}
+
+// accountCredentialsSource and namespaceCredentialsSource describe where the credentials of a resource come
+// from, the credentials of the operator are described by an empty string
+func accountCredentialsSource(account string) string {
+	return fmt.Sprintf("ChecklyAccount %s", account)
+}
+
+func namespaceCredentialsSource(namespace string) string {
+	return fmt.Sprintf("Secret %s", types.NamespacedName{Name: namespaceCredentialsSecret, Namespace: namespace})
+}
+
+// credentialsMissingError is returned for a check synced with the credentials of its namespace once they're
+// removed, syncing its ID with the operator credentials would create a duplicate in the operator account
+type credentialsMissingError struct {
+	credentials string
+	id          string
+}
+
+// Error implements error
+func (e *credentialsMissingError) Error() string {
+	return fmt.Sprintf("check %s was synced with the credentials of %s which doesn't exist anymore, restore it or set an account", e.id, e.credentials)
+}
+
+// accountMismatchError is returned when a resource references a group or alert channel of another
+// checklyhq.com account, their IDs don't exist in the account of the resource
+type accountMismatchError struct {
+	kind      string
+	name      string
+	account   string
+	reference string
+}
+
+// Error implements error
+func (e *accountMismatchError) Error() string {
+	return fmt.Sprintf("%s %s uses the checklyhq.com account of %s, not the account of %s", e.kind, e.name, e.reference, e.account)
+}
+
+// resolvedAccount is the checklyhq.com account apiClientForAccountName resolves an account to
+type resolvedAccount struct {
+	// id is the checklyhq.com account ID, empty for the operator credentials
+	id string
+	// source describes where the credentials come from
+	source string
+}
+
+// operatorAccount is the account of the operator credentials, snippets and private locations have no account
+// and are always synced with them
+var operatorAccount = resolvedAccount{source: "the operator credentials"}
+
+// resolveAccount returns the checklyhq.com account of the credentials apiClientForAccountName uses for the
+// account, cluster scoped resources pass an empty namespace
+func resolveAccount(ctx context.Context, c client.Client, account string, namespace string) (resolvedAccount, error) {
+	key := types.NamespacedName{Name: namespaceCredentialsSecret, Namespace: namespace}
+	source := namespaceCredentialsSource(namespace)
+	if account != "" {
+		checklyAccount, err := allowedAccount(ctx, c, account, namespace)
+		if err != nil {
+			return resolvedAccount{}, err
+		}
+		key = types.NamespacedName{Name: checklyAccount.Spec.SecretRef.Name, Namespace: checklyAccount.Spec.SecretRef.Namespace}
+		source = accountCredentialsSource(account)
+	} else if namespace == "" {
+		return operatorAccount, nil
+	}
+
+	credentials, err := NewCredentialsFromSecret(ctx, c, key)
+	if account == "" && errors.IsNotFound(err) {
+		return operatorAccount, nil
+	}
+	if err != nil {
+		return resolvedAccount{}, err
+	}
+	_, accountID := credentials.Get()
+
+	return resolvedAccount{id: accountID, source: source}, nil
+}
+
+// accountReferences are the resources a resource references by name, their checklyhq.com IDs are only valid
+// within the account they're synced with
+type accountReferences struct {
+	group            string
+	alertChannels    []string
+	subscriptions    []checklyv1alpha1.AlertChannelSubscription
+	snippets         []string
+	privateLocations []string
+}
+
+// sameAccount returns an accountMismatchError if a resource referenced by the resource, its alert channels also
+// through AlertChannelSubscription resources, resolves to another checklyhq.com account than the resource.
+// Snippets and private locations are synced with the operator credentials, resources of other accounts can't
+// reference them
+func sameAccount(ctx context.Context, c client.Client, account string, namespace string, refs accountReferences) error {
+	resourceAccount, err := resolveAccount(ctx, c, account, namespace)
+	if err != nil {
+		return err
+	}
+
+	if resourceAccount.id != operatorAccount.id {
+		if len(refs.snippets) > 0 {
+			return &accountMismatchError{kind: "snippet", name: refs.snippets[0], account: resourceAccount.source, reference: operatorAccount.source}
+		}
+		if len(refs.privateLocations) > 0 {
+			return &accountMismatchError{kind: "private location", name: refs.privateLocations[0], account: resourceAccount.source, reference: operatorAccount.source}
+		}
+	}
+
+	if refs.group != "" {
+		ref := &checklyv1alpha1.Group{}
+		err = c.Get(ctx, types.NamespacedName{Name: refs.group}, ref)
+		if err != nil {
+			return err
+		}
+		groupAccount, err := resolveAccount(ctx, c, ref.Spec.Account, "")
+		if err != nil {
+			return err
+		}
+		if groupAccount.id != resourceAccount.id {
+			return &accountMismatchError{kind: "group", name: refs.group, account: resourceAccount.source, reference: groupAccount.source}
+		}
+	}
+
+	names := slices.Clone(refs.alertChannels)
+	for _, subscription := range refs.subscriptions {
+		names = append(names, subscription.Spec.AlertChannel)
+	}
+	slices.Sort(names)
+	for _, name := range slices.Compact(names) {
+		ref := &checklyv1alpha1.AlertChannel{}
+		err = c.Get(ctx, types.NamespacedName{Name: name}, ref)
+		if err != nil {
+			return err
+		}
+		alertChannelAccount, err := resolveAccount(ctx, c, ref.Spec.Account, "")
+		if err != nil {
+			return err
+		}
+		if alertChannelAccount.id != resourceAccount.id {
+			return &accountMismatchError{kind: "alert channel", name: name, account: resourceAccount.source, reference: alertChannelAccount.source}
+		}
+	}
+
+	return nil
+}
+
+// NewAPIClientFromSecret returns a checkly client with the credentials stored in the
+// CHECKLY_API_KEY and CHECKLY_ACCOUNT_ID keys of the Secret
+func NewAPIClientFromSecret(ctx context.Context, c client.Reader, key types.NamespacedName, baseURL string) (checkly.Client, error) {
+	credentials, err := NewCredentialsFromSecret(ctx, c, key)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey, accountID := credentials.Get()
+	apiClient := checkly.NewClient(
+		baseURL,
+		apiKey,
+		external.NewHTTPClient(),
+		nil, //io.Writer to output debug messages
+	)
+	apiClient.SetAccountId(accountID)
+
+	return apiClient, nil
+}
+
+// NewCredentialsFromSecret returns the credentials stored in the CHECKLY_API_KEY and
+// CHECKLY_ACCOUNT_ID keys of the Secret
+func NewCredentialsFromSecret(ctx context.Context, c client.Reader, key types.NamespacedName) (*external.Credentials, error) {
+	secret := &corev1.Secret{}
+	err := c.Get(ctx, key, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey := string(secret.Data[accountAPIKeyField])
+	accountID := string(secret.Data[accountIDField])
+	if apiKey == "" || accountID == "" {
+		return nil, fmt.Errorf("secret %s is missing %s or %s", key, accountAPIKeyField, accountIDField)
+	}
+
+	return external.NewCredentials(apiKey, accountID), nil
+}
@@ -0,0 +1,284 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/checkly/checkly-go-sdk"
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("ChecklyAccount", func() {
+
+	Context("apiClientForAccount", func() {
+		It("Resolves credentials", func() {
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-checklyaccount-secret",
+					Namespace: "default",
+				},
+				Data: map[string][]byte{
+					"CHECKLY_API_KEY":    []byte("foobarbaz"),
+					"CHECKLY_ACCOUNT_ID": []byte("1234567890"),
+				},
+			}
+
+			account := &checklyv1alpha1.ChecklyAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-checklyaccount",
+				},
+				Spec: checklyv1alpha1.ChecklyAccountSpec{
+					SecretRef: corev1.SecretReference{
+						Name:      secret.Name,
+						Namespace: secret.Namespace,
+					},
+					URL: "http://localhost:5555",
+				},
+			}
+
+			defaultClient := checkly.NewClient(
+				"http://localhost:5555",
+				"foobarbaz",
+				nil,
+				nil,
+			)
+
+			group := &checklyv1alpha1.Group{}
+			apiCheck := &checklyv1alpha1.ApiCheck{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+				},
+			}
+
+			Expect(k8sClient.Create(context.Background(), secret)).Should(Succeed())
+			Expect(k8sClient.Create(context.Background(), account)).Should(Succeed())
+
+			By("Expecting the default client without an account")
+			apiClient, err := apiClientForAccount(context.Background(), k8sClient, defaultClient, "", group)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(apiClient).To(Equal(defaultClient))
+
+			By("Expecting a client for the account")
+			apiClient, err = apiClientForAccount(context.Background(), k8sClient, defaultClient, account.Name, apiCheck)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(apiClient).ToNot(BeNil())
+			Expect(apiClient).ToNot(Equal(defaultClient))
+
+			By("Expecting an error for a missing account")
+			_, err = apiClientForAccount(context.Background(), k8sClient, defaultClient, "does-not-exist", group)
+			Expect(err).To(HaveOccurred())
+
+			By("Expecting an error for incomplete credentials")
+			secret.Data = map[string][]byte{
+				"CHECKLY_API_KEY": []byte("foobarbaz"),
+			}
+			Expect(k8sClient.Update(context.Background(), secret)).Should(Succeed())
+			_, err = apiClientForAccount(context.Background(), k8sClient, defaultClient, account.Name, apiCheck)
+			Expect(err).To(HaveOccurred())
+
+			Expect(k8sClient.Delete(context.Background(), account)).Should(Succeed())
+			Expect(k8sClient.Delete(context.Background(), secret)).Should(Succeed())
+		})
+
+		It("Resolves namespace credentials", func() {
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "checkly-credentials",
+					Namespace: "default",
+				},
+				Data: map[string][]byte{
+					"CHECKLY_API_KEY":    []byte("foobarbaz"),
+					"CHECKLY_ACCOUNT_ID": []byte("1234567890"),
+				},
+			}
+
+			defaultClient := checkly.NewClient(
+				"http://localhost:5555",
+				"foobarbaz",
+				nil,
+				nil,
+			)
+
+			group := &checklyv1alpha1.Group{}
+			apiCheck := &checklyv1alpha1.ApiCheck{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+				},
+			}
+
+			By("Expecting the default client without namespace credentials")
+			apiClient, err := apiClientForAccount(context.Background(), k8sClient, defaultClient, "", apiCheck)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(apiClient).To(Equal(defaultClient))
+
+			Expect(k8sClient.Create(context.Background(), secret)).Should(Succeed())
+
+			By("Expecting a client for the namespace")
+			apiClient, err = apiClientForAccount(context.Background(), k8sClient, defaultClient, "", apiCheck)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(apiClient).ToNot(BeNil())
+			Expect(apiClient).ToNot(Equal(defaultClient))
+
+			By("Expecting the default client for cluster scoped resources")
+			apiClient, err = apiClientForAccount(context.Background(), k8sClient, defaultClient, "", group)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(apiClient).To(Equal(defaultClient))
+
+			Expect(k8sClient.Delete(context.Background(), secret)).Should(Succeed())
+		})
+	})
+
+	Context("allowedNamespaces", func() {
+		It("Restricts the namespaces which can use the account", func() {
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "account", Namespace: "checkly"},
+				Data: map[string][]byte{
+					"CHECKLY_API_KEY":    []byte("foobarbaz"),
+					"CHECKLY_ACCOUNT_ID": []byte("1234567890"),
+				},
+			}
+			account := &checklyv1alpha1.ChecklyAccount{
+				ObjectMeta: metav1.ObjectMeta{Name: "team"},
+				Spec: checklyv1alpha1.ChecklyAccountSpec{
+					SecretRef:         corev1.SecretReference{Name: secret.Name, Namespace: secret.Namespace},
+					AllowedNamespaces: []string{"team"},
+				},
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(secret, account).Build()
+
+			By("Expecting a client for the allowed namespace")
+			_, _, err := apiClientForAccountName(context.Background(), fakeClient, nil, account.Name, "team")
+			Expect(err).ToNot(HaveOccurred())
+
+			By("Expecting a client for cluster scoped resources")
+			_, _, err = apiClientForAccountName(context.Background(), fakeClient, nil, account.Name, "")
+			Expect(err).ToNot(HaveOccurred())
+
+			By("Expecting an error for other namespaces")
+			_, _, err = apiClientForAccountName(context.Background(), fakeClient, nil, account.Name, "default")
+			Expect(err).To(MatchError("account team doesn't allow resources from namespace default"))
+			_, err = resolveAccount(context.Background(), fakeClient, account.Name, "default")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("sameAccount", func() {
+		It("Fails on references to another account", func() {
+
+			accountSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "account", Namespace: "checkly"},
+				Data: map[string][]byte{
+					"CHECKLY_API_KEY":    []byte("foobarbaz"),
+					"CHECKLY_ACCOUNT_ID": []byte("1234567890"),
+				},
+			}
+			account := &checklyv1alpha1.ChecklyAccount{
+				ObjectMeta: metav1.ObjectMeta{Name: "account"},
+				Spec: checklyv1alpha1.ChecklyAccountSpec{
+					SecretRef: corev1.SecretReference{Name: accountSecret.Name, Namespace: accountSecret.Namespace},
+				},
+			}
+			group := &checklyv1alpha1.Group{ObjectMeta: metav1.ObjectMeta{Name: "group"}}
+			alertChannel := &checklyv1alpha1.AlertChannel{
+				ObjectMeta: metav1.ObjectMeta{Name: "alert-channel"},
+				Spec:       checklyv1alpha1.AlertChannelSpec{Account: account.Name},
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(accountSecret, account, group, alertChannel).Build()
+			subscriptions := []checklyv1alpha1.AlertChannelSubscription{{
+				Spec: checklyv1alpha1.AlertChannelSubscriptionSpec{AlertChannel: alertChannel.Name},
+			}}
+
+			By("Expecting the operator account of the check and the group to match")
+			Expect(sameAccount(context.Background(), fakeClient, "", "default", accountReferences{group: group.Name})).To(Succeed())
+
+			By("Expecting the account of a subscribed alert channel not to match")
+			err := sameAccount(context.Background(), fakeClient, "", "default", accountReferences{group: group.Name, subscriptions: subscriptions})
+			Expect(err).To(MatchError("alert channel alert-channel uses the checklyhq.com account of ChecklyAccount account, not the account of the operator credentials"))
+			reason, _ := syncFailure(err)
+			Expect(reason).To(Equal(reasonAccountMismatch))
+
+			By("Expecting the namespace credentials of the same account to match the alert channel")
+			namespaceSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: namespaceCredentialsSecret, Namespace: "default"},
+				Data:       accountSecret.Data,
+			}
+			Expect(fakeClient.Create(context.Background(), namespaceSecret)).To(Succeed())
+			Expect(sameAccount(context.Background(), fakeClient, "", "default", accountReferences{alertChannels: []string{alertChannel.Name}})).To(Succeed())
+
+			By("Expecting the group of the operator account not to match the namespace credentials")
+			err = sameAccount(context.Background(), fakeClient, "", "default", accountReferences{group: group.Name, alertChannels: []string{alertChannel.Name}})
+			Expect(err).To(MatchError("group group uses the checklyhq.com account of the operator credentials, not the account of Secret default/checkly-credentials"))
+
+			By("Expecting snippets and private locations to only match the operator account")
+			err = sameAccount(context.Background(), fakeClient, "", "default", accountReferences{snippets: []string{"snippet"}})
+			Expect(err).To(MatchError("snippet snippet uses the checklyhq.com account of the operator credentials, not the account of Secret default/checkly-credentials"))
+			err = sameAccount(context.Background(), fakeClient, account.Name, "", accountReferences{privateLocations: []string{"private-location"}})
+			Expect(err).To(MatchError("private location private-location uses the checklyhq.com account of the operator credentials, not the account of ChecklyAccount account"))
+			Expect(sameAccount(context.Background(), fakeClient, "", "", accountReferences{group: group.Name, snippets: []string{"snippet"}, privateLocations: []string{"private-location"}})).To(Succeed())
+		})
+	})
+
+	Context("apiClientForCheck", func() {
+		It("Doesn't fall back to the operator credentials for checks synced with the namespace credentials", func() {
+
+			namespaceSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: namespaceCredentialsSecret, Namespace: "team"},
+				Data: map[string][]byte{
+					"CHECKLY_API_KEY":    []byte("foobarbaz"),
+					"CHECKLY_ACCOUNT_ID": []byte("1234567890"),
+				},
+			}
+			apiCheck := &checklyv1alpha1.ApiCheck{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-credentials", Namespace: "team"},
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(namespaceSecret).Build()
+
+			By("Expecting the namespace credentials to be the source")
+			_, source, err := apiClientForCheck(context.Background(), fakeClient, nil, "", apiCheck, "", "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(source).To(Equal("Secret team/checkly-credentials"))
+
+			Expect(fakeClient.Delete(context.Background(), namespaceSecret)).To(Succeed())
+
+			By("Expecting checks without an ID to fall back to the operator credentials")
+			_, source, err = apiClientForCheck(context.Background(), fakeClient, nil, "", apiCheck, "", "Secret team/checkly-credentials")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(source).To(BeEmpty())
+
+			By("Expecting checks synced with the operator credentials to keep them")
+			_, _, err = apiClientForCheck(context.Background(), fakeClient, nil, "", apiCheck, "abc", "")
+			Expect(err).ToNot(HaveOccurred())
+
+			By("Expecting an error for checks synced with the removed namespace credentials")
+			_, _, err = apiClientForCheck(context.Background(), fakeClient, nil, "", apiCheck, "abc", "Secret team/checkly-credentials")
+			Expect(err).To(MatchError("check abc was synced with the credentials of Secret team/checkly-credentials which doesn't exist anymore, restore it or set an account"))
+			reason, _ := syncFailure(err)
+			Expect(reason).To(Equal(reasonCredentialsMissing))
+		})
+	})
+})
@@ -0,0 +1,254 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+	errs "errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/checkly/checkly-go-sdk"
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+	external "github.com/checkly/checkly-operator/external/checkly"
+)
+
+// CheckTriggerReconciler reconciles a CheckTrigger object
+type CheckTriggerReconciler struct {
+	client.Client
+	Scheme           *runtime.Scheme
+	ApiClient        checkly.Client
+	ControllerDomain string
+	ResourceSelector labels.Selector
+	Recorder         record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=checktriggers,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=checktriggers/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=checktriggers/finalizers,verbs=update
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=apichecks;browserchecks;multistepchecks,verbs=get;list
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=groups,verbs=get;list
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.0/pkg/reconcile
+func (r *CheckTriggerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	checkTriggerFinalizer := fmt.Sprintf("%s/finalizer", r.ControllerDomain)
+	logger.V(1).Info("Reconciler started")
+
+	checkTrigger := &checklyv1alpha1.CheckTrigger{}
+
+	// ////////////////////////////////
+	// Delete Logic
+	// ///////////////////////////////
+	err := r.Get(ctx, req.NamespacedName, checkTrigger)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// The resource has been deleted
+			logger.V(1).Info("Deleted", "name", checkTrigger.Name)
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object
+		logger.Error(err, "can't read the object")
+		return ctrl.Result{}, nil
+	}
+
+	// Writes skipped by --dry-run and --observe are recorded as Drift events of the trigger
+	apiClient := external.DryRunClientFor(r.ApiClient, checkTrigger)
+
+	if checkTrigger.GetDeletionTimestamp() != nil {
+		if controllerutil.ContainsFinalizer(checkTrigger, checkTriggerFinalizer) {
+			if checkTrigger.Spec.DeletionPolicy == checklyv1alpha1.DeletionPolicyRetain {
+				logger.Info("Deletion policy is Retain, leaving the checkly trigger in place", "url", checkTrigger.Status.URL)
+			} else {
+				logger.V(1).Info("Finalizer is present, trying to delete Checkly trigger", "url", checkTrigger.Status.URL)
+				err := r.deleteTrigger(apiClient, checkTrigger)
+				if err != nil {
+					recordSyncFailed(r.Recorder, checkTrigger, "delete", "trigger", err)
+					logger.Error(err, "Failed to delete checkly trigger")
+					forced, retry, err := forceDelete(ctx, r.Recorder, r.ControllerDomain, checkTrigger, "trigger", checkTrigger.Status.URL, err)
+					if !forced {
+						return retry, err
+					}
+				} else {
+					logger.Info("Successfully deleted checkly trigger", "url", checkTrigger.Status.URL)
+				}
+			}
+
+			controllerutil.RemoveFinalizer(checkTrigger, checkTriggerFinalizer)
+			err = r.Update(ctx, checkTrigger)
+			if err != nil {
+				logger.Error(err, "Failed to delete finalizer")
+				return ctrl.Result{}, err
+			}
+			logger.V(1).Info("Successfully deleted finalizer")
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Object found, let's do something with it. It's either updated, or it's new.
+	logger.V(1).Info("Object found", "name", checkTrigger.Name)
+
+	// /////////////////////////////
+	// Finalizer logic
+	// ////////////////////////////
+	if !controllerutil.ContainsFinalizer(checkTrigger, checkTriggerFinalizer) {
+		controllerutil.AddFinalizer(checkTrigger, checkTriggerFinalizer)
+		err = r.Update(ctx, checkTrigger)
+		if err != nil {
+			logger.Error(err, "Failed to update CheckTrigger status")
+			return ctrl.Result{}, err
+		}
+		logger.V(1).Info("Added finalizer", "url", checkTrigger.Status.URL)
+		return ctrl.Result{}, nil
+	}
+
+	// /////////////////////////////
+	// Lookup check or group ID
+	// ////////////////////////////
+	var checkID string
+	var groupID int64
+	switch {
+	case checkTrigger.Spec.Group != "":
+		group := &checklyv1alpha1.Group{}
+		err = r.Get(ctx, types.NamespacedName{Name: checkTrigger.Spec.Group}, group)
+		if err != nil {
+			logger.Error(err, "can't read the group object", "name", checkTrigger.Spec.Group)
+			return ctrl.Result{}, err
+		}
+		groupID = group.Status.ID
+	case checkTrigger.Spec.Check != "":
+		checkID, err = r.lookupCheckID(ctx, checkTrigger)
+		if err != nil {
+			logger.Error(err, "can't read the check object", "name", checkTrigger.Spec.Check, "kind", checkTrigger.Spec.CheckKind)
+			return ctrl.Result{}, err
+		}
+	default:
+		specErr := errs.New("check or group is empty")
+		logger.Error(specErr, "Please reference a check or a group")
+		return ctrl.Result{}, specErr
+	}
+
+	if checkID == "" && groupID == 0 {
+		logger.V(1).Info("Check or group ID has not been populated, we're too quick, requeining for retry")
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	// /////////////////////////////
+	// Update logic
+	// ////////////////////////////
+
+	// Triggers can't be updated, if the referenced check or group changed we replace the trigger
+	if checkTrigger.Status.URL != "" {
+		if checkTrigger.Status.CheckID == checkID && checkTrigger.Status.GroupID == groupID {
+			logger.V(1).Info("Trigger is up to date", "url", checkTrigger.Status.URL)
+			return ctrl.Result{}, nil
+		}
+
+		logger.V(1).Info("Referenced check or group changed, deleting old trigger", "url", checkTrigger.Status.URL)
+		err = r.deleteTrigger(apiClient, checkTrigger)
+		if err != nil {
+			recordSyncFailed(r.Recorder, checkTrigger, "delete", "trigger", err)
+			logger.Error(err, "Failed to delete checkly trigger")
+			return ctrl.Result{}, err
+		}
+	}
+
+	// /////////////////////////////
+	// Create logic
+	// ////////////////////////////
+	var URL string
+	if groupID != 0 {
+		URL, err = external.CreateGroupTrigger(groupID, apiClient)
+	} else {
+		URL, err = external.CreateCheckTrigger(checkID, apiClient)
+	}
+	if err != nil {
+		recordSyncFailed(r.Recorder, checkTrigger, "create", "trigger", err)
+		logger.Error(err, "Failed to create checkly trigger")
+		return ctrl.Result{}, err
+	}
+	recordCreated(r.Recorder, apiClient, checkTrigger, "trigger", URL)
+
+	// Update the custom resource Status with the returned URL
+	checkTrigger.Status.CheckID = checkID
+	checkTrigger.Status.GroupID = groupID
+	checkTrigger.Status.URL = URL
+	err = r.Status().Update(ctx, checkTrigger)
+	if err != nil {
+		logger.Error(err, "Failed to update CheckTrigger status")
+		return ctrl.Result{}, err
+	}
+	logger.V(1).Info("New checkly trigger created with", "url", checkTrigger.Status.URL)
+
+	return ctrl.Result{}, nil
+}
+
+// lookupCheckID returns the checklyhq.com ID of the check referenced by the CheckTrigger
+func (r *CheckTriggerReconciler) lookupCheckID(ctx context.Context, checkTrigger *checklyv1alpha1.CheckTrigger) (ID string, err error) {
+	key := types.NamespacedName{Name: checkTrigger.Spec.Check, Namespace: checkTrigger.Namespace}
+
+	switch checkTrigger.Spec.CheckKind {
+	case "BrowserCheck":
+		check := &checklyv1alpha1.BrowserCheck{}
+		err = r.Get(ctx, key, check)
+		ID = check.Status.ID
+	case "MultiStepCheck":
+		check := &checklyv1alpha1.MultiStepCheck{}
+		err = r.Get(ctx, key, check)
+		ID = check.Status.ID
+	default:
+		check := &checklyv1alpha1.ApiCheck{}
+		err = r.Get(ctx, key, check)
+		ID = check.Status.ID
+	}
+
+	return
+}
+
+// deleteTrigger deletes the checklyhq.com trigger recorded in the CheckTrigger status
+func (r *CheckTriggerReconciler) deleteTrigger(apiClient checkly.Client, checkTrigger *checklyv1alpha1.CheckTrigger) (err error) {
+	switch {
+	case checkTrigger.Status.GroupID != 0:
+		err = external.DeleteGroupTrigger(checkTrigger.Status.GroupID, apiClient)
+	case checkTrigger.Status.CheckID != "":
+		err = external.DeleteCheckTrigger(checkTrigger.Status.CheckID, apiClient)
+	}
+
+	return
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *CheckTriggerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&checklyv1alpha1.CheckTrigger{}, builder.WithPredicates(ResourceSelectorPredicate(r.ResourceSelector))).
+		Complete(rateLimitRequeue{r})
+}
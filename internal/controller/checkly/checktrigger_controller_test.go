@@ -0,0 +1,134 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("CheckTrigger Controller", func() {
+
+	// Define utility constants for object names and testing timeouts/durations and intervals.
+	const (
+		timeout  = time.Second * 10
+		duration = time.Second * 10
+		interval = time.Millisecond * 250
+	)
+
+	BeforeEach(func() {
+		// Add any setup steps that needs to be executed before each test
+	})
+
+	AfterEach(func() {
+		// Add any teardown steps that needs to be executed after each test
+	})
+
+	Context("CheckTrigger", func() {
+		It("Full reconciliation", func() {
+
+			key := types.NamespacedName{
+				Name:      "test-checktrigger",
+				Namespace: "default",
+			}
+
+			groupKey := types.NamespacedName{
+				Name: "test-checktrigger-group",
+			}
+
+			group := &checklyv1alpha1.Group{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: groupKey.Name,
+				},
+			}
+
+			checkTrigger := &checklyv1alpha1.CheckTrigger{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: checklyv1alpha1.CheckTriggerSpec{
+					Group: groupKey.Name,
+				},
+			}
+
+			// Create
+			Expect(k8sClient.Create(context.Background(), group)).Should(Succeed())
+			Expect(k8sClient.Create(context.Background(), checkTrigger)).Should(Succeed())
+
+			By("Expecting submitted")
+			Eventually(func() bool {
+				f := &checklyv1alpha1.CheckTrigger{}
+				err := k8sClient.Get(context.Background(), key, f)
+				if err != nil {
+					return false
+				}
+				return true
+			}, timeout, interval).Should(BeTrue())
+
+			// Status.URL should be present
+			By("Expecting trigger URL")
+			Eventually(func() bool {
+				f := &checklyv1alpha1.CheckTrigger{}
+				err := k8sClient.Get(context.Background(), key, f)
+				if f.Status.URL == "http://localhost:5555/check-groups/1/trigger/trigger" && f.Status.GroupID == 1 && err == nil {
+					return true
+				}
+				return false
+			}, timeout, interval).Should(BeTrue())
+
+			// Finalizer should be present
+			By("Expecting finalizer")
+			Eventually(func() bool {
+				f := &checklyv1alpha1.CheckTrigger{}
+				err := k8sClient.Get(context.Background(), key, f)
+				if err != nil {
+					return false
+				}
+
+				for _, finalizer := range f.Finalizers {
+					Expect(finalizer).To(Equal("testing.domain.tld/finalizer"), "Finalizer should match")
+				}
+
+				return true
+			}, timeout, interval).Should(BeTrue())
+
+			// Delete
+			By("Expecting to delete successfully")
+			Eventually(func() error {
+				f := &checklyv1alpha1.CheckTrigger{}
+				k8sClient.Get(context.Background(), key, f)
+				return k8sClient.Delete(context.Background(), f)
+			}, timeout, interval).Should(Succeed())
+
+			By("Expecting delete to finish")
+			Eventually(func() error {
+				f := &checklyv1alpha1.CheckTrigger{}
+				return k8sClient.Get(context.Background(), key, f)
+			}, timeout, interval).ShouldNot(Succeed())
+
+			Expect(k8sClient.Delete(context.Background(), group)).Should(Succeed())
+		})
+	})
+})
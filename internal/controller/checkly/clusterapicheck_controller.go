@@ -0,0 +1,125 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/checkly/checkly-go-sdk"
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+// ClusterApiCheckReconciler reconciles a ClusterApiCheck object
+type ClusterApiCheckReconciler struct {
+	client.Client
+	Scheme           *runtime.Scheme
+	ApiClient        checkly.Client
+	ControllerDomain string
+	ResourceSelector labels.Selector
+	Recorder         record.EventRecorder
+	ResyncInterval   time.Duration
+}
+
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=clusterapichecks,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=clusterapichecks/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=clusterapichecks/finalizers,verbs=update
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=groups,verbs=get;list
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.0/pkg/reconcile
+func (r *ClusterApiCheckReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	logger.V(1).Info("Reconciler started")
+
+	clusterApiCheck := &checklyv1alpha1.ClusterApiCheck{}
+
+	// ////////////////////////////////
+	// Delete Logic
+	// ///////////////////////////////
+	err := r.Get(ctx, req.NamespacedName, clusterApiCheck)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// The resource has been deleted
+			logger.V(1).Info("Deleted", "checkly ID", clusterApiCheck.Status.ID, "name", req.Name)
+			forgetFailures(clusterApiCheck, req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object
+		logger.Error(err, "can't read the object")
+		return ctrl.Result{}, nil
+	}
+
+	return reconcileApiCheck(ctx, r.Client, r.ApiClient, r.Recorder, r.ControllerDomain, r.ResyncInterval, clusterApiCheck, &clusterApiCheck.Spec, &clusterApiCheck.Status)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterApiCheckReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := mgr.GetFieldIndexer().IndexField(context.Background(), &checklyv1alpha1.ClusterApiCheck{}, apiCheckGroupField, func(rawObj client.Object) []string {
+		clusterApiCheck := rawObj.(*checklyv1alpha1.ClusterApiCheck)
+		return []string{clusterApiCheck.Spec.Group}
+	})
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&checklyv1alpha1.ClusterApiCheck{}, builder.WithPredicates(ResourceSelectorPredicate(r.ResourceSelector))).
+		Watches(
+			&checklyv1alpha1.Group{},
+			handler.EnqueueRequestsFromMapFunc(r.findClusterApiChecksForGroup),
+		).
+		Complete(rateLimitRequeue{r})
+}
+
+// findClusterApiChecksForGroup returns a reconcile request for every ClusterApiCheck in the Group
+func (r *ClusterApiCheckReconciler) findClusterApiChecksForGroup(ctx context.Context, group client.Object) []reconcile.Request {
+	clusterApiChecks := &checklyv1alpha1.ClusterApiCheckList{}
+	listOps := &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(apiCheckGroupField, group.GetName()),
+	}
+	err := r.List(ctx, clusterApiChecks, listOps)
+	if err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, len(clusterApiChecks.Items))
+	for i, item := range clusterApiChecks.Items {
+		requests[i] = reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name: item.GetName(),
+			},
+		}
+	}
+	return requests
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+	external "github.com/checkly/checkly-operator/external/checkly"
+)
+
+// The reasons of the Ready, Synced and Degraded conditions
+const (
+	reasonSynced             = "Synced"
+	reasonSyncFailed         = "SyncFailed"
+	reasonInvalidSpec        = "InvalidSpec"
+	reasonAccountMismatch    = "AccountMismatch"
+	reasonCredentialsMissing = "CredentialsMissing"
+	reasonWaiting            = "WaitingForDependencies"
+	reasonNotCreated         = "NotCreated"
+	reasonAsExpected         = "AsExpected"
+	messageWaiting           = "Waiting for the referenced resources to be created in checklyhq.com"
+	messageNotCreated        = "The resource wasn't created in checklyhq.com, the operator runs in dry-run mode"
+)
+
+// degradation is the reason and message of a True Degraded condition, the zero value means the
+// resource isn't degraded
+type degradation struct {
+	reason  string
+	message string
+}
+
+// setConditions sets the Ready, Synced and Degraded conditions from the outcome of a reconcile,
+// created tells whether the resource has a checklyhq.com ID. It returns whether the conditions changed
+func setConditions(conditions *[]metav1.Condition, generation int64, created bool, result ctrl.Result, err error, degraded degradation) (changed bool) {
+	synced := metav1.Condition{
+		Type:               checklyv1alpha1.ConditionSynced,
+		Status:             metav1.ConditionTrue,
+		Reason:             reasonSynced,
+		Message:            "The spec is applied to checklyhq.com",
+		ObservedGeneration: generation,
+	}
+	switch {
+	case err != nil:
+		reason, message := syncFailure(err)
+		synced.Status, synced.Reason, synced.Message = metav1.ConditionFalse, reason, message
+	// RequeueAfter is only used to resync resources which are synced already
+	case result.Requeue:
+		synced.Status, synced.Reason, synced.Message = metav1.ConditionFalse, reasonWaiting, messageWaiting
+	}
+
+	ready := synced
+	ready.Type = checklyv1alpha1.ConditionReady
+	if synced.Status == metav1.ConditionTrue {
+		ready.Message = "The resource exists in checklyhq.com as specified"
+	}
+	if synced.Status == metav1.ConditionTrue && !created {
+		ready.Status, ready.Reason, ready.Message = metav1.ConditionFalse, reasonNotCreated, messageNotCreated
+	}
+
+	degradedCondition := metav1.Condition{
+		Type:               checklyv1alpha1.ConditionDegraded,
+		Status:             metav1.ConditionFalse,
+		Reason:             reasonAsExpected,
+		ObservedGeneration: generation,
+	}
+	if degraded.reason != "" {
+		degradedCondition.Status, degradedCondition.Reason, degradedCondition.Message = metav1.ConditionTrue, degraded.reason, degraded.message
+	}
+
+	for _, condition := range []metav1.Condition{ready, synced, degradedCondition} {
+		if meta.SetStatusCondition(conditions, condition) {
+			changed = true
+		}
+	}
+
+	return
+}
+
+// updateConditions sets the conditions of a reconcile which failed before their update is deferred, ex. on
+// the account lookup, and updates the status of the resource if they changed
+func updateConditions(ctx context.Context, c client.Client, obj client.Object, conditions *[]metav1.Condition, created bool, err error, degraded degradation) {
+	if !setConditions(conditions, obj.GetGeneration(), created, ctrl.Result{}, err, degraded) {
+		return
+	}
+
+	statusErr := c.Status().Update(ctx, obj)
+	if statusErr != nil {
+		log.FromContext(ctx).Error(statusErr, "Failed to update the conditions")
+	}
+}
+
+// syncFailure returns the reason and message of a failed sync, the checklyhq.com API errors are
+// reduced to their message and the fields checklyhq.com rejected
+func syncFailure(err error) (reason, message string) {
+	var mismatchErr *accountMismatchError
+	if errors.As(err, &mismatchErr) {
+		return reasonAccountMismatch, mismatchErr.Error()
+	}
+	var credentialsErr *credentialsMissingError
+	if errors.As(err, &credentialsErr) {
+		return reasonCredentialsMissing, credentialsErr.Error()
+	}
+
+	apiErr, ok := external.AsAPIError(err)
+	if !ok {
+		return reasonSyncFailed, err.Error()
+	}
+	if apiErr.IsValidation() {
+		return reasonInvalidSpec, apiErr.Error()
+	}
+
+	return reasonSyncFailed, apiErr.Error()
+}
+
+// setApplied records the generation and the hash of the spec once the resource is Ready, so it's
+// visible whether the latest spec is applied to checklyhq.com. It returns whether they changed
+func setApplied(conditions []metav1.Condition, generation int64, spec interface{}, observedGeneration *int64, specHash *string) (changed bool) {
+	if !meta.IsStatusConditionTrue(conditions, checklyv1alpha1.ConditionReady) {
+		return false
+	}
+
+	hash := hashSpec(spec)
+	changed = *observedGeneration != generation || *specHash != hash
+	*observedGeneration = generation
+	*specHash = hash
+
+	return
+}
+
+// hashSpec returns the hex encoded SHA-256 of the JSON encoded spec
+func hashSpec(spec interface{}) string {
+	data, _ := json.Marshal(spec)
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+var _ = Describe("Conditions", func() {
+
+	Context("setConditions", func() {
+		It("Sets the conditions from the reconcile outcome", func() {
+
+			var conditions []metav1.Condition
+
+			By("Expecting Ready and Synced after a successful sync")
+			Expect(setConditions(&conditions, 1, true, ctrl.Result{}, nil, degradation{})).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(conditions, checklyv1alpha1.ConditionReady)).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(conditions, checklyv1alpha1.ConditionSynced)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(conditions, checklyv1alpha1.ConditionDegraded)).To(BeTrue())
+			Expect(meta.FindStatusCondition(conditions, checklyv1alpha1.ConditionReady).ObservedGeneration).To(Equal(int64(1)))
+
+			By("Expecting no change when the outcome is the same")
+			Expect(setConditions(&conditions, 1, true, ctrl.Result{}, nil, degradation{})).To(BeFalse())
+
+			By("Expecting SyncFailed on errors")
+			Expect(setConditions(&conditions, 2, true, ctrl.Result{}, errors.New("boom"), degradation{})).To(BeTrue())
+			synced := meta.FindStatusCondition(conditions, checklyv1alpha1.ConditionSynced)
+			Expect(synced.Status).To(Equal(metav1.ConditionFalse))
+			Expect(synced.Reason).To(Equal(reasonSyncFailed))
+			Expect(synced.Message).To(Equal("boom"))
+			Expect(meta.IsStatusConditionFalse(conditions, checklyv1alpha1.ConditionReady)).To(BeTrue())
+
+			By("Expecting InvalidSpec with the fields checklyhq.com rejected")
+			validationErr := fmt.Errorf("unexpected response status %d: %q", 400, `{"message":"\"frequency\" must be one of [1, 2]","validation":{"keys":["frequency"]}}`)
+			setConditions(&conditions, 2, true, ctrl.Result{}, validationErr, degradation{})
+			synced = meta.FindStatusCondition(conditions, checklyv1alpha1.ConditionSynced)
+			Expect(synced.Reason).To(Equal(reasonInvalidSpec))
+			Expect(synced.Message).To(Equal(`checklyhq.com rejected frequency: "frequency" must be one of [1, 2]`))
+
+			By("Expecting WaitingForDependencies on requeues")
+			setConditions(&conditions, 2, false, ctrl.Result{Requeue: true}, nil, degradation{})
+			Expect(meta.FindStatusCondition(conditions, checklyv1alpha1.ConditionSynced).Reason).To(Equal(reasonWaiting))
+
+			By("Expecting Synced when the resource is resynced later")
+			setConditions(&conditions, 2, true, ctrl.Result{RequeueAfter: time.Minute}, nil, degradation{})
+			Expect(meta.IsStatusConditionTrue(conditions, checklyv1alpha1.ConditionSynced)).To(BeTrue())
+
+			By("Expecting NotCreated without a checklyhq.com ID")
+			setConditions(&conditions, 2, false, ctrl.Result{}, nil, degradation{})
+			Expect(meta.IsStatusConditionTrue(conditions, checklyv1alpha1.ConditionSynced)).To(BeTrue())
+			Expect(meta.FindStatusCondition(conditions, checklyv1alpha1.ConditionReady).Reason).To(Equal(reasonNotCreated))
+
+			By("Expecting Degraded with a degradation")
+			setConditions(&conditions, 2, true, ctrl.Result{}, nil, checkDegradation(false, true))
+			Expect(meta.IsStatusConditionTrue(conditions, checklyv1alpha1.ConditionDegraded)).To(BeTrue())
+			Expect(meta.FindStatusCondition(conditions, checklyv1alpha1.ConditionDegraded).Reason).To(Equal("Muted"))
+			Expect(meta.IsStatusConditionTrue(conditions, checklyv1alpha1.ConditionReady)).To(BeTrue())
+		})
+	})
+
+	Context("setApplied", func() {
+		It("Records the applied generation and spec hash once Ready", func() {
+
+			var conditions []metav1.Condition
+			var observedGeneration int64
+			var specHash string
+			spec := checklyv1alpha1.ApiCheckSpec{Endpoint: "https://foo.bar"}
+
+			By("Expecting nothing before the resource is Ready")
+			setConditions(&conditions, 1, false, ctrl.Result{}, nil, degradation{})
+			Expect(setApplied(conditions, 1, spec, &observedGeneration, &specHash)).To(BeFalse())
+			Expect(observedGeneration).To(BeZero())
+			Expect(specHash).To(BeEmpty())
+
+			By("Expecting the generation and hash once Ready")
+			setConditions(&conditions, 1, true, ctrl.Result{}, nil, degradation{})
+			Expect(setApplied(conditions, 1, spec, &observedGeneration, &specHash)).To(BeTrue())
+			Expect(observedGeneration).To(Equal(int64(1)))
+			Expect(specHash).To(Equal(hashSpec(spec)))
+			Expect(setApplied(conditions, 1, spec, &observedGeneration, &specHash)).To(BeFalse())
+
+			By("Expecting the previous values while a new generation fails")
+			spec.Endpoint = "https://foo.baz"
+			setConditions(&conditions, 2, true, ctrl.Result{}, errors.New("boom"), degradation{})
+			Expect(setApplied(conditions, 2, spec, &observedGeneration, &specHash)).To(BeFalse())
+			Expect(observedGeneration).To(Equal(int64(1)))
+			Expect(specHash).ToNot(Equal(hashSpec(spec)))
+		})
+	})
+})
@@ -0,0 +1,69 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	external "github.com/checkly/checkly-operator/external/checkly"
+)
+
+// CredentialsReconciler rotates the credentials of the default checkly client when the
+// --credentials-secret changes, so the operator doesn't have to be restarted
+type CredentialsReconciler struct {
+	client.Client
+	Secret      types.NamespacedName
+	Credentials *external.Credentials
+}
+
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+// Reconcile reloads the credentials from the Secret, the current credentials are kept
+// when the Secret is deleted or incomplete
+func (r *CredentialsReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	credentials, err := NewCredentialsFromSecret(ctx, r.Client, r.Secret)
+	if err != nil {
+		logger.Error(err, "Failed to reload the checklyhq.com credentials, keeping the current ones")
+		return ctrl.Result{}, nil
+	}
+
+	if r.Credentials.Set(credentials.Get()) {
+		logger.Info("Reloaded the checklyhq.com credentials")
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *CredentialsReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("credentials").
+		For(&corev1.Secret{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			return obj.GetNamespace() == r.Secret.Namespace && obj.GetName() == r.Secret.Name
+		}))).
+		Complete(r)
+}
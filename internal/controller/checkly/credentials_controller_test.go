@@ -0,0 +1,81 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+var _ = Describe("Credentials", func() {
+
+	Context("Secret rotation", func() {
+		It("Reloads the credentials", func() {
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-credentials-secret",
+					Namespace: "default",
+				},
+				Data: map[string][]byte{
+					"CHECKLY_API_KEY":    []byte("foobarbaz"),
+					"CHECKLY_ACCOUNT_ID": []byte("1234567890"),
+				},
+			}
+			key := types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}
+
+			Expect(k8sClient.Create(context.Background(), secret)).Should(Succeed())
+
+			credentials, err := NewCredentialsFromSecret(context.Background(), k8sClient, key)
+			Expect(err).ToNot(HaveOccurred())
+
+			reconciler := &CredentialsReconciler{
+				Client:      k8sClient,
+				Secret:      key,
+				Credentials: credentials,
+			}
+
+			By("Expecting the rotated credentials")
+			secret.Data["CHECKLY_API_KEY"] = []byte("rotated")
+			Expect(k8sClient.Update(context.Background(), secret)).Should(Succeed())
+			_, err = reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+			Expect(err).ToNot(HaveOccurred())
+			apiKey, accountID := credentials.Get()
+			Expect(apiKey).To(Equal("rotated"))
+			Expect(accountID).To(Equal("1234567890"))
+
+			By("Expecting the current credentials to be kept for incomplete secrets")
+			secret.Data = map[string][]byte{
+				"CHECKLY_API_KEY": []byte("foobarbaz"),
+			}
+			Expect(k8sClient.Update(context.Background(), secret)).Should(Succeed())
+			_, err = reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+			Expect(err).ToNot(HaveOccurred())
+			apiKey, _ = credentials.Get()
+			Expect(apiKey).To(Equal("rotated"))
+
+			Expect(k8sClient.Delete(context.Background(), secret)).Should(Succeed())
+		})
+	})
+})
@@ -0,0 +1,182 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/checkly/checkly-go-sdk"
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+	external "github.com/checkly/checkly-operator/external/checkly"
+)
+
+// DashboardReconciler reconciles a Dashboard object
+type DashboardReconciler struct {
+	client.Client
+	Scheme           *runtime.Scheme
+	ApiClient        checkly.Client
+	ControllerDomain string
+	ResourceSelector labels.Selector
+	Recorder         record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=dashboards,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=dashboards/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=dashboards/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.12.1/pkg/reconcile
+func (r *DashboardReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	dashboardFinalizer := fmt.Sprintf("%s/finalizer", r.ControllerDomain)
+
+	dashboard := &checklyv1alpha1.Dashboard{}
+
+	err := r.Get(ctx, req.NamespacedName, dashboard)
+
+	// ////////////////////////////////
+	// Delete Logic
+	// ///////////////////////////////
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// The resource has been deleted
+			logger.V(1).Info("Deleted", "checkly Dashboard ID", dashboard.Status.ID)
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object
+		logger.Error(err, "can't read the object")
+		return ctrl.Result{}, nil
+	}
+
+	// Writes skipped by --dry-run and --observe are recorded as Drift events of the dashboard
+	apiClient := external.DryRunClientFor(r.ApiClient, dashboard)
+
+	// ////////////////////////////////
+	// Remove Finalizer Logic
+	// ///////////////////////////////
+
+	if dashboard.GetDeletionTimestamp() != nil {
+		if controllerutil.ContainsFinalizer(dashboard, dashboardFinalizer) {
+			if dashboard.Spec.DeletionPolicy == checklyv1alpha1.DeletionPolicyRetain {
+				logger.Info("Deletion policy is Retain, leaving the checkly Dashboard in place", "ID", dashboard.Status.ID)
+			} else if dashboard.Status.ID == "" {
+				logger.Info("Checkly Dashboard was never created, nothing to delete")
+			} else {
+				logger.V(1).Info("Finalizer is present, trying to delete Checkly Dashboard", "ID", dashboard.Status.ID)
+				err := external.DeleteDashboard(dashboard, apiClient)
+				if external.IsNotFound(err) {
+					logger.Info("Checkly Dashboard was already deleted", "ID", dashboard.Status.ID)
+				} else if err != nil {
+					recordSyncFailed(r.Recorder, dashboard, "delete", "dashboard", err)
+					logger.Error(err, "Failed to delete checkly Dashboard")
+					forced, retry, err := forceDelete(ctx, r.Recorder, r.ControllerDomain, dashboard, "dashboard", dashboard.Status.ID, err)
+					if !forced {
+						return retry, err
+					}
+				} else {
+					logger.V(1).Info("Successfully deleted checkly Dashboard", "ID", dashboard.Status.ID)
+				}
+			}
+
+			controllerutil.RemoveFinalizer(dashboard, dashboardFinalizer)
+			err = r.Update(ctx, dashboard)
+			if err != nil {
+				logger.Error(err, "Failed to delete finalizer.")
+				return ctrl.Result{}, err
+			}
+			logger.V(1).Info("Successfully deleted finalizer from Dashboard")
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// /////////////////////////////
+	// Add Finalizer logic
+	// ////////////////////////////
+	if !controllerutil.ContainsFinalizer(dashboard, dashboardFinalizer) {
+		controllerutil.AddFinalizer(dashboard, dashboardFinalizer)
+		err = r.Update(ctx, dashboard)
+		if err != nil {
+			logger.Error(err, "Failed to update Dashboard status")
+			return ctrl.Result{}, err
+		}
+		logger.V(1).Info("Added finalizer", "checkly Dashboard ID", dashboard.Status.ID)
+		return ctrl.Result{}, nil
+	}
+
+	// /////////////////////////////
+	// Update logic
+	// ////////////////////////////
+
+	// Determine if it's a new object or if it's an update to an existing object
+	if dashboard.Status.ID != "" {
+		// Existing object, we need to update it
+		logger.V(1).Info("Existing object, with ID", "checkly Dashboard ID", dashboard.Status.ID)
+		err := external.UpdateDashboard(dashboard, apiClient)
+		if err != nil {
+			recordSyncFailed(r.Recorder, dashboard, "update", "dashboard", err)
+			logger.Error(err, "Failed to update checkly Dashboard")
+			return ctrl.Result{}, err
+		}
+		recordUpdated(r.Recorder, apiClient, dashboard, "dashboard", dashboard.Status.ID)
+		logger.V(1).Info("Updated checkly Dashboard", "ID", dashboard.Status.ID)
+		return ctrl.Result{}, nil
+	}
+
+	// /////////////////////////////
+	// Create logic
+	// ////////////////////////////
+	dashboardID, err := external.CreateDashboard(dashboard, apiClient)
+	if err != nil {
+		recordSyncFailed(r.Recorder, dashboard, "create", "dashboard", err)
+		logger.Error(err, "Failed to create checkly Dashboard")
+		return ctrl.Result{}, err
+	}
+	recordCreated(r.Recorder, apiClient, dashboard, "dashboard", dashboardID)
+
+	// Update the custom resource Status with the returned ID
+	dashboard.Status.ID = dashboardID
+	err = r.Status().Update(ctx, dashboard)
+	if err != nil {
+		logger.Error(err, "Failed to update Dashboard status", "ID", dashboard.Status.ID)
+		return ctrl.Result{}, err
+	}
+	logger.V(1).Info("New checkly Dashboard created", "ID", dashboard.Status.ID)
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DashboardReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&checklyv1alpha1.Dashboard{}, builder.WithPredicates(ResourceSelectorPredicate(r.ResourceSelector))).
+		Complete(rateLimitRequeue{r})
+}
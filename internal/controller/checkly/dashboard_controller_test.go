@@ -0,0 +1,170 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/checkly/checkly-go-sdk"
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+	external "github.com/checkly/checkly-operator/external/checkly"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Dashboard Controller", func() {
+
+	// Define utility constants for object names and testing timeouts/durations and intervals.
+	const (
+		timeout  = time.Second * 10
+		duration = time.Second * 10
+		interval = time.Millisecond * 250
+	)
+
+	BeforeEach(func() {
+		// Add any setup steps that needs to be executed before each test
+	})
+
+	AfterEach(func() {
+		// Add any teardown steps that needs to be executed after each test
+	})
+
+	Context("Dashboard", func() {
+		It("Full reconciliation", func() {
+
+			key := types.NamespacedName{
+				Name: "test-dashboard",
+			}
+
+			dashboard := &checklyv1alpha1.Dashboard{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: key.Name,
+				},
+				Spec: checklyv1alpha1.DashboardSpec{
+					CustomUrl: "test-dashboard",
+					Tags:      []string{"team:foo"},
+				},
+			}
+
+			// Create
+			Expect(k8sClient.Create(context.Background(), dashboard)).Should(Succeed())
+
+			By("Expecting submitted")
+			Eventually(func() bool {
+				f := &checklyv1alpha1.Dashboard{}
+				err := k8sClient.Get(context.Background(), key, f)
+				if err != nil {
+					return false
+				}
+				return true
+			}, timeout, interval).Should(BeTrue())
+
+			// Status.ID should be present
+			By("Expecting dashboard ID")
+			Eventually(func() bool {
+				f := &checklyv1alpha1.Dashboard{}
+				err := k8sClient.Get(context.Background(), key, f)
+				if f.Status.ID == "abc" && err == nil {
+					return true
+				}
+				return false
+			}, timeout, interval).Should(BeTrue())
+
+			// Finalizer should be present
+			By("Expecting finalizer")
+			Eventually(func() bool {
+				f := &checklyv1alpha1.Dashboard{}
+				err := k8sClient.Get(context.Background(), key, f)
+				if err != nil {
+					return false
+				}
+
+				for _, finalizer := range f.Finalizers {
+					Expect(finalizer).To(Equal("testing.domain.tld/finalizer"), "Finalizer should match")
+				}
+
+				return true
+			}, timeout, interval).Should(BeTrue())
+
+			// Update
+			updated := &checklyv1alpha1.Dashboard{}
+			Expect(k8sClient.Get(context.Background(), key, updated)).Should(Succeed())
+
+			updated.Spec.Header = "Updated header"
+			Expect(k8sClient.Update(context.Background(), updated)).Should(Succeed())
+
+			By("Expecting update")
+			Eventually(func() bool {
+				f := &checklyv1alpha1.Dashboard{}
+				err := k8sClient.Get(context.Background(), key, f)
+				if f.Spec.Header == "Updated header" && err == nil {
+					return true
+				}
+				return false
+			}, timeout, interval).Should(BeTrue())
+
+			// Delete
+			By("Expecting to delete successfully")
+			Eventually(func() error {
+				f := &checklyv1alpha1.Dashboard{}
+				k8sClient.Get(context.Background(), key, f)
+				return k8sClient.Delete(context.Background(), f)
+			}, timeout, interval).Should(Succeed())
+
+			By("Expecting delete to finish")
+			Eventually(func() error {
+				f := &checklyv1alpha1.Dashboard{}
+				return k8sClient.Get(context.Background(), key, f)
+			}, timeout, interval).ShouldNot(Succeed())
+		})
+	})
+
+	Context("Observe", func() {
+		It("Records the drift on the dashboard", func() {
+
+			dashboard := &checklyv1alpha1.Dashboard{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-dashboard-observed",
+				},
+				Spec: checklyv1alpha1.DashboardSpec{
+					CustomUrl: "test-dashboard-observed",
+				},
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(dashboard).Build()
+			recorder := record.NewFakeRecorder(10)
+
+			r := &DashboardReconciler{
+				Client:           NewObserverClient(fakeClient, "testing.domain.tld"),
+				ApiClient:        &external.DryRunClient{Client: checkly.NewClient("http://localhost", "foobarbaz", nil, nil), Recorder: recorder},
+				Recorder:         recorder,
+				ControllerDomain: "testing.domain.tld",
+			}
+
+			_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: dashboard.Name}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recorder.Events).To(Receive(Equal("Warning Drift checklyhq.com dashboard doesn't exist, it would be created")))
+		})
+	})
+})
@@ -0,0 +1,64 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// eventDriftDetected is the reason of the events recorded when a resource was changed outside of the operator
+const eventDriftDetected = "DriftDetected"
+
+// driftDetected counts the resources found changed in checklyhq.com
+var driftDetected = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "checkly_operator_drift_detected_total",
+	Help: "Number of resources found changed outside of the operator in checklyhq.com by kind",
+}, []string{"kind"})
+
+func init() {
+	metrics.Registry.MustRegister(driftDetected)
+}
+
+// specApplied returns whether the spec is the one last applied to checklyhq.com, differences found
+// in checklyhq.com are changes made outside of the operator then
+func specApplied(generation int64, spec interface{}, observedGeneration int64, specHash string) bool {
+	return observedGeneration == generation && specHash == hashSpec(spec)
+}
+
+// payloadApplied returns whether the payload is the one last pushed to checklyhq.com, together with no drift
+// there's nothing to push. Payloads without a hash are always pushed
+func payloadApplied(payloadHash, appliedHash string) bool {
+	return payloadHash != "" && payloadHash == appliedHash
+}
+
+// recordDrift records the fields changed outside of the operator, which the update reverts
+func recordDrift(recorder record.EventRecorder, object runtime.Object, kind string, id interface{}, changes []string) {
+	if len(changes) == 0 {
+		return
+	}
+	driftDetected.WithLabelValues(kind).Inc()
+	if recorder == nil {
+		return
+	}
+	recorder.Eventf(object, corev1.EventTypeWarning, eventDriftDetected, "The %s %v was changed in checklyhq.com, reverting %s", kind, id, strings.Join(changes, ", "))
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+	"k8s.io/client-go/tools/record"
+)
+
+var _ = Describe("Drift", func() {
+
+	It("Records changes made outside of the operator", func() {
+
+		spec := checklyv1alpha1.GroupSpec{Locations: []string{"eu-west-1"}}
+		Expect(specApplied(2, spec, 2, hashSpec(spec))).To(BeTrue())
+		Expect(specApplied(3, spec, 2, hashSpec(spec))).To(BeFalse())
+		Expect(specApplied(2, checklyv1alpha1.GroupSpec{Locations: []string{"us-east-1"}}, 2, hashSpec(spec))).To(BeFalse())
+
+		recorder := record.NewFakeRecorder(10)
+		group := &checklyv1alpha1.Group{}
+
+		recordDrift(recorder, group, "group", 1, []string{`name: "bar" -> "foo"`})
+		Expect(<-recorder.Events).To(Equal(`Warning DriftDetected The group 1 was changed in checklyhq.com, reverting name: "bar" -> "foo"`))
+
+		By("Expecting no events without changes")
+		recordDrift(recorder, group, "group", 1, nil)
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	It("Skips payloads which were already pushed", func() {
+
+		Expect(payloadApplied("abc", "abc")).To(BeTrue())
+		Expect(payloadApplied("abc", "def")).To(BeFalse())
+		Expect(payloadApplied("abc", "")).To(BeFalse())
+
+		By("Expecting payloads without a hash to be pushed")
+		Expect(payloadApplied("", "")).To(BeFalse())
+	})
+})
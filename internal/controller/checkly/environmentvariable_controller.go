@@ -0,0 +1,229 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+	errs "errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/checkly/checkly-go-sdk"
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+	external "github.com/checkly/checkly-operator/external/checkly"
+)
+
+// EnvironmentVariableReconciler reconciles a EnvironmentVariable object
+type EnvironmentVariableReconciler struct {
+	client.Client
+	Scheme           *runtime.Scheme
+	ApiClient        checkly.Client
+	ControllerDomain string
+	ResourceSelector labels.Selector
+	Recorder         record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=environmentvariables,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=environmentvariables/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=environmentvariables/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.12.1/pkg/reconcile
+func (r *EnvironmentVariableReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	environmentVariableFinalizer := fmt.Sprintf("%s/finalizer", r.ControllerDomain)
+
+	environmentVariable := &checklyv1alpha1.EnvironmentVariable{}
+
+	err := r.Get(ctx, req.NamespacedName, environmentVariable)
+
+	// ////////////////////////////////
+	// Delete Logic
+	// ///////////////////////////////
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// The resource has been deleted
+			logger.V(1).Info("Deleted", "checkly EnvironmentVariable key", environmentVariable.Status.Key)
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object
+		logger.Error(err, "can't read the object")
+		return ctrl.Result{}, nil
+	}
+
+	// Writes skipped by --dry-run and --observe are recorded as Drift events of the environment variable
+	apiClient := external.DryRunClientFor(r.ApiClient, environmentVariable)
+
+	// ////////////////////////////////
+	// Remove Finalizer Logic
+	// ///////////////////////////////
+
+	if environmentVariable.GetDeletionTimestamp() != nil {
+		if controllerutil.ContainsFinalizer(environmentVariable, environmentVariableFinalizer) {
+			if environmentVariable.Spec.DeletionPolicy == checklyv1alpha1.DeletionPolicyRetain {
+				logger.Info("Deletion policy is Retain, leaving the checkly EnvironmentVariable in place", "key", environmentVariable.Status.Key)
+			} else if environmentVariable.Status.Key == "" {
+				logger.Info("Checkly EnvironmentVariable was never created, nothing to delete")
+			} else {
+				logger.V(1).Info("Finalizer is present, trying to delete Checkly EnvironmentVariable", "key", environmentVariable.Status.Key)
+				err := external.DeleteEnvironmentVariable(environmentVariable.Status.Key, apiClient)
+				if external.IsNotFound(err) {
+					logger.Info("Checkly EnvironmentVariable was already deleted", "key", environmentVariable.Status.Key)
+				} else if err != nil {
+					recordSyncFailed(r.Recorder, environmentVariable, "delete", "environment variable", err)
+					logger.Error(err, "Failed to delete checkly EnvironmentVariable")
+					forced, retry, err := forceDelete(ctx, r.Recorder, r.ControllerDomain, environmentVariable, "environment variable", environmentVariable.Status.Key, err)
+					if !forced {
+						return retry, err
+					}
+				} else {
+					logger.V(1).Info("Successfully deleted checkly EnvironmentVariable", "key", environmentVariable.Status.Key)
+				}
+			}
+
+			controllerutil.RemoveFinalizer(environmentVariable, environmentVariableFinalizer)
+			err = r.Update(ctx, environmentVariable)
+			if err != nil {
+				logger.Error(err, "Failed to delete finalizer.")
+				return ctrl.Result{}, err
+			}
+			logger.V(1).Info("Successfully deleted finalizer from EnvironmentVariable")
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// /////////////////////////////
+	// Add Finalizer logic
+	// ////////////////////////////
+	if !controllerutil.ContainsFinalizer(environmentVariable, environmentVariableFinalizer) {
+		controllerutil.AddFinalizer(environmentVariable, environmentVariableFinalizer)
+		err = r.Update(ctx, environmentVariable)
+		if err != nil {
+			logger.Error(err, "Failed to update EnvironmentVariable status")
+			return ctrl.Result{}, err
+		}
+		logger.V(1).Info("Added finalizer", "checkly EnvironmentVariable key", environmentVariable.Status.Key)
+		return ctrl.Result{}, nil
+	}
+
+	// /////////////////////////////
+	// Secret retrieval
+	// ////////////////////////////
+	value := environmentVariable.Spec.Value
+	if environmentVariable.Spec.SecretKeyRef != (corev1.ObjectReference{}) {
+		secret := &corev1.Secret{}
+		err := r.Get(ctx,
+			types.NamespacedName{
+				Name:      environmentVariable.Spec.SecretKeyRef.Name,
+				Namespace: environmentVariable.Spec.SecretKeyRef.Namespace},
+			secret)
+		if err != nil {
+			logger.Error(err, "Unable to read secret for value")
+			return ctrl.Result{}, err
+		}
+
+		value = string(secret.Data[environmentVariable.Spec.SecretKeyRef.FieldPath])
+		if value == "" {
+			secretErr := errs.New("secret value is empty")
+			logger.Error(secretErr, "Please add the value to the secret")
+			return ctrl.Result{}, secretErr
+		}
+	}
+
+	// /////////////////////////////
+	// Update logic
+	// ////////////////////////////
+
+	// The key is the identifier of the environment variable, if it changed we need to replace it
+	if environmentVariable.Status.Key != "" && environmentVariable.Status.Key != environmentVariable.Spec.Key {
+		logger.V(1).Info("Key changed, deleting old environment variable", "key", environmentVariable.Status.Key)
+		err := external.DeleteEnvironmentVariable(environmentVariable.Status.Key, apiClient)
+		if err != nil {
+			recordSyncFailed(r.Recorder, environmentVariable, "delete", "environment variable", err)
+			logger.Error(err, "Failed to delete checkly EnvironmentVariable")
+			return ctrl.Result{}, err
+		}
+
+		environmentVariable.Status.Key = ""
+		err = r.Status().Update(ctx, environmentVariable)
+		if err != nil {
+			logger.Error(err, "Failed to update EnvironmentVariable status")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	// Determine if it's a new object or if it's an update to an existing object
+	if environmentVariable.Status.Key != "" {
+		// Existing object, we need to update it
+		logger.V(1).Info("Existing object, with key", "checkly EnvironmentVariable key", environmentVariable.Status.Key)
+		err := external.UpdateEnvironmentVariable(environmentVariable, value, apiClient)
+		if err != nil {
+			recordSyncFailed(r.Recorder, environmentVariable, "update", "environment variable", err)
+			logger.Error(err, "Failed to update checkly EnvironmentVariable")
+			return ctrl.Result{}, err
+		}
+		recordUpdated(r.Recorder, apiClient, environmentVariable, "environment variable", environmentVariable.Status.Key)
+		logger.V(1).Info("Updated checkly EnvironmentVariable", "key", environmentVariable.Status.Key)
+		return ctrl.Result{}, nil
+	}
+
+	// /////////////////////////////
+	// Create logic
+	// ////////////////////////////
+	err = external.CreateEnvironmentVariable(environmentVariable, value, apiClient)
+	if err != nil {
+		recordSyncFailed(r.Recorder, environmentVariable, "create", "environment variable", err)
+		logger.Error(err, "Failed to create checkly EnvironmentVariable")
+		return ctrl.Result{}, err
+	}
+	recordCreated(r.Recorder, apiClient, environmentVariable, "environment variable", environmentVariable.Spec.Key)
+
+	// Update the custom resource Status with the created key
+	environmentVariable.Status.Key = environmentVariable.Spec.Key
+	err = r.Status().Update(ctx, environmentVariable)
+	if err != nil {
+		logger.Error(err, "Failed to update EnvironmentVariable status", "key", environmentVariable.Status.Key)
+		return ctrl.Result{}, err
+	}
+	logger.V(1).Info("New checkly EnvironmentVariable created", "key", environmentVariable.Status.Key)
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *EnvironmentVariableReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&checklyv1alpha1.EnvironmentVariable{}, builder.WithPredicates(ResourceSelectorPredicate(r.ResourceSelector))).
+		Complete(rateLimitRequeue{r})
+}
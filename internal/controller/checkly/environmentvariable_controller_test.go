@@ -0,0 +1,214 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/checkly/checkly-go-sdk"
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("EnvironmentVariable Controller", func() {
+
+	// Define utility constants for object names and testing timeouts/durations and intervals.
+	const (
+		timeout  = time.Second * 10
+		duration = time.Second * 10
+		interval = time.Millisecond * 250
+	)
+
+	BeforeEach(func() {
+		// Add any setup steps that needs to be executed before each test
+	})
+
+	AfterEach(func() {
+		// Add any teardown steps that needs to be executed after each test
+	})
+
+	Context("EnvironmentVariable", func() {
+		It("Full reconciliation", func() {
+
+			key := types.NamespacedName{
+				Name: "test-environmentvariable",
+			}
+
+			secretKey := types.NamespacedName{
+				Name:      "test-environmentvariable-secret",
+				Namespace: "default",
+			}
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      secretKey.Name,
+					Namespace: secretKey.Namespace,
+				},
+				Data: map[string][]byte{
+					"TEST": []byte("test"),
+				},
+			}
+
+			environmentvariable := &checklyv1alpha1.EnvironmentVariable{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: key.Name,
+				},
+				Spec: checklyv1alpha1.EnvironmentVariableSpec{
+					Key: "TEST_KEY",
+					SecretKeyRef: corev1.ObjectReference{
+						Name:      secretKey.Name,
+						Namespace: secretKey.Namespace,
+						FieldPath: "TEST",
+					},
+				},
+			}
+
+			// Create
+			Expect(k8sClient.Create(context.Background(), secret)).Should(Succeed())
+			Expect(k8sClient.Create(context.Background(), environmentvariable)).Should(Succeed())
+
+			By("Expecting submitted")
+			Eventually(func() bool {
+				f := &checklyv1alpha1.EnvironmentVariable{}
+				err := k8sClient.Get(context.Background(), key, f)
+				if err != nil {
+					return false
+				}
+				return true
+			}, timeout, interval).Should(BeTrue())
+
+			// Status.ID should be present
+			By("Expecting environment variable key")
+			Eventually(func() bool {
+				f := &checklyv1alpha1.EnvironmentVariable{}
+				err := k8sClient.Get(context.Background(), key, f)
+				if f.Status.Key == "TEST_KEY" && err == nil {
+					return true
+				}
+				return false
+			}, timeout, interval).Should(BeTrue())
+
+			// Finalizer should be present
+			By("Expecting finalizer")
+			Eventually(func() bool {
+				f := &checklyv1alpha1.EnvironmentVariable{}
+				err := k8sClient.Get(context.Background(), key, f)
+				if err != nil {
+					return false
+				}
+
+				for _, finalizer := range f.Finalizers {
+					Expect(finalizer).To(Equal("testing.domain.tld/finalizer"), "Finalizer should match")
+				}
+
+				return true
+			}, timeout, interval).Should(BeTrue())
+
+			// Update
+			updated := &checklyv1alpha1.EnvironmentVariable{}
+			Expect(k8sClient.Get(context.Background(), key, updated)).Should(Succeed())
+
+			updated.Spec.Locked = true
+			Expect(k8sClient.Update(context.Background(), updated)).Should(Succeed())
+
+			By("Expecting update")
+			Eventually(func() bool {
+				f := &checklyv1alpha1.EnvironmentVariable{}
+				err := k8sClient.Get(context.Background(), key, f)
+				if f.Spec.Locked && err == nil {
+					return true
+				}
+				return false
+			}, timeout, interval).Should(BeTrue())
+
+			// Delete
+			By("Expecting to delete successfully")
+			Eventually(func() error {
+				f := &checklyv1alpha1.EnvironmentVariable{}
+				k8sClient.Get(context.Background(), key, f)
+				return k8sClient.Delete(context.Background(), f)
+			}, timeout, interval).Should(Succeed())
+
+			By("Expecting delete to finish")
+			Eventually(func() error {
+				f := &checklyv1alpha1.EnvironmentVariable{}
+				return k8sClient.Get(context.Background(), key, f)
+			}, timeout, interval).ShouldNot(Succeed())
+
+			Expect(k8sClient.Delete(context.Background(), secret)).Should(Succeed())
+		})
+	})
+
+	Context("Deletion", func() {
+		It("Removes the finalizer of variables which don't exist in checklyhq.com", func() {
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("/v1/variables/GONE", func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"statusCode":404,"error":"Not Found","message":"Not Found"}`))
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			deletion := metav1.Now()
+			neverCreated := &checklyv1alpha1.EnvironmentVariable{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "test-environmentvariable-never-created",
+					Finalizers:        []string{"testing.domain.tld/finalizer"},
+					DeletionTimestamp: &deletion,
+				},
+				Spec: checklyv1alpha1.EnvironmentVariableSpec{Key: "NEVER_CREATED"},
+			}
+			gone := &checklyv1alpha1.EnvironmentVariable{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "test-environmentvariable-gone",
+					Finalizers:        []string{"testing.domain.tld/finalizer"},
+					DeletionTimestamp: &deletion,
+				},
+				Spec:   checklyv1alpha1.EnvironmentVariableSpec{Key: "GONE"},
+				Status: checklyv1alpha1.EnvironmentVariableStatus{Key: "GONE"},
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(neverCreated, gone).Build()
+
+			r := &EnvironmentVariableReconciler{
+				Client:           fakeClient,
+				ApiClient:        checkly.NewClient(server.URL, "foobarbaz", nil, nil),
+				ControllerDomain: "testing.domain.tld",
+			}
+
+			for _, environmentVariable := range []*checklyv1alpha1.EnvironmentVariable{neverCreated, gone} {
+				key := client.ObjectKeyFromObject(environmentVariable)
+				_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(apierrors.IsNotFound(fakeClient.Get(context.Background(), key, &checklyv1alpha1.EnvironmentVariable{}))).To(BeTrue())
+			}
+		})
+	})
+})
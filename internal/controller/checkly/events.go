@@ -0,0 +1,92 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"time"
+
+	"github.com/checkly/checkly-go-sdk"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+
+	external "github.com/checkly/checkly-operator/external/checkly"
+)
+
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// The reasons of the events recorded on the reconciled resources
+const (
+	eventCreated      = "Created"
+	eventUpdated      = "Updated"
+	eventSyncFailed   = "SyncFailed"
+	eventNotFound     = "NotFound"
+	eventForceDeleted = "ForceDeleted"
+)
+
+// recordCreated records that the resource was created in checklyhq.com, nothing is created in
+// dry-run mode so nothing is recorded either
+func recordCreated(recorder record.EventRecorder, apiClient checkly.Client, object runtime.Object, kind string, id interface{}) {
+	if external.IsDryRun(apiClient) {
+		return
+	}
+	syncResults.WithLabelValues(kind, eventCreated).Inc()
+	if recorder == nil {
+		return
+	}
+	recorder.Eventf(object, corev1.EventTypeNormal, eventCreated, "Created the %s %v in checklyhq.com", kind, id)
+}
+
+// recordUpdated records that the resource was updated in checklyhq.com
+func recordUpdated(recorder record.EventRecorder, apiClient checkly.Client, object runtime.Object, kind string, id interface{}) {
+	if external.IsDryRun(apiClient) {
+		return
+	}
+	syncResults.WithLabelValues(kind, eventUpdated).Inc()
+	if recorder == nil {
+		return
+	}
+	recorder.Eventf(object, corev1.EventTypeNormal, eventUpdated, "Updated the %s %v in checklyhq.com", kind, id)
+}
+
+// recordSyncFailed records the checklyhq.com API error of a failed create, update or delete
+func recordSyncFailed(recorder record.EventRecorder, object runtime.Object, action, kind string, err error) {
+	syncResults.WithLabelValues(kind, eventSyncFailed).Inc()
+	if recorder == nil {
+		return
+	}
+	_, message := syncFailure(err)
+	recorder.Eventf(object, corev1.EventTypeWarning, eventSyncFailed, "Failed to %s the %s in checklyhq.com: %s", action, kind, message)
+}
+
+// recordNotFound records that the resource was deleted outside of the operator, it's created again with a new ID
+func recordNotFound(recorder record.EventRecorder, object runtime.Object, kind string, id interface{}) {
+	syncResults.WithLabelValues(kind, eventNotFound).Inc()
+	if recorder == nil {
+		return
+	}
+	recorder.Eventf(object, corev1.EventTypeWarning, eventNotFound, "The %s %v was deleted in checklyhq.com, creating it again", kind, id)
+}
+
+// recordForceDeleted records that the finalizer was removed although the resource couldn't be deleted in checklyhq.com
+func recordForceDeleted(recorder record.EventRecorder, object runtime.Object, kind string, id interface{}, failingFor time.Duration) {
+	syncResults.WithLabelValues(kind, eventForceDeleted).Inc()
+	if recorder == nil {
+		return
+	}
+	recorder.Eventf(object, corev1.EventTypeWarning, eventForceDeleted, "Removed the finalizer after failing to delete the %s %v in checklyhq.com for %s, it may still exist", kind, id, failingFor)
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/checkly/checkly-go-sdk"
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+	"k8s.io/client-go/tools/record"
+
+	external "github.com/checkly/checkly-operator/external/checkly"
+)
+
+var _ = Describe("Events", func() {
+
+	It("Records the reconcile outcomes", func() {
+
+		recorder := record.NewFakeRecorder(10)
+		apiClient := checkly.NewClient("http://localhost:5555", "foo", nil, nil)
+		group := &checklyv1alpha1.Group{}
+
+		recordCreated(recorder, apiClient, group, "group", 1)
+		Expect(<-recorder.Events).To(Equal("Normal Created Created the group 1 in checklyhq.com"))
+
+		recordUpdated(recorder, apiClient, group, "group", 1)
+		Expect(<-recorder.Events).To(Equal("Normal Updated Updated the group 1 in checklyhq.com"))
+
+		recordSyncFailed(recorder, group, "update", "group", errors.New("boom"))
+		Expect(<-recorder.Events).To(Equal("Warning SyncFailed Failed to update the group in checklyhq.com: boom"))
+
+		recordNotFound(recorder, group, "check", "abc")
+		Expect(<-recorder.Events).To(Equal("Warning NotFound The check abc was deleted in checklyhq.com, creating it again"))
+
+		By("Expecting no Created or Updated events in dry-run mode")
+		dryRunClient := external.NewDryRunClient(apiClient)
+		recordCreated(recorder, dryRunClient, group, "group", 1)
+		recordUpdated(recorder, dryRunClient, group, "group", 1)
+		Expect(recorder.Events).To(BeEmpty())
+
+		By("Expecting nothing without a recorder")
+		recordSyncFailed(nil, group, "update", "group", errors.New("boom"))
+	})
+})
@@ -0,0 +1,65 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// forceDeleteAfter is how long the deletes of a resource with the force-delete annotation are retried
+// after its deletion before its finalizer is removed
+const forceDeleteAfter = 2 * time.Minute
+
+// forceDeleteBackoff is the shortest delay between the retried deletes of a resource with the force-delete
+// annotation, the delay doubles with the time since its deletion
+const forceDeleteBackoff = 10 * time.Second
+
+// forceDelete returns whether the finalizer of a resource is removed although it couldn't be deleted in
+// checklyhq.com, ex. because the API key was revoked. Only resources with the <controller domain>/force-delete
+// annotation are given up on, once forceDeleteAfter passed since their deletion timestamp, until then the
+// delete is retried with a backoff. Otherwise it returns the result and error of the reconcile, deleteErr for
+// resources without the annotation.
+func forceDelete(ctx context.Context, recorder record.EventRecorder, controllerDomain string, object client.Object, kind string, id interface{}, deleteErr error) (bool, ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if object.GetAnnotations()[fmt.Sprintf("%s/force-delete", controllerDomain)] != "true" {
+		return false, ctrl.Result{}, deleteErr
+	}
+
+	var failingFor time.Duration
+	if deletion := object.GetDeletionTimestamp(); deletion != nil {
+		failingFor = time.Since(deletion.Time)
+	}
+	if remaining := forceDeleteAfter - failingFor; remaining > 0 {
+		backoff := max(failingFor, forceDeleteBackoff)
+		backoff = min(backoff, remaining)
+		logger.Info("Retrying the delete before force deleting", "retry in", backoff, "force delete in", remaining)
+		return false, ctrl.Result{RequeueAfter: backoff}, nil
+	}
+
+	failingFor = failingFor.Round(time.Second)
+	recordForceDeleted(recorder, object, kind, id, failingFor)
+	logger.Info("Force deleting, the finalizer is removed without deleting the checklyhq.com resource", "failing for", failingFor, "ID", id)
+	return true, ctrl.Result{}, nil
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+var _ = Describe("Force delete", func() {
+
+	It("Gives up on resources with the force-delete annotation after retrying the delete", func() {
+
+		ctx := context.Background()
+		recorder := record.NewFakeRecorder(10)
+		deleteErr := errors.New("unexpected response status 401: \"Unauthorized\"")
+
+		object := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-force-delete",
+				Namespace: "default",
+				Annotations: map[string]string{
+					"testing.domain.tld/force-delete": "true",
+				},
+			},
+		}
+		deletedAgo := func(ago time.Duration) {
+			deletion := metav1.NewTime(time.Now().Add(-ago))
+			object.SetDeletionTimestamp(&deletion)
+		}
+
+		By("Expecting the delete to be retried with a backoff")
+		deletedAgo(0)
+		forced, result, err := forceDelete(ctx, recorder, "testing.domain.tld", object, "check", "abc", deleteErr)
+		Expect(forced).To(BeFalse())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(forceDeleteBackoff))
+
+		deletedAgo(time.Minute)
+		forced, result, err = forceDelete(ctx, recorder, "testing.domain.tld", object, "check", "abc", deleteErr)
+		Expect(forced).To(BeFalse())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically("~", time.Minute, time.Second))
+
+		deletedAgo(forceDeleteAfter - 5*time.Second)
+		_, result, _ = forceDelete(ctx, recorder, "testing.domain.tld", object, "check", "abc", deleteErr)
+		Expect(result.RequeueAfter).To(BeNumerically("~", 5*time.Second, time.Second))
+		Expect(recorder.Events).To(BeEmpty())
+
+		By("Expecting the finalizer to be given up once the deletes failed for long enough")
+		deletedAgo(forceDeleteAfter)
+		forced, result, err = forceDelete(ctx, recorder, "testing.domain.tld", object, "check", "abc", deleteErr)
+		Expect(forced).To(BeTrue())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(ctrl.Result{}))
+		Expect(<-recorder.Events).To(Equal("Warning ForceDeleted Removed the finalizer after failing to delete the check abc in checklyhq.com for 2m0s, it may still exist"))
+
+		By("Expecting resources without the annotation to return the delete error")
+		delete(object.Annotations, "testing.domain.tld/force-delete")
+		forced, result, err = forceDelete(ctx, recorder, "testing.domain.tld", object, "check", "abc", deleteErr)
+		Expect(forced).To(BeFalse())
+		Expect(err).To(Equal(deleteErr))
+		Expect(result).To(Equal(ctrl.Result{}))
+	})
+
+	It("Gives up on resources whose account was deleted first", func() {
+
+		ctx := context.Background()
+		recorder := record.NewFakeRecorder(10)
+
+		deletion := metav1.NewTime(time.Now().Add(-forceDeleteAfter))
+		apiCheck := &checklyv1alpha1.ApiCheck{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "test-force-delete-account",
+				Namespace:         "default",
+				Finalizers:        []string{"testing.domain.tld/finalizer"},
+				DeletionTimestamp: &deletion,
+			},
+			Spec: checklyv1alpha1.ApiCheckSpec{
+				Account: "test-deleted-account",
+			},
+			Status: checklyv1alpha1.ApiCheckStatus{
+				ID: "abc",
+			},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(apiCheck).Build()
+
+		By("Expecting the account lookup to fail the delete without the annotation")
+		_, err := reconcileApiCheck(ctx, fakeClient, nil, recorder, "testing.domain.tld", 0, apiCheck, &apiCheck.Spec, &apiCheck.Status)
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(apiCheck), apiCheck)).To(Succeed())
+		Expect(apiCheck.Finalizers).To(ContainElement("testing.domain.tld/finalizer"))
+
+		By("Expecting the finalizer to be removed with the annotation")
+		apiCheck.Annotations = map[string]string{"testing.domain.tld/force-delete": "true"}
+		_, err = reconcileApiCheck(ctx, fakeClient, nil, recorder, "testing.domain.tld", 0, apiCheck, &apiCheck.Spec, &apiCheck.Status)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(apierrors.IsNotFound(fakeClient.Get(ctx, client.ObjectKeyFromObject(apiCheck), apiCheck))).To(BeTrue())
+		Expect(recorder.Events).To(HaveLen(3))
+	})
+})
@@ -0,0 +1,196 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/checkly/checkly-go-sdk"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+	external "github.com/checkly/checkly-operator/external/checkly"
+)
+
+// orphanedResources is the number of checks and groups the last garbage collection found without a resource
+var orphanedResources = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "checkly_operator_orphaned_resources",
+	Help: "Number of checks and groups in checklyhq.com whose owner resource doesn't exist anymore by kind",
+}, []string{"kind"})
+
+func init() {
+	metrics.Registry.MustRegister(orphanedResources)
+}
+
+// GarbageCollector periodically looks for checks and groups in checklyhq.com which have the owner tag of a
+// resource in this cluster, but whose resource doesn't exist anymore or holds another ID, ex. because the
+// operator was down when the resource was deleted. The orphans are logged and counted, or deleted.
+type GarbageCollector struct {
+	// Reader reads the owner resources, it bypasses the cache which may not hold every namespace
+	Reader    client.Reader
+	Lister    *external.Lister
+	ApiClient checkly.Client
+	Interval  time.Duration
+	// Delete deletes the orphans in checklyhq.com instead of only reporting them
+	Delete bool
+}
+
+// Start implements manager.Runnable, it collects until the context is cancelled
+func (g *GarbageCollector) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, g.collect, g.Interval)
+	return nil
+}
+
+// collect reports or deletes the orphaned checks and groups, the checks go first as they may belong to an
+// orphaned group
+func (g *GarbageCollector) collect(ctx context.Context) {
+	logger := log.FromContext(ctx).WithName("garbage-collector")
+
+	checks, err := g.Lister.Checks(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to list the checks")
+		return
+	}
+	groups, err := g.Lister.Groups(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to list the groups")
+		return
+	}
+
+	orphanedChecks := 0
+	for _, check := range checks {
+		namespace, name, found := external.Owner(check.Tags)
+		if !found {
+			continue
+		}
+		orphaned, err := g.orphaned(ctx, checkOwner(check.Type, namespace), types.NamespacedName{Namespace: namespace, Name: name}, check.ID)
+		if err != nil {
+			logger.Error(err, "Failed to read the owner of the check", "checkly ID", check.ID, "namespace", namespace, "name", name)
+			continue
+		}
+		if !orphaned {
+			continue
+		}
+		orphanedChecks++
+		g.remove(ctx, "check", check.ID, namespace, name, func() error {
+			return external.Delete(check.ID, g.ApiClient)
+		})
+	}
+
+	orphanedGroups := 0
+	for _, group := range groups {
+		_, name, found := external.Owner(group.Tags)
+		if !found {
+			continue
+		}
+		orphaned, err := g.orphaned(ctx, &checklyv1alpha1.Group{}, types.NamespacedName{Name: name}, strconv.FormatInt(group.ID, 10))
+		if err != nil {
+			logger.Error(err, "Failed to read the owner of the group", "checkly group ID", group.ID, "name", name)
+			continue
+		}
+		if !orphaned {
+			continue
+		}
+		orphanedGroups++
+		g.remove(ctx, "group", strconv.FormatInt(group.ID, 10), "", name, func() error {
+			return external.GroupDelete(group.ID, g.ApiClient)
+		})
+	}
+
+	orphanedResources.WithLabelValues("check").Set(float64(orphanedChecks))
+	orphanedResources.WithLabelValues("group").Set(float64(orphanedGroups))
+	logger.V(1).Info("Collected orphaned checks and groups", "checks", orphanedChecks, "groups", orphanedGroups)
+}
+
+// orphaned returns whether the checklyhq.com resource with the ID has no owner, resources which aren't
+// created yet may not have recorded their ID, they own nothing yet
+func (g *GarbageCollector) orphaned(ctx context.Context, owner client.Object, key types.NamespacedName, id string) (bool, error) {
+	err := g.Reader.Get(ctx, key, owner)
+	if errors.IsNotFound(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	ownedID := ownedChecklyID(owner)
+	return ownedID != "" && ownedID != id, nil
+}
+
+// remove deletes the orphaned resource or only reports it
+func (g *GarbageCollector) remove(ctx context.Context, kind, id, namespace, name string, deleteFunc func() error) {
+	logger := log.FromContext(ctx).WithName("garbage-collector")
+
+	if !g.Delete {
+		logger.Info("Found an orphaned checkly "+kind+", its resource doesn't exist anymore", "checkly ID", id, "namespace", namespace, "name", name)
+		return
+	}
+
+	err := deleteFunc()
+	if err != nil {
+		logger.Error(err, "Failed to delete the orphaned checkly "+kind, "checkly ID", id)
+		return
+	}
+	logger.Info("Deleted the orphaned checkly "+kind, "checkly ID", id, "namespace", namespace, "name", name)
+}
+
+// checkOwner returns an empty resource of the kind which creates checks of the type
+func checkOwner(checkType, namespace string) client.Object {
+	switch checkType {
+	case checkly.TypeBrowser:
+		return &checklyv1alpha1.BrowserCheck{}
+	case external.TypeMultiStep:
+		return &checklyv1alpha1.MultiStepCheck{}
+	case checkly.TypeHeartbeat:
+		return &checklyv1alpha1.HeartbeatCheck{}
+	}
+	if namespace == "" {
+		return &checklyv1alpha1.ClusterApiCheck{}
+	}
+
+	return &checklyv1alpha1.ApiCheck{}
+}
+
+// ownedChecklyID returns the checklyhq.com ID recorded by the resource, empty if it isn't created yet
+func ownedChecklyID(owner client.Object) string {
+	switch o := owner.(type) {
+	case *checklyv1alpha1.ApiCheck:
+		return o.Status.ID
+	case *checklyv1alpha1.ClusterApiCheck:
+		return o.Status.ID
+	case *checklyv1alpha1.BrowserCheck:
+		return o.Status.ID
+	case *checklyv1alpha1.MultiStepCheck:
+		return o.Status.ID
+	case *checklyv1alpha1.HeartbeatCheck:
+		return o.Status.ID
+	case *checklyv1alpha1.Group:
+		if o.Status.ID != 0 {
+			return strconv.FormatInt(o.Status.ID, 10)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/checkly/checkly-go-sdk"
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+
+	external "github.com/checkly/checkly-operator/external/checkly"
+)
+
+var _ = Describe("Garbage collector", func() {
+
+	It("Deletes the checks and groups whose resource doesn't exist anymore", func() {
+
+		external.ClusterName = "test"
+		defer func() { external.ClusterName = "" }()
+
+		var mu sync.Mutex
+		var deleted []string
+		mux := http.NewServeMux()
+		mux.HandleFunc("/v1/checks", func(w http.ResponseWriter, _ *http.Request) {
+			json.NewEncoder(w).Encode([]checkly.Check{
+				{ID: "orphan", Type: checkly.TypeAPI, Tags: []string{external.OwnerTag("default", "test-gc-missing")}},
+				{ID: "other-cluster", Type: checkly.TypeAPI, Tags: []string{"checkly-operator-owner:other/default/test-gc-missing"}},
+				{ID: "untagged", Type: checkly.TypeBrowser},
+			})
+		})
+		mux.HandleFunc("/v1/check-groups", func(w http.ResponseWriter, _ *http.Request) {
+			json.NewEncoder(w).Encode([]checkly.Group{
+				{ID: 7, Tags: []string{external.OwnerTag("", "test-gc-missing")}},
+			})
+		})
+		mux.HandleFunc("/v1/checks/", func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			deleted = append(deleted, r.Method+" "+r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		})
+		mux.HandleFunc("/v1/check-groups/", func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			deleted = append(deleted, r.Method+" "+r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		})
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		credentials := external.NewCredentials("foo", "bar")
+		collector := &GarbageCollector{
+			Reader:    k8sClient,
+			Lister:    external.NewLister(server.URL, credentials),
+			ApiClient: external.NewClientWithCredentials(server.URL, credentials),
+			Interval:  time.Minute,
+		}
+
+		By("Expecting the orphans to only be reported by default")
+		collector.collect(context.Background())
+		Expect(deleted).To(BeEmpty())
+
+		By("Expecting the orphans to be deleted")
+		collector.Delete = true
+		collector.collect(context.Background())
+		Expect(deleted).To(Equal([]string{"DELETE /v1/checks/orphan", "DELETE /v1/check-groups/7"}))
+	})
+
+	It("Looks up the owner by the check type", func() {
+		Expect(checkOwner(checkly.TypeAPI, "default")).To(BeAssignableToTypeOf(&checklyv1alpha1.ApiCheck{}))
+		Expect(checkOwner(checkly.TypeAPI, "")).To(BeAssignableToTypeOf(&checklyv1alpha1.ClusterApiCheck{}))
+		Expect(checkOwner(checkly.TypeHeartbeat, "default")).To(BeAssignableToTypeOf(&checklyv1alpha1.HeartbeatCheck{}))
+		Expect(checkOwner(external.TypeMultiStep, "default")).To(BeAssignableToTypeOf(&checklyv1alpha1.MultiStepCheck{}))
+
+		Expect(ownedChecklyID(&checklyv1alpha1.Group{Status: checklyv1alpha1.GroupStatus{ID: 7}})).To(Equal("7"))
+		Expect(ownedChecklyID(&checklyv1alpha1.Group{})).To(BeEmpty())
+	})
+})
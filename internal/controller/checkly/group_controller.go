@@ -19,38 +19,57 @@ package checkly
 import (
 	"context"
 	"fmt"
+	"slices"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/checkly/checkly-go-sdk"
 	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
 	external "github.com/checkly/checkly-operator/external/checkly"
 )
 
+// groupSecretField is the field index used to find Groups referencing a Secret, the value is namespace/name
+const groupSecretField = ".spec.environmentVariables.secrets"
+
+// groupConfigMapField is the field index used to find Groups referencing a ConfigMap, the value is namespace/name
+const groupConfigMapField = ".spec.environmentVariables.configmaps"
+
 // GroupReconciler reconciles a Group object
 type GroupReconciler struct {
 	client.Client
 	Scheme           *runtime.Scheme
 	ApiClient        checkly.Client
 	ControllerDomain string
+	ResourceSelector labels.Selector
+	Recorder         record.EventRecorder
+	ResyncInterval   time.Duration
 }
 
 //+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=groups,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=groups/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=groups/finalizers,verbs=update
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=privatelocations,verbs=get;list
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 //
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.0/pkg/reconcile
-func (r *GroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *GroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	logger := log.FromContext(ctx)
 
 	logger.V(1).Info("Reconciler started")
@@ -63,11 +82,12 @@ func (r *GroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 	// Delete Logic
 	// TODO: Add logic to determine if there are any checks that are part of the group. If yes, throw error and do not delete the group until the checks have been deleted first.
 	// ///////////////////////////////
-	err := r.Get(ctx, req.NamespacedName, group)
+	err = r.Get(ctx, req.NamespacedName, group)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			// The resource has been deleted
 			logger.Info("Deleted", "group ID", group.Status.ID, "name", group.Name)
+			forgetFailures(group, req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		// Error reading the object
@@ -75,18 +95,42 @@ func (r *GroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		return ctrl.Result{}, nil
 	}
 
+	// /////////////////////////////
+	// Account lookup
+	// ////////////////////////////
+	// A failed lookup fails the delete of a deleted resource like an API error would, so the force-delete
+	// annotation still removes its finalizer once the account or its Secret is gone
+	apiClient, accountErr := apiClientForAccount(ctx, r.Client, r.ApiClient, group.Spec.Account, group)
+	if accountErr != nil {
+		logger.Error(accountErr, "Unable to read credentials of the account", "account", group.Spec.Account)
+		if group.GetDeletionTimestamp() == nil {
+			return ctrl.Result{}, accountErr
+		}
+	}
+
 	// If DeletionTimestamp is present, the object is marked for deletion, we need to remove the finalizer
 	if group.GetDeletionTimestamp() != nil {
 		if controllerutil.ContainsFinalizer(group, groupFinalizer) {
-			logger.V(1).Info("Finalizer is present, trying to delete Checkly group", "checkly group ID", group.Status.ID)
-			err := external.GroupDelete(group.Status.ID, r.ApiClient)
-			if err != nil {
-				logger.Error(err, "Failed to delete checkly group")
-				return ctrl.Result{}, err
+			if groupRetained(group) {
+				logger.Info("Checkly group is retained, leaving it in place", "checkly group ID", group.Status.ID)
+			} else {
+				logger.V(1).Info("Finalizer is present, trying to delete Checkly group", "checkly group ID", group.Status.ID)
+				err := accountErr
+				if err == nil {
+					err = external.GroupDelete(group.Status.ID, apiClient)
+				}
+				if err != nil {
+					recordSyncFailed(r.Recorder, group, "delete", "group", err)
+					logger.Error(err, "Failed to delete checkly group")
+					forced, retry, err := forceDelete(ctx, r.Recorder, r.ControllerDomain, group, "group", group.Status.ID, err)
+					if !forced {
+						return retry, err
+					}
+				} else {
+					logger.Info("Successfully deleted checkly group", "checkly group ID", group.Status.ID)
+				}
 			}
 
-			logger.Info("Successfully deleted checkly group", "checkly group ID", group.Status.ID)
-
 			controllerutil.RemoveFinalizer(group, groupFinalizer)
 			err = r.Update(ctx, group)
 			if err != nil {
@@ -94,6 +138,7 @@ func (r *GroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 				return ctrl.Result{}, err
 			}
 			logger.V(1).Info("Successfully deleted finalizer")
+			forgetFailures(group, client.ObjectKeyFromObject(group))
 		}
 		return ctrl.Result{}, nil
 	}
@@ -115,30 +160,89 @@ func (r *GroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		return ctrl.Result{}, nil
 	}
 
+	// /////////////////////////////
+	// Conditions
+	// ////////////////////////////
+	defer func() {
+		changed := setConditions(&group.Status.Conditions, group.Generation, group.Status.ID != 0, result, err, degradation{})
+		if setApplied(group.Status.Conditions, group.Generation, group.Spec, &group.Status.ObservedGeneration, &group.Status.LastAppliedSpecHash) {
+			changed = true
+		}
+		if setStalled(&group.Status.Conditions, group, "group", err) {
+			changed = true
+		}
+		if changed {
+			statusErr := r.Status().Update(ctx, group)
+			if statusErr != nil {
+				logger.Error(statusErr, "Failed to update Group conditions")
+			}
+		}
+	}()
+
 	// /////////////////////////////
 	// AlertChannelsSubscription logic
 	// ////////////////////////////
-	var alertChannels []checkly.AlertChannelSubscription
+	subscriptions, err := groupSubscriptions(ctx, r.Client, group.Name)
+	if err != nil {
+		logger.Error(err, "Could not list AlertChannelSubscription resources")
+		return ctrl.Result{}, err
+	}
 
-	if len(group.Spec.AlertChannels) != 0 {
-		for _, alertChannel := range group.Spec.AlertChannels {
-			ac := &checklyv1alpha1.AlertChannel{}
-			err := r.Get(ctx, types.NamespacedName{Name: alertChannel}, ac)
-			if err != nil {
-				logger.Error(err, "Could not find alertChannel resource", "name", alertChannel)
-				return ctrl.Result{}, err
-			}
-			if ac.Status.ID == 0 {
-				logger.Info("AlertChannel ID not yet populated, we'll retry")
-				return ctrl.Result{Requeue: true}, nil
-			}
-			alertChannels = append(alertChannels, checkly.AlertChannelSubscription{
-				ChannelID: ac.Status.ID,
-				Activated: true,
-			})
+	subscriptions = append(groupSpecSubscriptions(group), subscriptions...)
+	err = sameAccount(ctx, r.Client, group.Spec.Account, "", accountReferences{
+		alertChannels:    group.Spec.AlertChannels,
+		subscriptions:    subscriptions,
+		privateLocations: group.Spec.PrivateLocations,
+	})
+	if err != nil {
+		logger.Error(err, "Please use the same checklyhq.com account for the group and the resources it references")
+		return ctrl.Result{}, err
+	}
+
+	alertChannels, ready, err := alertChannelSubscriptions(ctx, r.Client, group.Spec.AlertChannels, subscriptions)
+	if err != nil {
+		logger.Error(err, "Could not find alertChannel resource")
+		return ctrl.Result{}, err
+	}
+	if !ready {
+		logger.Info("AlertChannel ID not yet populated, we'll retry")
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	// /////////////////////////////
+	// Runtime lookup
+	// ////////////////////////////
+	if group.Spec.RuntimeID != "" {
+		_, err := external.GetRuntime(group.Spec.RuntimeID, apiClient)
+		if err != nil {
+			logger.Error(err, "Runtime is not supported by the account", "runtime", group.Spec.RuntimeID)
+			return ctrl.Result{}, err
 		}
 	}
 
+	// /////////////////////////////
+	// Private location lookup
+	// ////////////////////////////
+	privateLocations, privateLocationsReady, err := privateLocationSlugs(ctx, r.Client, group.Spec.PrivateLocations)
+	if err != nil {
+		logger.Error(err, "Unable to read the private locations", "names", group.Spec.PrivateLocations)
+		return ctrl.Result{}, err
+	}
+	if !privateLocationsReady {
+		logger.V(1).Info("Private location ID has not been populated, we're too quick, requeining for retry")
+		return ctrl.Result{Requeue: true}, nil
+	}
+	privateLocations = append(privateLocations, group.Spec.PrivateLocationSlugs...)
+
+	// /////////////////////////////
+	// Environment variables lookup
+	// ////////////////////////////
+	environmentVariables, err := checkEnvironmentVariables(ctx, r.Client, "", group.Spec.EnvironmentVariables)
+	if err != nil {
+		logger.Error(err, "Unable to read the values of the environment variables")
+		return ctrl.Result{}, err
+	}
+
 	// Create internal Check type
 	internalCheck := external.Group{
 		Name:          group.Name,
@@ -147,7 +251,18 @@ func (r *GroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		AlertChannels: alertChannels,
 		ID:            group.Status.ID,
 		Labels:        group.Labels,
+		Tags:          group.Spec.Tags,
+		RuntimeID:     group.Spec.RuntimeID,
+		Concurrency:   group.Spec.Concurrency,
+
+		PrivateLocations:     privateLocations,
+		EnvironmentVariables: environmentVariables,
+		RetryStrategy:        retryStrategy(group.Spec.RetryStrategy),
+		AlertSettings:        groupAlertSettings(group.Spec.AlertSettings),
+		APICheckDefaults:     groupApiCheckDefaults(group.Spec.ApiCheckDefaults),
+		Retained:             groupRetained(group),
 	}
+	payloadHash := external.GroupPayloadHash(internalCheck)
 
 	// /////////////////////////////
 	// Update logic
@@ -157,26 +272,88 @@ func (r *GroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 	if group.Status.ID != 0 {
 		// Existing object, we need to update it
 		logger.V(1).Info("Existing object, with ID", "checkly group ID", group.Status.ID)
-		err := external.GroupUpdate(internalCheck, r.ApiClient)
+
+		// The spec didn't change since the last sync, differences are changes made outside of the operator
+		upToDate := false
+		if !external.IsDryRun(apiClient) && specApplied(group.Generation, group.Spec, group.Status.ObservedGeneration, group.Status.LastAppliedSpecHash) {
+			changes, driftErr := external.GroupDrift(internalCheck, apiClient)
+			if driftErr != nil {
+				logger.Error(driftErr, "Failed to read the checkly group, skipping drift detection")
+			}
+			recordDrift(r.Recorder, group, "group", group.Status.ID, changes)
+			upToDate = driftErr == nil && len(changes) == 0 && payloadApplied(payloadHash, group.Status.LastAppliedPayloadHash)
+		}
+		if upToDate {
+			logger.V(1).Info("Checkly group is up to date, skipping the update", "checkly group ID", group.Status.ID)
+			return ctrl.Result{RequeueAfter: resyncAfter(r.ResyncInterval)}, nil
+		}
+
+		state, err := external.GroupUpdate(internalCheck, apiClient)
 		if err != nil {
+			recordSyncFailed(r.Recorder, group, "update", "group", err)
 			logger.Error(err, "Failed to update the checkly group")
 			return ctrl.Result{}, err
 		}
+		recordUpdated(r.Recorder, apiClient, group, "group", group.Status.ID)
 		logger.V(1).Info("Updated checkly check", "checkly group ID", group.Status.ID)
-		return ctrl.Result{}, nil
+
+		stateChanged := setChecklyState(&group.Status.Checkly, apiClient, state)
+
+		// Record the pushed payload, groups created before the URL was recorded get it on their next update
+		if stateChanged || group.Status.LastAppliedPayloadHash != payloadHash || group.Status.ChecklyURL != external.GroupURL(group.Status.ID) {
+			group.Status.LastAppliedPayloadHash = payloadHash
+			group.Status.ChecklyURL = external.GroupURL(group.Status.ID)
+			err = r.Status().Update(ctx, group)
+			if err != nil {
+				logger.Error(err, "Failed to update group status")
+				return ctrl.Result{}, err
+			}
+		}
+		return ctrl.Result{RequeueAfter: resyncAfter(r.ResyncInterval)}, nil
+	}
+
+	// /////////////////////////////
+	// Adopt logic
+	// ////////////////////////////
+	if group.Spec.Adopt != nil {
+		// Updating the existing group takes over its configuration and fails if it doesn't exist
+		internalCheck.ID = group.Spec.Adopt.ID
+		state, err := external.GroupUpdate(internalCheck, apiClient)
+		if err != nil {
+			recordSyncFailed(r.Recorder, group, "adopt", "group", err)
+			logger.Error(err, "Failed to adopt checkly group", "checkly group ID", group.Spec.Adopt.ID)
+			return ctrl.Result{}, err
+		}
+		recordUpdated(r.Recorder, apiClient, group, "group", group.Spec.Adopt.ID)
+
+		group.Status.ID = group.Spec.Adopt.ID
+		group.Status.ChecklyURL = external.GroupURL(group.Status.ID)
+		group.Status.LastAppliedPayloadHash = payloadHash
+		setChecklyState(&group.Status.Checkly, apiClient, state)
+		err = r.Status().Update(ctx, group)
+		if err != nil {
+			logger.Error(err, "Failed to update group status", "ID", group.Status.ID)
+			return ctrl.Result{}, err
+		}
+		logger.Info("Adopted checkly group", "ID", group.Status.ID)
+		return ctrl.Result{RequeueAfter: resyncAfter(r.ResyncInterval)}, nil
 	}
 
 	// /////////////////////////////
 	// Create logic
 	// ////////////////////////////
-	checklyID, err := external.GroupCreate(internalCheck, r.ApiClient)
+	checklyID, err := external.GroupCreate(internalCheck, apiClient)
 	if err != nil {
+		recordSyncFailed(r.Recorder, group, "create", "group", err)
 		logger.Error(err, "Failed to create checkly group")
 		return ctrl.Result{}, err
 	}
+	recordCreated(r.Recorder, apiClient, group, "group", checklyID)
 
 	// Update the custom resource Status with the returned ID
 	group.Status.ID = checklyID
+	group.Status.ChecklyURL = external.GroupURL(checklyID)
+	group.Status.LastAppliedPayloadHash = payloadHash
 	err = r.Status().Update(ctx, group)
 	if err != nil {
 		logger.Error(err, "Failed to update group status", "ID", group.Status.ID)
@@ -184,12 +361,138 @@ func (r *GroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 	}
 	logger.Info("New checkly group created", "ID", group.Status.ID)
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: resyncAfter(r.ResyncInterval)}, nil
+}
+
+// groupRetained returns true if the checklyhq.com group is kept when the resource is deleted, which is the case
+// with the Retain deletion policy and for adopted groups unless the resource took ownership
+func groupRetained(group *checklyv1alpha1.Group) bool {
+	return group.Spec.DeletionPolicy == checklyv1alpha1.DeletionPolicyRetain || (group.Spec.Adopt != nil && !group.Spec.Adopt.TakeOwnership)
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *GroupReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Index Groups by the Secrets and ConfigMaps their environment variables read values from
+	// so rotated values are pushed to checklyhq.com
+	err := mgr.GetFieldIndexer().IndexField(context.Background(), &checklyv1alpha1.Group{}, groupSecretField, func(rawObj client.Object) []string {
+		group := rawObj.(*checklyv1alpha1.Group)
+		secrets, _ := groupEnvironmentSources(groupSourceVariables(&group.Spec))
+		return secrets
+	})
+	if err != nil {
+		return err
+	}
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &checklyv1alpha1.Group{}, groupConfigMapField, func(rawObj client.Object) []string {
+		group := rawObj.(*checklyv1alpha1.Group)
+		_, configMaps := groupEnvironmentSources(groupSourceVariables(&group.Spec))
+		return configMaps
+	})
+	if err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&checklyv1alpha1.Group{}).
-		Complete(r)
+		For(&checklyv1alpha1.Group{}, builder.WithPredicates(ResourceSelectorPredicate(r.ResourceSelector))).
+		Watches(
+			&checklyv1alpha1.AlertChannelSubscription{},
+			handler.EnqueueRequestsFromMapFunc(findGroupForSubscription),
+		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.findGroupsForSource(groupSecretField)),
+		).
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.findGroupsForSource(groupConfigMapField)),
+		).
+		Complete(rateLimitRequeue{r})
+}
+
+// groupSourceVariables returns the environment variables of the group and of its browser check defaults,
+// which both read values from Secrets and ConfigMaps
+func groupSourceVariables(spec *checklyv1alpha1.GroupSpec) []checklyv1alpha1.CheckEnvironmentVariable {
+	if spec.BrowserCheckDefaults == nil {
+		return spec.EnvironmentVariables
+	}
+
+	return append(slices.Clone(spec.EnvironmentVariables), spec.BrowserCheckDefaults.EnvironmentVariables...)
+}
+
+// groupAllowsNamespace returns true if checks from the namespace can join the group, cluster scoped checks
+// can always join
+func groupAllowsNamespace(group *checklyv1alpha1.Group, namespace string) bool {
+	if len(group.Spec.AllowedNamespaces) == 0 || namespace == "" {
+		return true
+	}
+
+	return slices.Contains(group.Spec.AllowedNamespaces, namespace)
+}
+
+// groupTags returns the tags of the group which are added to its member checks, nil unless propagateTags is set
+func groupTags(group *checklyv1alpha1.Group) []string {
+	if !group.Spec.PropagateTags {
+		return nil
+	}
+
+	return slices.Clone(group.Spec.Tags)
+}
+
+// groupAlertSettings turns the alert settings of the spec into the Checkly alert settings, nil if not set
+func groupAlertSettings(settings *checklyv1alpha1.AlertSettings) *checkly.AlertSettings {
+	if settings == nil {
+		return nil
+	}
+
+	return &checkly.AlertSettings{
+		EscalationType: settings.EscalationType,
+		RunBasedEscalation: checkly.RunBasedEscalation{
+			FailedRunThreshold: settings.FailedRunThreshold,
+		},
+		TimeBasedEscalation: checkly.TimeBasedEscalation{
+			MinutesFailingThreshold: settings.MinutesFailingThreshold,
+		},
+		Reminders: checkly.Reminders{
+			Amount:   settings.ReminderAmount,
+			Interval: settings.ReminderInterval,
+		},
+	}
+}
+
+// groupApiCheckDefaults turns the api check defaults of the spec into the Checkly api check defaults
+func groupApiCheckDefaults(defaults *checklyv1alpha1.ApiCheckDefaults) checkly.APICheckDefaults {
+	if defaults == nil {
+		return checkly.APICheckDefaults{}
+	}
+
+	return checkly.APICheckDefaults{
+		BaseURL:         defaults.BaseURL,
+		Headers:         queryParameters(defaults.Headers),
+		QueryParameters: queryParameters(defaults.QueryParameters),
+		Assertions:      assertions(defaults.Assertions),
+	}
+}
+
+// findGroupsForSource returns a function which maps a Secret or ConfigMap to a reconcile request
+// for every Group which references it through the given field index
+func (r *GroupReconciler) findGroupsForSource(field string) func(context.Context, client.Object) []reconcile.Request {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		groups := &checklyv1alpha1.GroupList{}
+		listOps := &client.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector(field, client.ObjectKeyFromObject(obj).String()),
+		}
+		err := r.List(ctx, groups, listOps)
+		if err != nil {
+			return []reconcile.Request{}
+		}
+
+		requests := make([]reconcile.Request, len(groups.Items))
+		for i, item := range groups.Items {
+			requests[i] = reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: item.GetName(),
+				},
+			}
+		}
+		return requests
+	}
 }
@@ -162,5 +162,33 @@ var _ = Describe("ApiCheck Controller", func() {
 				return k8sClient.Get(context.Background(), groupKey, f)
 			}, timeout, interval).ShouldNot(Succeed())
 		})
+
+		It("Limits the namespaces of the checks", func() {
+			group := &checklyv1alpha1.Group{}
+			Expect(groupAllowsNamespace(group, "team-a")).To(BeTrue())
+
+			group.Spec.AllowedNamespaces = []string{"team-a"}
+			Expect(groupAllowsNamespace(group, "team-a")).To(BeTrue())
+			Expect(groupAllowsNamespace(group, "team-b")).To(BeFalse())
+
+			By("Expecting cluster scoped checks to be allowed")
+			Expect(groupAllowsNamespace(group, "")).To(BeTrue())
+		})
+
+		It("Retains adopted groups", func() {
+			group := &checklyv1alpha1.Group{}
+			Expect(groupRetained(group)).To(BeFalse())
+
+			group.Spec.Adopt = &checklyv1alpha1.GroupAdoption{ID: 42}
+			Expect(groupRetained(group)).To(BeTrue())
+
+			By("Expecting ownership to delete the group")
+			group.Spec.Adopt.TakeOwnership = true
+			Expect(groupRetained(group)).To(BeFalse())
+
+			By("Expecting the Retain deletion policy to keep the group")
+			group.Spec.DeletionPolicy = checklyv1alpha1.DeletionPolicyRetain
+			Expect(groupRetained(group)).To(BeTrue())
+		})
 	})
 })
@@ -0,0 +1,327 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/checkly/checkly-go-sdk"
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+	external "github.com/checkly/checkly-operator/external/checkly"
+)
+
+// HeartbeatCheckReconciler reconciles a HeartbeatCheck object
+type HeartbeatCheckReconciler struct {
+	client.Client
+	Scheme           *runtime.Scheme
+	ApiClient        checkly.Client
+	ControllerDomain string
+	ResourceSelector labels.Selector
+	Recorder         record.EventRecorder
+	ResyncInterval   time.Duration
+}
+
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=heartbeatchecks,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=heartbeatchecks/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=heartbeatchecks/finalizers,verbs=update
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=alertchannels,verbs=get;list
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.0/pkg/reconcile
+func (r *HeartbeatCheckReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	logger := log.FromContext(ctx)
+
+	heartbeatCheckFinalizer := fmt.Sprintf("%s/finalizer", r.ControllerDomain)
+	logger.V(1).Info("Reconciler started")
+
+	heartbeatCheck := &checklyv1alpha1.HeartbeatCheck{}
+
+	// ////////////////////////////////
+	// Delete Logic
+	// ///////////////////////////////
+	err = r.Get(ctx, req.NamespacedName, heartbeatCheck)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// The resource has been deleted
+			logger.V(1).Info("Deleted", "checkly ID", heartbeatCheck.Status.ID, "name", heartbeatCheck.Name)
+			forgetFailures(heartbeatCheck, req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object
+		logger.Error(err, "can't read the object")
+		return ctrl.Result{}, nil
+	}
+
+	// /////////////////////////////
+	// Account lookup
+	// ////////////////////////////
+	// A failed lookup fails the delete of a deleted resource like an API error would, so the force-delete
+	// annotation still removes its finalizer once the account or its Secret is gone
+	apiClient, credentials, accountErr := apiClientForCheck(ctx, r.Client, r.ApiClient, heartbeatCheck.Spec.Account, heartbeatCheck, heartbeatCheck.Status.ID, heartbeatCheck.Status.Credentials)
+	if accountErr != nil {
+		logger.Error(accountErr, "Unable to read credentials of the account", "account", heartbeatCheck.Spec.Account)
+		if heartbeatCheck.GetDeletionTimestamp() == nil {
+			updateConditions(ctx, r.Client, heartbeatCheck, &heartbeatCheck.Status.Conditions, heartbeatCheck.Status.ID != "", accountErr, checkDegradation(heartbeatCheck.Spec.Paused, heartbeatCheck.Spec.Muted))
+			return ctrl.Result{}, accountErr
+		}
+	}
+	credentialsChanged := accountErr == nil && heartbeatCheck.Status.Credentials != credentials
+	if credentialsChanged {
+		heartbeatCheck.Status.Credentials = credentials
+	}
+
+	if heartbeatCheck.GetDeletionTimestamp() != nil {
+		if controllerutil.ContainsFinalizer(heartbeatCheck, heartbeatCheckFinalizer) {
+			if checkRetained(heartbeatCheck.Spec.DeletionPolicy, heartbeatCheck.Spec.Adopt) {
+				logger.Info("Checkly heartbeat check is retained, leaving it in place", "checkly ID", heartbeatCheck.Status.ID)
+			} else if heartbeatCheck.Status.ID == "" {
+				logger.Info("Checkly heartbeat check was never created, nothing to delete")
+			} else {
+				logger.V(1).Info("Finalizer is present, trying to delete Checkly heartbeat check", "checkly ID", heartbeatCheck.Status.ID)
+				err := accountErr
+				if err == nil {
+					err = external.DeleteHeartbeatCheck(heartbeatCheck.Status.ID, apiClient)
+				}
+				if external.IsNotFound(err) {
+					logger.Info("Checkly heartbeat check was already deleted", "checkly ID", heartbeatCheck.Status.ID)
+				} else if err != nil {
+					recordSyncFailed(r.Recorder, heartbeatCheck, "delete", "heartbeat check", err)
+					logger.Error(err, "Failed to delete checkly heartbeat check")
+					forced, retry, err := forceDelete(ctx, r.Recorder, r.ControllerDomain, heartbeatCheck, "heartbeat check", heartbeatCheck.Status.ID, err)
+					if !forced {
+						return retry, err
+					}
+				} else {
+					logger.Info("Successfully deleted checkly heartbeat check", "checkly ID", heartbeatCheck.Status.ID)
+				}
+			}
+
+			controllerutil.RemoveFinalizer(heartbeatCheck, heartbeatCheckFinalizer)
+			err = r.Update(ctx, heartbeatCheck)
+			if err != nil {
+				logger.Error(err, "Failed to delete finalizer")
+				return ctrl.Result{}, err
+			}
+			logger.V(1).Info("Successfully deleted finalizer")
+			forgetFailures(heartbeatCheck, client.ObjectKeyFromObject(heartbeatCheck))
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Object found, let's do something with it. It's either updated, or it's new.
+	logger.V(1).Info("Object found", "name", heartbeatCheck.Name)
+
+	// /////////////////////////////
+	// Finalizer logic
+	// ////////////////////////////
+	if !controllerutil.ContainsFinalizer(heartbeatCheck, heartbeatCheckFinalizer) {
+		controllerutil.AddFinalizer(heartbeatCheck, heartbeatCheckFinalizer)
+		err = r.Update(ctx, heartbeatCheck)
+		if err != nil {
+			logger.Error(err, "Failed to update HeartbeatCheck status")
+			return ctrl.Result{}, err
+		}
+		logger.V(1).Info("Added finalizer", "checkly ID", heartbeatCheck.Status.ID)
+		return ctrl.Result{}, nil
+	}
+
+	// /////////////////////////////
+	// Conditions
+	// ////////////////////////////
+	defer func() {
+		changed := setConditions(&heartbeatCheck.Status.Conditions, heartbeatCheck.Generation, heartbeatCheck.Status.ID != "", result, err, checkDegradation(heartbeatCheck.Spec.Paused, heartbeatCheck.Spec.Muted))
+		if setStalled(&heartbeatCheck.Status.Conditions, heartbeatCheck, "heartbeat check", err) {
+			changed = true
+		}
+		if credentialsChanged {
+			changed = true
+		}
+		if changed {
+			statusErr := r.Status().Update(ctx, heartbeatCheck)
+			if statusErr != nil {
+				logger.Error(statusErr, "Failed to update HeartbeatCheck conditions")
+			}
+		}
+	}()
+
+	// /////////////////////////////
+	// AlertChannelsSubscription logic
+	// ////////////////////////////
+	subscriptions, err := checkSubscriptions(ctx, r.Client, "HeartbeatCheck", req.NamespacedName)
+	if err != nil {
+		logger.Error(err, "Could not list AlertChannelSubscription resources")
+		return ctrl.Result{}, err
+	}
+
+	err = sameAccount(ctx, r.Client, heartbeatCheck.Spec.Account, heartbeatCheck.Namespace, accountReferences{alertChannels: heartbeatCheck.Spec.AlertChannels, subscriptions: subscriptions})
+	if err != nil {
+		logger.Error(err, "Please use the same checklyhq.com account for the check and its alert channels")
+		return ctrl.Result{}, err
+	}
+
+	alertChannels, ready, err := alertChannelSubscriptions(ctx, r.Client, heartbeatCheck.Spec.AlertChannels, subscriptions)
+	if err != nil {
+		logger.Error(err, "Could not find alertChannel resource")
+		return ctrl.Result{}, err
+	}
+	if !ready {
+		logger.Info("AlertChannel ID not yet populated, we'll retry")
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	// Create internal HeartbeatCheck type
+	internalCheck := external.HeartbeatCheck{
+		Name:          heartbeatCheck.Name,
+		Namespace:     heartbeatCheck.Namespace,
+		Period:        heartbeatCheck.Spec.Period,
+		PeriodUnit:    heartbeatCheck.Spec.PeriodUnit,
+		Grace:         heartbeatCheck.Spec.Grace,
+		GraceUnit:     heartbeatCheck.Spec.GraceUnit,
+		ID:            heartbeatCheck.Status.ID,
+		Muted:         heartbeatCheck.Spec.Muted,
+		Paused:        heartbeatCheck.Spec.Paused,
+		AlertChannels: alertChannels,
+		Labels:        heartbeatCheck.Labels,
+		Retained:      checkRetained(heartbeatCheck.Spec.DeletionPolicy, heartbeatCheck.Spec.Adopt),
+	}
+
+	// /////////////////////////////
+	// Update logic
+	// ////////////////////////////
+
+	// Determine if it's a new object or if it's an update to an existing object
+	var pingURL string
+	var state external.State
+	if heartbeatCheck.Status.ID != "" {
+		// Existing object, we need to update it
+		logger.V(1).Info("Existing object, with ID", "checkly ID", heartbeatCheck.Status.ID)
+		pingURL, state, err = external.UpdateHeartbeatCheck(internalCheck, apiClient)
+		if external.IsNotFound(err) {
+			// The check was deleted outside of the operator, it's created again with a new ID and ping URL
+			recordNotFound(r.Recorder, heartbeatCheck, "heartbeat check", heartbeatCheck.Status.ID)
+			logger.Info("Checkly heartbeat check not found, recreating it", "checkly ID", heartbeatCheck.Status.ID)
+			heartbeatCheck.Status.ID, internalCheck.ID = "", ""
+		} else if err != nil {
+			recordSyncFailed(r.Recorder, heartbeatCheck, "update", "heartbeat check", err)
+			logger.Error(err, "Failed to update the checkly heartbeat check")
+			return ctrl.Result{}, err
+		}
+	}
+
+	if heartbeatCheck.Status.ID != "" {
+		recordUpdated(r.Recorder, apiClient, heartbeatCheck, "heartbeat check", heartbeatCheck.Status.ID)
+		logger.Info("Updated checkly heartbeat check", "checkly ID", heartbeatCheck.Status.ID)
+
+		stateChanged := setChecklyState(&heartbeatCheck.Status.Checkly, apiClient, state)
+
+		// Checks created before the URL was recorded get it on their next update
+		checklyURL := external.CheckURL(heartbeatCheck.Status.ID)
+		if pingURL == "" {
+			pingURL = heartbeatCheck.Status.PingURL
+		}
+		if stateChanged || pingURL != heartbeatCheck.Status.PingURL || checklyURL != heartbeatCheck.Status.ChecklyURL {
+			heartbeatCheck.Status.PingURL = pingURL
+			heartbeatCheck.Status.ChecklyURL = checklyURL
+			err = r.Status().Update(ctx, heartbeatCheck)
+			if err != nil {
+				logger.Error(err, "Failed to update HeartbeatCheck status")
+				return ctrl.Result{}, err
+			}
+		}
+		return ctrl.Result{RequeueAfter: resyncAfter(r.ResyncInterval)}, nil
+	}
+
+	// /////////////////////////////
+	// Adopt logic
+	// ////////////////////////////
+	if heartbeatCheck.Spec.Adopt != nil {
+		// Updating the existing check takes over its configuration and fails if it doesn't exist
+		internalCheck.ID = heartbeatCheck.Spec.Adopt.ID
+		pingURL, state, err = external.UpdateHeartbeatCheck(internalCheck, apiClient)
+		if err != nil {
+			recordSyncFailed(r.Recorder, heartbeatCheck, "adopt", "heartbeat check", err)
+			logger.Error(err, "Failed to adopt checkly heartbeat check", "checkly ID", heartbeatCheck.Spec.Adopt.ID)
+			return ctrl.Result{}, err
+		}
+		recordUpdated(r.Recorder, apiClient, heartbeatCheck, "heartbeat check", heartbeatCheck.Spec.Adopt.ID)
+
+		heartbeatCheck.Status.ID = heartbeatCheck.Spec.Adopt.ID
+		heartbeatCheck.Status.ChecklyURL = external.CheckURL(heartbeatCheck.Status.ID)
+		heartbeatCheck.Status.PingURL = pingURL
+		setChecklyState(&heartbeatCheck.Status.Checkly, apiClient, state)
+		err = r.Status().Update(ctx, heartbeatCheck)
+		if err != nil {
+			logger.Error(err, "Failed to update HeartbeatCheck status")
+			return ctrl.Result{}, err
+		}
+		logger.V(1).Info("Adopted checkly heartbeat check", "checkly ID", heartbeatCheck.Status.ID)
+		return ctrl.Result{RequeueAfter: resyncAfter(r.ResyncInterval)}, nil
+	}
+
+	// /////////////////////////////
+	// Create logic
+	// ////////////////////////////
+
+	checklyID, pingURL, err := external.CreateHeartbeatCheck(internalCheck, apiClient)
+	if err != nil {
+		recordSyncFailed(r.Recorder, heartbeatCheck, "create", "heartbeat check", err)
+		logger.Error(err, "Failed to create checkly heartbeat check")
+		return ctrl.Result{}, err
+	}
+	recordCreated(r.Recorder, apiClient, heartbeatCheck, "heartbeat check", checklyID)
+
+	// Update the custom resource Status with the returned ID and ping URL
+
+	heartbeatCheck.Status.ID = checklyID
+	heartbeatCheck.Status.ChecklyURL = external.CheckURL(checklyID)
+	heartbeatCheck.Status.PingURL = pingURL
+	err = r.Status().Update(ctx, heartbeatCheck)
+	if err != nil {
+		logger.Error(err, "Failed to update HeartbeatCheck status")
+		return ctrl.Result{}, err
+	}
+	logger.V(1).Info("New checkly heartbeat check created with", "checkly ID", heartbeatCheck.Status.ID, "spec", heartbeatCheck.Spec)
+
+	return ctrl.Result{RequeueAfter: resyncAfter(r.ResyncInterval)}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *HeartbeatCheckReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&checklyv1alpha1.HeartbeatCheck{}, builder.WithPredicates(ResourceSelectorPredicate(r.ResourceSelector))).
+		Watches(
+			&checklyv1alpha1.AlertChannelSubscription{},
+			handler.EnqueueRequestsFromMapFunc(findCheckForSubscription("HeartbeatCheck")),
+		).
+		Complete(rateLimitRequeue{r})
+}
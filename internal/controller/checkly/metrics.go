@@ -0,0 +1,33 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// syncResults counts the outcomes of the syncs with checklyhq.com, the reconcile results of the
+// controllers are counted by controller-runtime in controller_runtime_reconcile_total
+var syncResults = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "checkly_operator_sync_total",
+	Help: "Number of creates, updates and failed syncs of the resources in checklyhq.com by kind",
+}, []string{"kind", "result"})
+
+func init() {
+	metrics.Registry.MustRegister(syncResults)
+}
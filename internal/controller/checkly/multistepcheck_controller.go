@@ -0,0 +1,513 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+	errs "errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/checkly/checkly-go-sdk"
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+	external "github.com/checkly/checkly-operator/external/checkly"
+)
+
+// multiStepCheckGroupField is the field index used to find the MultiStepChecks of a Group
+const multiStepCheckGroupField = ".spec.group"
+
+// multiStepCheckSecretField is the field index used to find MultiStepChecks referencing a Secret
+const multiStepCheckSecretField = ".spec.secrets"
+
+// multiStepCheckConfigMapField is the field index used to find MultiStepChecks referencing a ConfigMap
+const multiStepCheckConfigMapField = ".spec.configmap.name"
+
+// MultiStepCheckReconciler reconciles a MultiStepCheck object
+type MultiStepCheckReconciler struct {
+	client.Client
+	Scheme           *runtime.Scheme
+	ApiClient        checkly.Client
+	ControllerDomain string
+	ResourceSelector labels.Selector
+	Recorder         record.EventRecorder
+	ResyncInterval   time.Duration
+}
+
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=multistepchecks,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=multistepchecks/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=multistepchecks/finalizers,verbs=update
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=groups,verbs=get;list
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.0/pkg/reconcile
+func (r *MultiStepCheckReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	logger := log.FromContext(ctx)
+
+	multiStepCheckFinalizer := fmt.Sprintf("%s/finalizer", r.ControllerDomain)
+	logger.V(1).Info("Reconciler started")
+
+	multiStepCheck := &checklyv1alpha1.MultiStepCheck{}
+
+	// ////////////////////////////////
+	// Delete Logic
+	// ///////////////////////////////
+	err = r.Get(ctx, req.NamespacedName, multiStepCheck)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// The resource has been deleted
+			logger.V(1).Info("Deleted", "checkly ID", multiStepCheck.Status.ID, "name", multiStepCheck.Name)
+			forgetFailures(multiStepCheck, req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object
+		logger.Error(err, "can't read the object")
+		return ctrl.Result{}, nil
+	}
+
+	// /////////////////////////////
+	// Account lookup
+	// ////////////////////////////
+	// A failed lookup fails the delete of a deleted resource like an API error would, so the force-delete
+	// annotation still removes its finalizer once the account or its Secret is gone
+	apiClient, credentials, accountErr := apiClientForCheck(ctx, r.Client, r.ApiClient, multiStepCheck.Spec.Account, multiStepCheck, multiStepCheck.Status.ID, multiStepCheck.Status.Credentials)
+	if accountErr != nil {
+		logger.Error(accountErr, "Unable to read credentials of the account", "account", multiStepCheck.Spec.Account)
+		if multiStepCheck.GetDeletionTimestamp() == nil {
+			updateConditions(ctx, r.Client, multiStepCheck, &multiStepCheck.Status.Conditions, multiStepCheck.Status.ID != "", accountErr, checkDegradation(multiStepCheck.Spec.Paused, multiStepCheck.Spec.Muted))
+			return ctrl.Result{}, accountErr
+		}
+	}
+	credentialsChanged := accountErr == nil && multiStepCheck.Status.Credentials != credentials
+	if credentialsChanged {
+		multiStepCheck.Status.Credentials = credentials
+	}
+
+	if multiStepCheck.GetDeletionTimestamp() != nil {
+		if controllerutil.ContainsFinalizer(multiStepCheck, multiStepCheckFinalizer) {
+			if checkRetained(multiStepCheck.Spec.DeletionPolicy, multiStepCheck.Spec.Adopt) {
+				logger.Info("Checkly multi-step check is retained, leaving it in place", "checkly ID", multiStepCheck.Status.ID)
+			} else if multiStepCheck.Status.ID == "" {
+				logger.Info("Checkly multi-step check was never created, nothing to delete")
+			} else {
+				logger.V(1).Info("Finalizer is present, trying to delete Checkly multi-step check", "checkly ID", multiStepCheck.Status.ID)
+				err := accountErr
+				if err == nil {
+					err = external.DeleteMultiStepCheck(multiStepCheck.Status.ID, apiClient)
+				}
+				if external.IsNotFound(err) {
+					logger.Info("Checkly multi-step check was already deleted", "checkly ID", multiStepCheck.Status.ID)
+				} else if err != nil {
+					recordSyncFailed(r.Recorder, multiStepCheck, "delete", "multi-step check", err)
+					logger.Error(err, "Failed to delete checkly multi-step check")
+					forced, retry, err := forceDelete(ctx, r.Recorder, r.ControllerDomain, multiStepCheck, "multi-step check", multiStepCheck.Status.ID, err)
+					if !forced {
+						return retry, err
+					}
+				} else {
+					logger.Info("Successfully deleted checkly multi-step check", "checkly ID", multiStepCheck.Status.ID)
+				}
+			}
+
+			controllerutil.RemoveFinalizer(multiStepCheck, multiStepCheckFinalizer)
+			err = r.Update(ctx, multiStepCheck)
+			if err != nil {
+				logger.Error(err, "Failed to delete finalizer")
+				return ctrl.Result{}, err
+			}
+			logger.V(1).Info("Successfully deleted finalizer")
+			forgetFailures(multiStepCheck, client.ObjectKeyFromObject(multiStepCheck))
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Object found, let's do something with it. It's either updated, or it's new.
+	logger.V(1).Info("Object found", "name", multiStepCheck.Name)
+
+	// /////////////////////////////
+	// Finalizer logic
+	// ////////////////////////////
+	if !controllerutil.ContainsFinalizer(multiStepCheck, multiStepCheckFinalizer) {
+		controllerutil.AddFinalizer(multiStepCheck, multiStepCheckFinalizer)
+		err = r.Update(ctx, multiStepCheck)
+		if err != nil {
+			logger.Error(err, "Failed to update MultiStepCheck status")
+			return ctrl.Result{}, err
+		}
+		logger.V(1).Info("Added finalizer", "checkly ID", multiStepCheck.Status.ID)
+		return ctrl.Result{}, nil
+	}
+
+	// /////////////////////////////
+	// Conditions
+	// ////////////////////////////
+	defer func() {
+		changed := setConditions(&multiStepCheck.Status.Conditions, multiStepCheck.Generation, multiStepCheck.Status.ID != "", result, err, checkDegradation(multiStepCheck.Spec.Paused, multiStepCheck.Spec.Muted))
+		if setStalled(&multiStepCheck.Status.Conditions, multiStepCheck, "multi-step check", err) {
+			changed = true
+		}
+		if credentialsChanged {
+			changed = true
+		}
+		if changed {
+			statusErr := r.Status().Update(ctx, multiStepCheck)
+			if statusErr != nil {
+				logger.Error(statusErr, "Failed to update MultiStepCheck conditions")
+			}
+		}
+	}()
+
+	// /////////////////////////////
+	// Script lookup
+	// ////////////////////////////
+	script := multiStepCheck.Spec.Script
+	if multiStepCheck.Spec.ConfigMap != nil {
+		configMap := &corev1.ConfigMap{}
+		err = r.Get(ctx, types.NamespacedName{Name: multiStepCheck.Spec.ConfigMap.Name, Namespace: multiStepCheck.Namespace}, configMap)
+		if err != nil {
+			logger.Error(err, "Unable to read configmap for script", "name", multiStepCheck.Spec.ConfigMap.Name)
+			return ctrl.Result{}, err
+		}
+
+		script = configMap.Data[multiStepCheck.Spec.ConfigMap.Key]
+	}
+
+	if script == "" {
+		scriptErr := errs.New("script is empty")
+		logger.Error(scriptErr, "Please add a script inline or through a configmap")
+		return ctrl.Result{}, scriptErr
+	}
+
+	// /////////////////////////////
+	// Environment variables lookup
+	// ////////////////////////////
+	environmentVariables, err := checkEnvironmentVariables(ctx, r.Client, multiStepCheck.Namespace, multiStepCheck.Spec.EnvironmentVariables)
+	if err != nil {
+		logger.Error(err, "Unable to read the values of the environment variables")
+		return ctrl.Result{}, err
+	}
+
+	// /////////////////////////////
+	// Lookup group ID
+	// ////////////////////////////
+	group := &checklyv1alpha1.Group{}
+	err = r.Get(ctx, types.NamespacedName{Name: multiStepCheck.Spec.Group}, group)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// The resource has been deleted
+			logger.Error(err, "Group not found, probably deleted or does not exist", "name", multiStepCheck.Spec.Group)
+			return ctrl.Result{}, err
+		}
+		// Error reading the object
+		logger.Error(err, "can't read the group object")
+		return ctrl.Result{}, err
+	}
+
+	if !groupAllowsNamespace(group, multiStepCheck.Namespace) {
+		groupErr := fmt.Errorf("group %s doesn't allow checks from namespace %s", multiStepCheck.Spec.Group, multiStepCheck.Namespace)
+		logger.Error(groupErr, "Please add the namespace to the allowedNamespaces of the group")
+		return ctrl.Result{}, groupErr
+	}
+
+	err = sameAccount(ctx, r.Client, multiStepCheck.Spec.Account, multiStepCheck.Namespace, accountReferences{group: multiStepCheck.Spec.Group})
+	if err != nil {
+		logger.Error(err, "Please use the same checklyhq.com account for the check and its group")
+		return ctrl.Result{}, err
+	}
+
+	if group.Status.ID == 0 {
+		logger.V(1).Info("Group ID has not been populated, we're too quick, requeining for retry", "group name", multiStepCheck.Spec.Group)
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	// /////////////////////////////
+	// Runtime lookup
+	// ////////////////////////////
+	// The group holds the default of the runtime
+	runtimeID := multiStepCheck.Spec.Runtime
+	if runtimeID == "" {
+		runtimeID = group.Spec.RuntimeID
+	}
+	if runtimeID != "" {
+		runtime, err := external.GetRuntime(runtimeID, apiClient)
+		if err != nil {
+			logger.Error(err, "Runtime is not supported by the account", "runtime", runtimeID)
+			return ctrl.Result{}, err
+		}
+		if !runtime.MultiStepSupport {
+			runtimeErr := fmt.Errorf("runtime %s doesn't support multi-step checks", runtimeID)
+			logger.Error(runtimeErr, "Please pick a newer runtime")
+			return ctrl.Result{}, runtimeErr
+		}
+	}
+
+	// The group holds the default of the retry strategy
+	checkRetryStrategy := multiStepCheck.Spec.RetryStrategy
+	if checkRetryStrategy == nil {
+		checkRetryStrategy = group.Spec.RetryStrategy
+	}
+
+	// Create internal MultiStepCheck type
+	internalCheck := external.MultiStepCheck{
+		Name:      multiStepCheck.Name,
+		Namespace: multiStepCheck.Namespace,
+		Frequency: multiStepCheck.Spec.Frequency,
+		Locations: multiStepCheck.Spec.Locations,
+		Runtime:   runtimeID,
+		Script:    script,
+		ID:        multiStepCheck.Status.ID,
+		GroupID:   group.Status.ID,
+		Muted:     multiStepCheck.Spec.Muted,
+		Paused:    multiStepCheck.Spec.Paused,
+		Labels:    multiStepCheck.Labels,
+		Tags:      groupTags(group),
+
+		EnvironmentVariables: environmentVariables,
+		RetryStrategy:        retryStrategy(checkRetryStrategy),
+		Retained:             checkRetained(multiStepCheck.Spec.DeletionPolicy, multiStepCheck.Spec.Adopt),
+	}
+
+	// /////////////////////////////
+	// Update logic
+	// ////////////////////////////
+
+	// Determine if it's a new object or if it's an update to an existing object
+	var state external.State
+	if multiStepCheck.Status.ID != "" {
+		// Existing object, we need to update it
+		logger.V(1).Info("Existing object, with ID", "checkly ID", multiStepCheck.Status.ID)
+		state, err = external.UpdateMultiStepCheck(internalCheck, apiClient)
+		if external.IsNotFound(err) {
+			// The check was deleted outside of the operator, it's created again with a new ID
+			recordNotFound(r.Recorder, multiStepCheck, "multi-step check", multiStepCheck.Status.ID)
+			logger.Info("Checkly multi-step check not found, recreating it", "checkly ID", multiStepCheck.Status.ID)
+			multiStepCheck.Status.ID, internalCheck.ID = "", ""
+		} else if err != nil {
+			recordSyncFailed(r.Recorder, multiStepCheck, "update", "multi-step check", err)
+			logger.Error(err, "Failed to update the checkly multi-step check")
+			return ctrl.Result{}, err
+		}
+	}
+
+	if multiStepCheck.Status.ID != "" {
+		recordUpdated(r.Recorder, apiClient, multiStepCheck, "multi-step check", multiStepCheck.Status.ID)
+		logger.Info("Updated checkly multi-step check", "checkly ID", multiStepCheck.Status.ID)
+
+		stateChanged := setChecklyState(&multiStepCheck.Status.Checkly, apiClient, state)
+
+		// Checks created before the URL was recorded get it on their next update
+		if stateChanged || multiStepCheck.Status.ChecklyURL != external.CheckURL(multiStepCheck.Status.ID) {
+			multiStepCheck.Status.ChecklyURL = external.CheckURL(multiStepCheck.Status.ID)
+			err = r.Status().Update(ctx, multiStepCheck)
+			if err != nil {
+				logger.Error(err, "Failed to update MultiStepCheck status")
+				return ctrl.Result{}, err
+			}
+		}
+		return ctrl.Result{RequeueAfter: resyncAfter(r.ResyncInterval)}, nil
+	}
+
+	// /////////////////////////////
+	// Adopt logic
+	// ////////////////////////////
+	if multiStepCheck.Spec.Adopt != nil {
+		// Updating the existing check takes over its configuration and fails if it doesn't exist
+		internalCheck.ID = multiStepCheck.Spec.Adopt.ID
+		state, err := external.UpdateMultiStepCheck(internalCheck, apiClient)
+		if err != nil {
+			recordSyncFailed(r.Recorder, multiStepCheck, "adopt", "multi-step check", err)
+			logger.Error(err, "Failed to adopt checkly multi-step check", "checkly ID", multiStepCheck.Spec.Adopt.ID)
+			return ctrl.Result{}, err
+		}
+		recordUpdated(r.Recorder, apiClient, multiStepCheck, "multi-step check", multiStepCheck.Spec.Adopt.ID)
+
+		multiStepCheck.Status.ID = multiStepCheck.Spec.Adopt.ID
+		multiStepCheck.Status.ChecklyURL = external.CheckURL(multiStepCheck.Status.ID)
+		multiStepCheck.Status.GroupID = group.Status.ID
+		setChecklyState(&multiStepCheck.Status.Checkly, apiClient, state)
+		err = r.Status().Update(ctx, multiStepCheck)
+		if err != nil {
+			logger.Error(err, "Failed to update MultiStepCheck status")
+			return ctrl.Result{}, err
+		}
+		logger.V(1).Info("Adopted checkly multi-step check", "checkly ID", multiStepCheck.Status.ID)
+		return ctrl.Result{RequeueAfter: resyncAfter(r.ResyncInterval)}, nil
+	}
+
+	// /////////////////////////////
+	// Create logic
+	// ////////////////////////////
+
+	checklyID, err := external.CreateMultiStepCheck(internalCheck, apiClient)
+	if err != nil {
+		recordSyncFailed(r.Recorder, multiStepCheck, "create", "multi-step check", err)
+		logger.Error(err, "Failed to create checkly multi-step check")
+		return ctrl.Result{}, err
+	}
+	recordCreated(r.Recorder, apiClient, multiStepCheck, "multi-step check", checklyID)
+
+	// Update the custom resource Status with the returned ID
+
+	multiStepCheck.Status.ID = checklyID
+	multiStepCheck.Status.ChecklyURL = external.CheckURL(checklyID)
+	multiStepCheck.Status.GroupID = group.Status.ID
+	err = r.Status().Update(ctx, multiStepCheck)
+	if err != nil {
+		logger.Error(err, "Failed to update MultiStepCheck status")
+		return ctrl.Result{}, err
+	}
+	logger.V(1).Info("New checkly multi-step check created with", "checkly ID", multiStepCheck.Status.ID, "spec", multiStepCheck.Spec)
+
+	return ctrl.Result{RequeueAfter: resyncAfter(r.ResyncInterval)}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *MultiStepCheckReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Index MultiStepChecks by the ConfigMaps and Secrets they read their script and environment
+	// variables from so changes to them trigger a reconciliation of the check
+	err := mgr.GetFieldIndexer().IndexField(context.Background(), &checklyv1alpha1.MultiStepCheck{}, multiStepCheckConfigMapField, func(rawObj client.Object) []string {
+		multiStepCheck := rawObj.(*checklyv1alpha1.MultiStepCheck)
+		names := checkEnvironmentConfigMapNames(multiStepCheck.Spec.EnvironmentVariables)
+		if multiStepCheck.Spec.ConfigMap != nil && multiStepCheck.Spec.ConfigMap.Name != "" {
+			names = append(names, multiStepCheck.Spec.ConfigMap.Name)
+		}
+		return names
+	})
+	if err != nil {
+		return err
+	}
+	// Index MultiStepChecks by their Group so the defaults of the Group are applied when it changes
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &checklyv1alpha1.MultiStepCheck{}, multiStepCheckGroupField, func(rawObj client.Object) []string {
+		multiStepCheck := rawObj.(*checklyv1alpha1.MultiStepCheck)
+		return []string{multiStepCheck.Spec.Group}
+	})
+	if err != nil {
+		return err
+	}
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &checklyv1alpha1.MultiStepCheck{}, multiStepCheckSecretField, func(rawObj client.Object) []string {
+		multiStepCheck := rawObj.(*checklyv1alpha1.MultiStepCheck)
+		return checkEnvironmentSecretNames(multiStepCheck.Spec.EnvironmentVariables)
+	})
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&checklyv1alpha1.MultiStepCheck{}, builder.WithPredicates(ResourceSelectorPredicate(r.ResourceSelector))).
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.findMultiStepChecksForConfigMap),
+		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.findMultiStepChecksForSecret),
+		).
+		Watches(
+			&checklyv1alpha1.Group{},
+			handler.EnqueueRequestsFromMapFunc(r.findMultiStepChecksForGroup),
+		).
+		Complete(rateLimitRequeue{r})
+}
+
+// findMultiStepChecksForConfigMap returns a reconcile request for every MultiStepCheck which references the ConfigMap
+func (r *MultiStepCheckReconciler) findMultiStepChecksForConfigMap(ctx context.Context, configMap client.Object) []reconcile.Request {
+	multiStepChecks := &checklyv1alpha1.MultiStepCheckList{}
+	listOps := &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(multiStepCheckConfigMapField, configMap.GetName()),
+		Namespace:     configMap.GetNamespace(),
+	}
+	err := r.List(ctx, multiStepChecks, listOps)
+	if err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, len(multiStepChecks.Items))
+	for i, item := range multiStepChecks.Items {
+		requests[i] = reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      item.GetName(),
+				Namespace: item.GetNamespace(),
+			},
+		}
+	}
+	return requests
+}
+
+// findMultiStepChecksForSecret returns a reconcile request for every MultiStepCheck which references the Secret
+func (r *MultiStepCheckReconciler) findMultiStepChecksForSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	multiStepChecks := &checklyv1alpha1.MultiStepCheckList{}
+	listOps := &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(multiStepCheckSecretField, secret.GetName()),
+		Namespace:     secret.GetNamespace(),
+	}
+	err := r.List(ctx, multiStepChecks, listOps)
+	if err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, len(multiStepChecks.Items))
+	for i, item := range multiStepChecks.Items {
+		requests[i] = reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      item.GetName(),
+				Namespace: item.GetNamespace(),
+			},
+		}
+	}
+	return requests
+}
+
+// findMultiStepChecksForGroup returns a reconcile request for every MultiStepCheck in the Group
+func (r *MultiStepCheckReconciler) findMultiStepChecksForGroup(ctx context.Context, group client.Object) []reconcile.Request {
+	multiStepChecks := &checklyv1alpha1.MultiStepCheckList{}
+	listOps := &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(multiStepCheckGroupField, group.GetName()),
+	}
+	err := r.List(ctx, multiStepChecks, listOps)
+	if err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, len(multiStepChecks.Items))
+	for i, item := range multiStepChecks.Items {
+		requests[i] = reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      item.GetName(),
+				Namespace: item.GetNamespace(),
+			},
+		}
+	}
+	return requests
+}
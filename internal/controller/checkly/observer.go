@@ -0,0 +1,59 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+// observerClient doesn't write to the cluster, the writes are sent as dry-run requests. The operator
+// resources it reads look like they have the finalizer, so the reconcilers don't stop after adding
+// it and go on comparing the resources with checklyhq.com
+type observerClient struct {
+	client.Client
+	finalizer string
+}
+
+// NewObserverClient wraps the client of the reconcilers for the --observe flag
+func NewObserverClient(c client.Client, controllerDomain string) client.Client {
+	return &observerClient{
+		Client:    client.NewDryRunClient(c),
+		finalizer: fmt.Sprintf("%s/finalizer", controllerDomain),
+	}
+}
+
+// Get implements client.Client
+func (c *observerClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	err := c.Client.Get(ctx, key, obj, opts...)
+	if err != nil {
+		return err
+	}
+
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err == nil && gvk.Group == checklyv1alpha1.GroupVersion.Group {
+		controllerutil.AddFinalizer(obj, c.finalizer)
+	}
+
+	return nil
+}
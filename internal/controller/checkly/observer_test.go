@@ -0,0 +1,77 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var _ = Describe("Observer", func() {
+
+	Context("NewObserverClient", func() {
+		It("Doesn't write to the cluster", func() {
+
+			group := &checklyv1alpha1.Group{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-observer-group",
+				},
+				Spec: checklyv1alpha1.GroupSpec{
+					Locations: []string{"eu-west-1"},
+				},
+			}
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-observer-secret",
+					Namespace: "default",
+				},
+			}
+			Expect(k8sClient.Create(context.Background(), group)).Should(Succeed())
+			Expect(k8sClient.Create(context.Background(), secret)).Should(Succeed())
+
+			observerClient := NewObserverClient(k8sClient, "testing.domain.tld")
+
+			By("Expecting the operator resources to have the finalizer")
+			observed := &checklyv1alpha1.Group{}
+			Expect(observerClient.Get(context.Background(), types.NamespacedName{Name: group.Name}, observed)).Should(Succeed())
+			Expect(controllerutil.ContainsFinalizer(observed, "testing.domain.tld/finalizer")).To(BeTrue())
+
+			By("Expecting other resources as they are")
+			observedSecret := &corev1.Secret{}
+			Expect(observerClient.Get(context.Background(), types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}, observedSecret)).Should(Succeed())
+			Expect(observedSecret.Finalizers).To(BeEmpty())
+
+			By("Expecting the writes not to be stored")
+			observed.Spec.Locations = []string{"us-east-1"}
+			Expect(observerClient.Update(context.Background(), observed)).Should(Succeed())
+			stored := &checklyv1alpha1.Group{}
+			Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: group.Name}, stored)).Should(Succeed())
+			Expect(stored.Spec.Locations).To(Equal([]string{"eu-west-1"}))
+
+			Expect(k8sClient.Delete(context.Background(), group)).Should(Succeed())
+			Expect(k8sClient.Delete(context.Background(), secret)).Should(Succeed())
+		})
+	})
+})
@@ -0,0 +1,244 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/checkly/checkly-go-sdk"
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+	external "github.com/checkly/checkly-operator/external/checkly"
+)
+
+// PrivateLocationReconciler reconciles a PrivateLocation object
+type PrivateLocationReconciler struct {
+	client.Client
+	Scheme           *runtime.Scheme
+	ApiClient        checkly.Client
+	ControllerDomain string
+	ResourceSelector labels.Selector
+	Recorder         record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=privatelocations,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=privatelocations/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=privatelocations/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;create
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.12.1/pkg/reconcile
+func (r *PrivateLocationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	privateLocationFinalizer := fmt.Sprintf("%s/finalizer", r.ControllerDomain)
+
+	privateLocation := &checklyv1alpha1.PrivateLocation{}
+
+	err := r.Get(ctx, req.NamespacedName, privateLocation)
+
+	// ////////////////////////////////
+	// Delete Logic
+	// ///////////////////////////////
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// The resource has been deleted
+			logger.V(1).Info("Deleted", "checkly PrivateLocation ID", privateLocation.Status.ID)
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object
+		logger.Error(err, "can't read the object")
+		return ctrl.Result{}, nil
+	}
+
+	// Writes skipped by --dry-run and --observe are recorded as Drift events of the private location
+	apiClient := external.DryRunClientFor(r.ApiClient, privateLocation)
+
+	// ////////////////////////////////
+	// Remove Finalizer Logic
+	// ///////////////////////////////
+
+	if privateLocation.GetDeletionTimestamp() != nil {
+		if controllerutil.ContainsFinalizer(privateLocation, privateLocationFinalizer) {
+			if privateLocation.Spec.DeletionPolicy == checklyv1alpha1.DeletionPolicyRetain {
+				logger.Info("Deletion policy is Retain, leaving the checkly PrivateLocation in place", "ID", privateLocation.Status.ID)
+			} else if privateLocation.Status.ID == "" {
+				logger.Info("Checkly PrivateLocation was never created, nothing to delete")
+			} else {
+				logger.V(1).Info("Finalizer is present, trying to delete Checkly PrivateLocation", "ID", privateLocation.Status.ID)
+				err := external.DeletePrivateLocation(privateLocation, apiClient)
+				if external.IsNotFound(err) {
+					logger.Info("Checkly PrivateLocation was already deleted", "ID", privateLocation.Status.ID)
+				} else if err != nil {
+					recordSyncFailed(r.Recorder, privateLocation, "delete", "private location", err)
+					logger.Error(err, "Failed to delete checkly PrivateLocation")
+					forced, retry, err := forceDelete(ctx, r.Recorder, r.ControllerDomain, privateLocation, "private location", privateLocation.Status.ID, err)
+					if !forced {
+						return retry, err
+					}
+				} else {
+					logger.V(1).Info("Successfully deleted checkly PrivateLocation", "ID", privateLocation.Status.ID)
+				}
+			}
+
+			controllerutil.RemoveFinalizer(privateLocation, privateLocationFinalizer)
+			err = r.Update(ctx, privateLocation)
+			if err != nil {
+				logger.Error(err, "Failed to delete finalizer.")
+				return ctrl.Result{}, err
+			}
+			logger.V(1).Info("Successfully deleted finalizer from PrivateLocation")
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// /////////////////////////////
+	// Add Finalizer logic
+	// ////////////////////////////
+	if !controllerutil.ContainsFinalizer(privateLocation, privateLocationFinalizer) {
+		controllerutil.AddFinalizer(privateLocation, privateLocationFinalizer)
+		err = r.Update(ctx, privateLocation)
+		if err != nil {
+			logger.Error(err, "Failed to update PrivateLocation status")
+			return ctrl.Result{}, err
+		}
+		logger.V(1).Info("Added finalizer", "checkly PrivateLocation ID", privateLocation.Status.ID)
+		return ctrl.Result{}, nil
+	}
+
+	// /////////////////////////////
+	// Update logic
+	// ////////////////////////////
+
+	// Determine if it's a new object or if it's an update to an existing object
+	if privateLocation.Status.ID != "" {
+		// Existing object, we need to update it
+		logger.V(1).Info("Existing object, with ID", "checkly PrivateLocation ID", privateLocation.Status.ID)
+		err := external.UpdatePrivateLocation(privateLocation, apiClient)
+		if err != nil {
+			recordSyncFailed(r.Recorder, privateLocation, "update", "private location", err)
+			logger.Error(err, "Failed to update checkly PrivateLocation")
+			return ctrl.Result{}, err
+		}
+		recordUpdated(r.Recorder, apiClient, privateLocation, "private location", privateLocation.Status.ID)
+		logger.V(1).Info("Updated checkly PrivateLocation", "ID", privateLocation.Status.ID)
+		return ctrl.Result{}, nil
+	}
+
+	// /////////////////////////////
+	// Create logic
+	// ////////////////////////////
+	privateLocationID, key, err := external.CreatePrivateLocation(privateLocation, apiClient)
+	if err != nil {
+		recordSyncFailed(r.Recorder, privateLocation, "create", "private location", err)
+		logger.Error(err, "Failed to create checkly PrivateLocation")
+		return ctrl.Result{}, err
+	}
+	recordCreated(r.Recorder, apiClient, privateLocation, "private location", privateLocationID)
+
+	// The raw key is only returned on creation, store it before anything else can fail
+	var secretErr error
+	if privateLocation.Spec.KeySecret != nil {
+		secretErr = r.createKeySecret(ctx, privateLocation, key.RawKey)
+		if secretErr != nil {
+			logger.Error(secretErr, "Failed to create PrivateLocation key secret", "name", privateLocation.Spec.KeySecret.Name, "namespace", privateLocation.Spec.KeySecret.Namespace)
+		}
+	}
+
+	// Update the custom resource Status with the returned ID
+	privateLocation.Status.ID = privateLocationID
+	privateLocation.Status.MaskedKey = key.MaskedKey
+	err = r.Status().Update(ctx, privateLocation)
+	if err != nil {
+		logger.Error(err, "Failed to update PrivateLocation status", "ID", privateLocation.Status.ID)
+		return ctrl.Result{}, err
+	}
+	logger.V(1).Info("New checkly PrivateLocation created", "ID", privateLocation.Status.ID)
+
+	return ctrl.Result{}, secretErr
+}
+
+// createKeySecret creates the Secret holding the API key of the private location, the Secret is owned by the PrivateLocation
+func (r *PrivateLocationReconciler) createKeySecret(ctx context.Context, privateLocation *checklyv1alpha1.PrivateLocation, rawKey string) (err error) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      privateLocation.Spec.KeySecret.Name,
+			Namespace: privateLocation.Spec.KeySecret.Namespace,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			"API_KEY": []byte(rawKey),
+		},
+	}
+
+	err = controllerutil.SetControllerReference(privateLocation, secret, r.Scheme)
+	if err != nil {
+		return
+	}
+
+	err = r.Create(ctx, secret)
+
+	return
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PrivateLocationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&checklyv1alpha1.PrivateLocation{}, builder.WithPredicates(ResourceSelectorPredicate(r.ResourceSelector))).
+		Complete(rateLimitRequeue{r})
+}
+
+// privateLocationSlugs returns the slug names of the PrivateLocation resources, ready is false
+// if one of the private locations hasn't been created in checklyhq.com yet
+func privateLocationSlugs(ctx context.Context, c client.Client, names []string) (slugs []string, ready bool, err error) {
+	ready = true
+
+	for _, name := range names {
+		privateLocation := &checklyv1alpha1.PrivateLocation{}
+		err = c.Get(ctx, types.NamespacedName{Name: name}, privateLocation)
+		if err != nil {
+			return
+		}
+		if privateLocation.Status.ID == "" {
+			ready = false
+		}
+
+		slugName := privateLocation.Spec.SlugName
+		if slugName == "" {
+			slugName = privateLocation.Name
+		}
+		slugs = append(slugs, slugName)
+	}
+
+	return
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("PrivateLocation Controller", func() {
+
+	// Define utility constants for object names and testing timeouts/durations and intervals.
+	const (
+		timeout  = time.Second * 10
+		duration = time.Second * 10
+		interval = time.Millisecond * 250
+	)
+
+	BeforeEach(func() {
+		// Add any setup steps that needs to be executed before each test
+	})
+
+	AfterEach(func() {
+		// Add any teardown steps that needs to be executed after each test
+	})
+
+	Context("PrivateLocation", func() {
+		It("Full reconciliation", func() {
+
+			key := types.NamespacedName{
+				Name: "test-privatelocation",
+			}
+
+			secretKey := types.NamespacedName{
+				Name:      "test-privatelocation-key",
+				Namespace: "default",
+			}
+
+			privatelocation := &checklyv1alpha1.PrivateLocation{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: key.Name,
+				},
+				Spec: checklyv1alpha1.PrivateLocationSpec{
+					KeySecret: &corev1.SecretReference{
+						Name:      secretKey.Name,
+						Namespace: secretKey.Namespace,
+					},
+				},
+			}
+
+			// Create
+			Expect(k8sClient.Create(context.Background(), privatelocation)).Should(Succeed())
+
+			By("Expecting submitted")
+			Eventually(func() bool {
+				f := &checklyv1alpha1.PrivateLocation{}
+				err := k8sClient.Get(context.Background(), key, f)
+				if err != nil {
+					return false
+				}
+				return true
+			}, timeout, interval).Should(BeTrue())
+
+			// Status.ID should be present
+			By("Expecting private location ID")
+			Eventually(func() bool {
+				f := &checklyv1alpha1.PrivateLocation{}
+				err := k8sClient.Get(context.Background(), key, f)
+				if f.Status.ID == "abc" && err == nil {
+					return true
+				}
+				return false
+			}, timeout, interval).Should(BeTrue())
+
+			// Key secret should be present
+			By("Expecting key secret")
+			Eventually(func() bool {
+				f := &corev1.Secret{}
+				err := k8sClient.Get(context.Background(), secretKey, f)
+				if err != nil {
+					return false
+				}
+
+				if string(f.Data["API_KEY"]) != "pl_raw" {
+					return false
+				}
+
+				return true
+			}, timeout, interval).Should(BeTrue())
+
+			// Finalizer should be present
+			By("Expecting finalizer")
+			Eventually(func() bool {
+				f := &checklyv1alpha1.PrivateLocation{}
+				err := k8sClient.Get(context.Background(), key, f)
+				if err != nil {
+					return false
+				}
+
+				for _, finalizer := range f.Finalizers {
+					Expect(finalizer).To(Equal("testing.domain.tld/finalizer"), "Finalizer should match")
+				}
+
+				return true
+			}, timeout, interval).Should(BeTrue())
+
+			// Update
+			updated := &checklyv1alpha1.PrivateLocation{}
+			Expect(k8sClient.Get(context.Background(), key, updated)).Should(Succeed())
+
+			updated.Spec.Icon = "cloud"
+			Expect(k8sClient.Update(context.Background(), updated)).Should(Succeed())
+
+			By("Expecting update")
+			Eventually(func() bool {
+				f := &checklyv1alpha1.PrivateLocation{}
+				err := k8sClient.Get(context.Background(), key, f)
+				if f.Spec.Icon == "cloud" && err == nil {
+					return true
+				}
+				return false
+			}, timeout, interval).Should(BeTrue())
+
+			// Delete
+			By("Expecting to delete successfully")
+			Eventually(func() error {
+				f := &checklyv1alpha1.PrivateLocation{}
+				k8sClient.Get(context.Background(), key, f)
+				return k8sClient.Delete(context.Background(), f)
+			}, timeout, interval).Should(Succeed())
+
+			By("Expecting delete to finish")
+			Eventually(func() error {
+				f := &checklyv1alpha1.PrivateLocation{}
+				return k8sClient.Get(context.Background(), key, f)
+			}, timeout, interval).ShouldNot(Succeed())
+		})
+	})
+
+	Context("privateLocationSlugs", func() {
+		It("Resolves the slug names", func() {
+
+			privateLocation := &checklyv1alpha1.PrivateLocation{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-privatelocation-slugs",
+				},
+				Spec: checklyv1alpha1.PrivateLocationSpec{
+					SlugName: "test-slug",
+				},
+			}
+
+			By("Expecting an error for a missing private location")
+			_, _, err := privateLocationSlugs(context.Background(), k8sClient, []string{privateLocation.Name})
+			Expect(err).To(HaveOccurred())
+
+			Expect(k8sClient.Create(context.Background(), privateLocation)).Should(Succeed())
+
+			By("Expecting the slug name once the private location is created")
+			Eventually(func() bool {
+				slugs, ready, err := privateLocationSlugs(context.Background(), k8sClient, []string{privateLocation.Name})
+				return err == nil && ready && len(slugs) == 1 && slugs[0] == "test-slug"
+			}, timeout, interval).Should(BeTrue())
+
+			Expect(k8sClient.Delete(context.Background(), privateLocation)).Should(Succeed())
+		})
+	})
+})
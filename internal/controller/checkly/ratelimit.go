@@ -0,0 +1,45 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	external "github.com/checkly/checkly-operator/external/checkly"
+)
+
+// rateLimitRequeue requeues the reconciles which failed on the checklyhq.com rate limit of their account
+// once it resets, instead of retrying them with the backoff of failed reconciles
+type rateLimitRequeue struct {
+	reconcile.Reconciler
+}
+
+// Reconcile implements reconcile.Reconciler
+func (r rateLimitRequeue) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	result, err := r.Reconciler.Reconcile(ctx, req)
+	rateLimitErr, ok := external.AsRateLimitError(err)
+	if !ok {
+		return result, err
+	}
+
+	log.FromContext(ctx).V(1).Info("Rate limit of checklyhq.com reached, requeueing", "after", rateLimitErr.RetryAfter)
+	return ctrl.Result{RequeueAfter: rateLimitErr.RetryAfter}, nil
+}
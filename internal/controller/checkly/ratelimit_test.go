@@ -0,0 +1,54 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	external "github.com/checkly/checkly-operator/external/checkly"
+)
+
+var _ = Describe("Rate limit", func() {
+
+	It("Requeues the rate limited reconciles once the rate limit resets", func() {
+
+		var err error
+		r := rateLimitRequeue{reconcile.Func(func(context.Context, reconcile.Request) (ctrl.Result, error) {
+			return ctrl.Result{}, err
+		})}
+
+		// The SDK only keeps the message of the error
+		err = fmt.Errorf("HTTP request failed with: %v", &external.RateLimitError{RetryAfter: 7 * time.Second})
+		result, reconcileErr := r.Reconcile(context.Background(), reconcile.Request{})
+		Expect(reconcileErr).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(7 * time.Second))
+
+		By("Expecting other errors to fail the reconcile")
+		err = errors.New("foo")
+		result, reconcileErr = r.Reconcile(context.Background(), reconcile.Request{})
+		Expect(reconcileErr).To(MatchError("foo"))
+		Expect(result.RequeueAfter).To(BeZero())
+	})
+})
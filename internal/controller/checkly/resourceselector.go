@@ -0,0 +1,53 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// ResourceSelectorPredicate filters the events of the resources which don't match the
+// --resource-selector, a nil selector matches every resource
+func ResourceSelectorPredicate(selector labels.Selector) predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return selector == nil || selector.Matches(labels.Set(obj.GetLabels()))
+	})
+}
+
+// SetSelectorLabels copies the labels the selector depends on from the source to the check, the
+// checks created from a selected Ingress or Service are selected as well
+func SetSelectorLabels(selector labels.Selector, source client.Object, check client.Object) {
+	if selector == nil {
+		return
+	}
+
+	checkLabels := check.GetLabels()
+	requirements, _ := selector.Requirements()
+	for _, requirement := range requirements {
+		value, ok := source.GetLabels()[requirement.Key()]
+		if !ok {
+			continue
+		}
+		if checkLabels == nil {
+			checkLabels = map[string]string{}
+		}
+		checkLabels[requirement.Key()] = value
+	}
+	check.SetLabels(checkLabels)
+}
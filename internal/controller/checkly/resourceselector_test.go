@@ -0,0 +1,81 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+var _ = Describe("ResourceSelector", func() {
+
+	Context("ResourceSelectorPredicate", func() {
+		It("Filters resources", func() {
+
+			selector, err := labels.Parse("checkly-operator=canary")
+			Expect(err).ToNot(HaveOccurred())
+
+			selected := &checklyv1alpha1.ApiCheck{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"checkly-operator": "canary"},
+				},
+			}
+			other := &checklyv1alpha1.ApiCheck{}
+
+			By("Expecting the selected resources to pass")
+			Expect(ResourceSelectorPredicate(selector).Create(event.CreateEvent{Object: selected})).To(BeTrue())
+			Expect(ResourceSelectorPredicate(selector).Create(event.CreateEvent{Object: other})).To(BeFalse())
+
+			By("Expecting every resource to pass without a selector")
+			Expect(ResourceSelectorPredicate(nil).Create(event.CreateEvent{Object: other})).To(BeTrue())
+		})
+	})
+
+	Context("SetSelectorLabels", func() {
+		It("Copies the selected labels", func() {
+
+			selector, err := labels.Parse("checkly-operator=canary,!legacy")
+			Expect(err).ToNot(HaveOccurred())
+
+			ingress := &networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"checkly-operator": "canary", "app": "foo"},
+				},
+			}
+			apiCheck := &checklyv1alpha1.ApiCheck{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"team": "a"},
+				},
+			}
+
+			SetSelectorLabels(selector, ingress, apiCheck)
+			Expect(apiCheck.Labels).To(Equal(map[string]string{"checkly-operator": "canary", "team": "a"}))
+			Expect(selector.Matches(labels.Set(apiCheck.Labels))).To(BeTrue())
+
+			By("Expecting no labels without a selector")
+			apiCheck = &checklyv1alpha1.ApiCheck{}
+			SetSelectorLabels(nil, ingress, apiCheck)
+			Expect(apiCheck.Labels).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,174 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/checkly/checkly-go-sdk"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+	external "github.com/checkly/checkly-operator/external/checkly"
+)
+
+// The labels of the check result metrics
+var checkResultLabels = []string{"kind", "namespace", "name", "location"}
+
+var (
+	checkPassed = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "checkly_operator_check_passed",
+		Help: "Whether the latest result of the check in the location passed, 1 or 0",
+	}, checkResultLabels)
+	checkDegraded = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "checkly_operator_check_degraded",
+		Help: "Whether the latest result of the check in the location was degraded, 1 or 0",
+	}, checkResultLabels)
+	checkResponseTime = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "checkly_operator_check_response_time_seconds",
+		Help: "Response time of the latest result of the check in the location",
+	}, checkResultLabels)
+)
+
+func init() {
+	metrics.Registry.MustRegister(checkPassed, checkDegraded, checkResponseTime)
+}
+
+// ResultsPoller periodically reads the latest results of the checks managed by the operator from
+// checklyhq.com and exposes them as metrics
+type ResultsPoller struct {
+	client.Client
+	ApiClient checkly.Client
+	Interval  time.Duration
+}
+
+// polledCheck is a check the poller reads the results of
+type polledCheck struct {
+	kind      string
+	namespace string
+	name      string
+	account   string
+	id        string
+}
+
+// Start implements manager.Runnable, it polls until the context is cancelled
+func (p *ResultsPoller) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, p.poll, p.Interval)
+	return nil
+}
+
+// poll replaces the check result metrics with the latest results
+func (p *ResultsPoller) poll(ctx context.Context) {
+	logger := log.FromContext(ctx).WithName("results-poller")
+
+	checks, err := p.polledChecks(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to list the checks")
+		return
+	}
+
+	results := make(map[polledCheck][]checkly.CheckResult, len(checks))
+	for _, check := range checks {
+		apiClient, _, err := apiClientForAccountName(ctx, p.Client, p.ApiClient, check.account, check.namespace)
+		if err != nil {
+			logger.Error(err, "Unable to read credentials of the account", "account", check.account)
+			continue
+		}
+
+		checkResults, err := external.LatestCheckResults(check.id, apiClient)
+		if err != nil {
+			logger.Error(err, "Failed to read the check results", "kind", check.kind, "namespace", check.namespace, "name", check.name)
+			continue
+		}
+		results[check] = checkResults
+	}
+
+	// Removed checks and locations drop out of the metrics
+	checkPassed.Reset()
+	checkDegraded.Reset()
+	checkResponseTime.Reset()
+	for check, checkResults := range results {
+		for _, result := range checkResults {
+			labels := prometheus.Labels{"kind": check.kind, "namespace": check.namespace, "name": check.name, "location": result.RunLocation}
+			checkPassed.With(labels).Set(boolValue(!result.HasFailures && !result.HasErrors))
+			checkDegraded.With(labels).Set(boolValue(result.IsDegraded))
+			checkResponseTime.With(labels).Set(float64(result.ResponseTime) / 1000)
+		}
+	}
+	logger.V(1).Info("Polled check results", "checks", len(results))
+}
+
+// polledChecks returns the checks created in checklyhq.com
+func (p *ResultsPoller) polledChecks(ctx context.Context) (checks []polledCheck, err error) {
+	apiChecks := &checklyv1alpha1.ApiCheckList{}
+	err = p.List(ctx, apiChecks)
+	if err != nil {
+		return
+	}
+	for _, check := range apiChecks.Items {
+		checks = append(checks, polledCheck{"ApiCheck", check.Namespace, check.Name, check.Spec.Account, check.Status.ID})
+	}
+
+	clusterApiChecks := &checklyv1alpha1.ClusterApiCheckList{}
+	err = p.List(ctx, clusterApiChecks)
+	if err != nil {
+		return
+	}
+	for _, check := range clusterApiChecks.Items {
+		checks = append(checks, polledCheck{"ClusterApiCheck", "", check.Name, check.Spec.Account, check.Status.ID})
+	}
+
+	browserChecks := &checklyv1alpha1.BrowserCheckList{}
+	err = p.List(ctx, browserChecks)
+	if err != nil {
+		return
+	}
+	for _, check := range browserChecks.Items {
+		checks = append(checks, polledCheck{"BrowserCheck", check.Namespace, check.Name, check.Spec.Account, check.Status.ID})
+	}
+
+	multiStepChecks := &checklyv1alpha1.MultiStepCheckList{}
+	err = p.List(ctx, multiStepChecks)
+	if err != nil {
+		return
+	}
+	for _, check := range multiStepChecks.Items {
+		checks = append(checks, polledCheck{"MultiStepCheck", check.Namespace, check.Name, check.Spec.Account, check.Status.ID})
+	}
+
+	// Checks which aren't created yet have no results
+	checks = slices.DeleteFunc(checks, func(check polledCheck) bool {
+		return check.id == ""
+	})
+
+	return
+}
+
+// boolValue returns the gauge value of a boolean
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
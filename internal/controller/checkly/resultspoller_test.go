@@ -0,0 +1,83 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/checkly/checkly-go-sdk"
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("ResultsPoller", func() {
+
+	It("Exposes the latest check results", func() {
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/v1/check-results/1", func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`[{"id":"a","runLocation":"eu-west-1","isDegraded":true,"responseTime":1500,"startedAt":"2024-01-01T10:00:00Z"}]`))
+		})
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		apiCheck := &checklyv1alpha1.ApiCheck{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-resultspoller-check",
+				Namespace: "default",
+			},
+			Spec: checklyv1alpha1.ApiCheckSpec{
+				Endpoint: "http://bar.baz/quoz",
+				Success:  "200",
+				Group:    "test-resultspoller-group",
+			},
+		}
+		Expect(k8sClient.Create(context.Background(), apiCheck)).Should(Succeed())
+		apiCheck.Status.ID = "1"
+		Expect(k8sClient.Status().Update(context.Background(), apiCheck)).Should(Succeed())
+
+		poller := &ResultsPoller{
+			Client:    k8sClient,
+			ApiClient: checkly.NewClient(server.URL, "foobarbaz", nil, nil),
+			Interval:  time.Minute,
+		}
+		poller.poll(context.Background())
+
+		Expect(testutil.ToFloat64(checkPassed.WithLabelValues("ApiCheck", "default", apiCheck.Name, "eu-west-1"))).To(Equal(float64(1)))
+		Expect(testutil.ToFloat64(checkDegraded.WithLabelValues("ApiCheck", "default", apiCheck.Name, "eu-west-1"))).To(Equal(float64(1)))
+		Expect(testutil.ToFloat64(checkResponseTime.WithLabelValues("ApiCheck", "default", apiCheck.Name, "eu-west-1"))).To(Equal(1.5))
+
+		By("Expecting checks without results to drop out of the metrics")
+		Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(apiCheck), apiCheck)).Should(Succeed())
+		apiCheck.Status.ID = ""
+		Expect(k8sClient.Status().Update(context.Background(), apiCheck)).Should(Succeed())
+		poller.poll(context.Background())
+		Expect(testutil.CollectAndCount(checkPassed)).To(Equal(0))
+
+		Expect(k8sClient.Delete(context.Background(), apiCheck)).Should(Succeed())
+	})
+})
@@ -0,0 +1,32 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// resyncJitter is the fraction of the resync interval added at random, so resources synced at the same time,
+// ex. when the operator starts, drift apart instead of reaching checklyhq.com together on every resync
+const resyncJitter = 0.1
+
+// resyncAfter returns when the resource is pushed to checklyhq.com again, 0 doesn't resync it
+func resyncAfter(interval time.Duration) time.Duration {
+	return wait.Jitter(interval, resyncJitter)
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Resync", func() {
+
+	It("Spreads the resyncs over a tenth of the interval", func() {
+
+		resyncs := map[time.Duration]bool{}
+		for i := 0; i < 20; i++ {
+			after := resyncAfter(time.Hour)
+			Expect(after).To(BeNumerically(">=", time.Hour))
+			Expect(after).To(BeNumerically("<=", time.Hour+6*time.Minute))
+			resyncs[after] = true
+		}
+		Expect(len(resyncs)).To(BeNumerically(">", 1))
+
+		By("Expecting no resync without an interval")
+		Expect(resyncAfter(0)).To(BeZero())
+	})
+})
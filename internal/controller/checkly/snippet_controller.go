@@ -0,0 +1,256 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"context"
+	errs "errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/checkly/checkly-go-sdk"
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+	external "github.com/checkly/checkly-operator/external/checkly"
+)
+
+// snippetConfigMapField is the field index used to find Snippets referencing a ConfigMap
+const snippetConfigMapField = ".spec.configmap"
+
+// SnippetReconciler reconciles a Snippet object
+type SnippetReconciler struct {
+	client.Client
+	Scheme           *runtime.Scheme
+	ApiClient        checkly.Client
+	ControllerDomain string
+	ResourceSelector labels.Selector
+	Recorder         record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=snippets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=snippets/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=snippets/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.12.1/pkg/reconcile
+func (r *SnippetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	snippetFinalizer := fmt.Sprintf("%s/finalizer", r.ControllerDomain)
+
+	snippet := &checklyv1alpha1.Snippet{}
+
+	err := r.Get(ctx, req.NamespacedName, snippet)
+
+	// ////////////////////////////////
+	// Delete Logic
+	// ///////////////////////////////
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// The resource has been deleted
+			logger.V(1).Info("Deleted", "checkly Snippet ID", snippet.Status.ID)
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object
+		logger.Error(err, "can't read the object")
+		return ctrl.Result{}, nil
+	}
+
+	// Writes skipped by --dry-run and --observe are recorded as Drift events of the snippet
+	apiClient := external.DryRunClientFor(r.ApiClient, snippet)
+
+	// ////////////////////////////////
+	// Remove Finalizer Logic
+	// ///////////////////////////////
+
+	if snippet.GetDeletionTimestamp() != nil {
+		if controllerutil.ContainsFinalizer(snippet, snippetFinalizer) {
+			if snippet.Spec.DeletionPolicy == checklyv1alpha1.DeletionPolicyRetain {
+				logger.Info("Deletion policy is Retain, leaving the checkly Snippet in place", "ID", snippet.Status.ID)
+			} else if snippet.Status.ID == 0 {
+				logger.Info("Checkly Snippet was never created, nothing to delete")
+			} else {
+				logger.V(1).Info("Finalizer is present, trying to delete Checkly Snippet", "ID", snippet.Status.ID)
+				err := external.DeleteSnippet(snippet, apiClient)
+				if external.IsNotFound(err) {
+					logger.Info("Checkly Snippet was already deleted", "ID", snippet.Status.ID)
+				} else if err != nil {
+					recordSyncFailed(r.Recorder, snippet, "delete", "snippet", err)
+					logger.Error(err, "Failed to delete checkly Snippet")
+					forced, retry, err := forceDelete(ctx, r.Recorder, r.ControllerDomain, snippet, "snippet", snippet.Status.ID, err)
+					if !forced {
+						return retry, err
+					}
+				} else {
+					logger.V(1).Info("Successfully deleted checkly Snippet", "ID", snippet.Status.ID)
+				}
+			}
+
+			controllerutil.RemoveFinalizer(snippet, snippetFinalizer)
+			err = r.Update(ctx, snippet)
+			if err != nil {
+				logger.Error(err, "Failed to delete finalizer.")
+				return ctrl.Result{}, err
+			}
+			logger.V(1).Info("Successfully deleted finalizer from Snippet")
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// /////////////////////////////
+	// Add Finalizer logic
+	// ////////////////////////////
+	if !controllerutil.ContainsFinalizer(snippet, snippetFinalizer) {
+		controllerutil.AddFinalizer(snippet, snippetFinalizer)
+		err = r.Update(ctx, snippet)
+		if err != nil {
+			logger.Error(err, "Failed to update Snippet status")
+			return ctrl.Result{}, err
+		}
+		logger.V(1).Info("Added finalizer", "checkly Snippet ID", snippet.Status.ID)
+		return ctrl.Result{}, nil
+	}
+
+	// /////////////////////////////
+	// Script lookup
+	// ////////////////////////////
+	script := snippet.Spec.Script
+	if snippet.Spec.ConfigMap != (corev1.ObjectReference{}) {
+		configMap := &corev1.ConfigMap{}
+		err := r.Get(ctx,
+			types.NamespacedName{
+				Name:      snippet.Spec.ConfigMap.Name,
+				Namespace: snippet.Spec.ConfigMap.Namespace},
+			configMap)
+		if err != nil {
+			logger.Error(err, "Unable to read configmap for script")
+			return ctrl.Result{}, err
+		}
+
+		script = configMap.Data[snippet.Spec.ConfigMap.FieldPath]
+	}
+
+	if script == "" {
+		scriptErr := errs.New("script is empty")
+		logger.Error(scriptErr, "Please add a script inline or through a configmap")
+		return ctrl.Result{}, scriptErr
+	}
+
+	// /////////////////////////////
+	// Update logic
+	// ////////////////////////////
+
+	// Determine if it's a new object or if it's an update to an existing object
+	if snippet.Status.ID != 0 {
+		// Existing object, we need to update it
+		logger.V(1).Info("Existing object, with ID", "checkly Snippet ID", snippet.Status.ID)
+		err := external.UpdateSnippet(snippet, script, apiClient)
+		if err != nil {
+			recordSyncFailed(r.Recorder, snippet, "update", "snippet", err)
+			logger.Error(err, "Failed to update checkly Snippet")
+			return ctrl.Result{}, err
+		}
+		recordUpdated(r.Recorder, apiClient, snippet, "snippet", snippet.Status.ID)
+		logger.V(1).Info("Updated checkly Snippet", "ID", snippet.Status.ID)
+		return ctrl.Result{}, nil
+	}
+
+	// /////////////////////////////
+	// Create logic
+	// ////////////////////////////
+	snippetID, err := external.CreateSnippet(snippet, script, apiClient)
+	if err != nil {
+		recordSyncFailed(r.Recorder, snippet, "create", "snippet", err)
+		logger.Error(err, "Failed to create checkly Snippet")
+		return ctrl.Result{}, err
+	}
+	recordCreated(r.Recorder, apiClient, snippet, "snippet", snippetID)
+
+	// Update the custom resource Status with the returned ID
+	snippet.Status.ID = snippetID
+	err = r.Status().Update(ctx, snippet)
+	if err != nil {
+		logger.Error(err, "Failed to update Snippet status", "ID", snippet.Status.ID)
+		return ctrl.Result{}, err
+	}
+	logger.V(1).Info("New checkly Snippet created", "ID", snippet.Status.ID)
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SnippetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Index Snippets by the ConfigMap they read their script from so
+	// changes to the ConfigMap trigger a reconciliation of the snippet
+	err := mgr.GetFieldIndexer().IndexField(context.Background(), &checklyv1alpha1.Snippet{}, snippetConfigMapField, func(rawObj client.Object) []string {
+		snippet := rawObj.(*checklyv1alpha1.Snippet)
+		if snippet.Spec.ConfigMap.Name == "" {
+			return nil
+		}
+		return []string{types.NamespacedName{Name: snippet.Spec.ConfigMap.Name, Namespace: snippet.Spec.ConfigMap.Namespace}.String()}
+	})
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&checklyv1alpha1.Snippet{}, builder.WithPredicates(ResourceSelectorPredicate(r.ResourceSelector))).
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.findSnippetsForConfigMap),
+		).
+		Complete(rateLimitRequeue{r})
+}
+
+// findSnippetsForConfigMap returns a reconcile request for every Snippet which references the ConfigMap
+func (r *SnippetReconciler) findSnippetsForConfigMap(ctx context.Context, configMap client.Object) []reconcile.Request {
+	snippets := &checklyv1alpha1.SnippetList{}
+	listOps := &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(snippetConfigMapField, types.NamespacedName{Name: configMap.GetName(), Namespace: configMap.GetNamespace()}.String()),
+	}
+	err := r.List(ctx, snippets, listOps)
+	if err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, len(snippets.Items))
+	for i, item := range snippets.Items {
+		requests[i] = reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name: item.GetName(),
+			},
+		}
+	}
+	return requests
+}
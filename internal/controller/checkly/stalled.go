@@ -0,0 +1,118 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+// stalledThreshold is the number of consecutive failed reconciles after which a resource is stalled
+const stalledThreshold = 5
+
+// reasonConsecutiveFailures is the reason of the Stalled condition
+const reasonConsecutiveFailures = "ConsecutiveFailures"
+
+// stalledResources counts the resources which became stalled
+var stalledResources = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "checkly_operator_stalled_total",
+	Help: "Number of times a resource failed to sync with checklyhq.com on consecutive reconciles and became stalled by kind",
+}, []string{"kind"})
+
+func init() {
+	metrics.Registry.MustRegister(stalledResources)
+}
+
+// consecutiveFailures counts the failed reconciles of the resources since their last successful one. The
+// counts are kept in memory, a status update per failure would trigger another reconcile right away and
+// bypass the backoff of the failed ones, so a restarted operator starts counting again.
+var consecutiveFailures = &failureCounter{failures: map[string]int{}}
+
+// failureCounter counts the consecutive failed reconciles by resource, see failureKey
+type failureCounter struct {
+	mu       sync.Mutex
+	failures map[string]int
+}
+
+// observe counts the outcome of a reconcile and returns the consecutive failures, a success resets them
+func (f *failureCounter) observe(key string, failed bool) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !failed {
+		delete(f.failures, key)
+		return 0
+	}
+	f.failures[key]++
+
+	return f.failures[key]
+}
+
+// forget drops the failures of a deleted resource
+func (f *failureCounter) forget(key string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.failures, key)
+}
+
+// failureKey identifies the resource by its type and name rather than its UID, so the failures are
+// forgotten by reconciles which don't find the resource anymore
+func failureKey(obj client.Object, name types.NamespacedName) string {
+	return fmt.Sprintf("%T %s", obj, name)
+}
+
+// forgetFailures drops the failures of the deleted resource, obj only has to be of its type
+func forgetFailures(obj client.Object, name types.NamespacedName) {
+	consecutiveFailures.forget(failureKey(obj, name))
+}
+
+// setStalled counts the outcome of a reconcile of the resource and sets the Stalled condition once it failed
+// stalledThreshold times in a row, only a successful reconcile removes it again. It returns whether the
+// conditions changed
+func setStalled(conditions *[]metav1.Condition, obj client.Object, kind string, err error) (changed bool) {
+	failures := consecutiveFailures.observe(failureKey(obj, client.ObjectKeyFromObject(obj)), err != nil)
+	if failures == 0 {
+		return meta.RemoveStatusCondition(conditions, checklyv1alpha1.ConditionStalled)
+	}
+	// A resource which stalled before a restart of the operator stays stalled until it succeeds
+	if failures < stalledThreshold {
+		return false
+	}
+
+	if !meta.IsStatusConditionTrue(*conditions, checklyv1alpha1.ConditionStalled) {
+		stalledResources.WithLabelValues(kind).Inc()
+	}
+	_, message := syncFailure(err)
+
+	return meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               checklyv1alpha1.ConditionStalled,
+		Status:             metav1.ConditionTrue,
+		Reason:             reasonConsecutiveFailures,
+		Message:            fmt.Sprintf("At least %d consecutive reconciles failed, the latest with: %s", stalledThreshold, message),
+		ObservedGeneration: obj.GetGeneration(),
+	})
+}
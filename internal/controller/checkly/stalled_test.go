@@ -0,0 +1,69 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+var _ = Describe("Stalled", func() {
+
+	It("Sets the Stalled condition after consecutive failures", func() {
+
+		var conditions []metav1.Condition
+		apiCheck := &checklyv1alpha1.ApiCheck{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-stalled", Namespace: "default", Generation: 1},
+		}
+		failure := errors.New("unexpected response status 401: \"Unauthorized\"")
+
+		By("Expecting no condition below the threshold")
+		for i := 1; i < stalledThreshold; i++ {
+			Expect(setStalled(&conditions, apiCheck, "check", failure)).To(BeFalse())
+		}
+		Expect(conditions).To(BeEmpty())
+
+		By("Expecting the condition once the threshold is reached")
+		Expect(setStalled(&conditions, apiCheck, "check", failure)).To(BeTrue())
+		stalled := meta.FindStatusCondition(conditions, checklyv1alpha1.ConditionStalled)
+		Expect(stalled).NotTo(BeNil())
+		Expect(stalled.Status).To(Equal(metav1.ConditionTrue))
+		Expect(stalled.Reason).To(Equal(reasonConsecutiveFailures))
+		Expect(setStalled(&conditions, apiCheck, "check", failure)).To(BeFalse())
+
+		By("Expecting a success to remove the condition")
+		Expect(setStalled(&conditions, apiCheck, "check", nil)).To(BeTrue())
+		Expect(conditions).To(BeEmpty())
+		Expect(setStalled(&conditions, apiCheck, "check", failure)).To(BeFalse())
+
+		By("Expecting the failures of a deleted resource to be forgotten")
+		forgetFailures(&checklyv1alpha1.ApiCheck{}, client.ObjectKeyFromObject(apiCheck))
+		for i := 1; i < stalledThreshold; i++ {
+			Expect(setStalled(&conditions, apiCheck, "check", failure)).To(BeFalse())
+		}
+		Expect(conditions).To(BeEmpty())
+		forgetFailures(apiCheck, client.ObjectKeyFromObject(apiCheck))
+	})
+})
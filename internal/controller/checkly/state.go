@@ -0,0 +1,54 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"slices"
+
+	"github.com/checkly/checkly-go-sdk"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+	external "github.com/checkly/checkly-operator/external/checkly"
+)
+
+// setChecklyState records the state returned by checklyhq.com after an update and returns whether it
+// changed. Every update bumps the updatedAt of checklyhq.com, so the time is only taken over together
+// with another change, otherwise each status update would trigger yet another reconcile. In dry-run
+// mode nothing is updated and there's no state to record.
+func setChecklyState(recorded **checklyv1alpha1.ChecklyState, apiClient checkly.Client, state external.State) bool {
+	if external.IsDryRun(apiClient) {
+		return false
+	}
+
+	current := *recorded
+	if current != nil && current.Activated == state.Activated && current.Muted == state.Muted && slices.Equal(current.Locations, state.Locations) {
+		return false
+	}
+
+	checklyState := &checklyv1alpha1.ChecklyState{
+		Activated: state.Activated,
+		Muted:     state.Muted,
+		Locations: state.Locations,
+	}
+	if !state.UpdatedAt.IsZero() {
+		checklyState.LastUpdatedAt = &metav1.Time{Time: state.UpdatedAt}
+	}
+	*recorded = checklyState
+
+	return true
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkly
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/checkly/checkly-go-sdk"
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+
+	external "github.com/checkly/checkly-operator/external/checkly"
+)
+
+var _ = Describe("Checkly state", func() {
+
+	It("Records the state returned by checklyhq.com", func() {
+
+		apiClient := checkly.NewClient("http://localhost:5555", "foo", nil, nil)
+		updatedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		state := external.State{Activated: true, Locations: []string{"eu-west-1"}, UpdatedAt: updatedAt}
+
+		var recorded *checklyv1alpha1.ChecklyState
+		Expect(setChecklyState(&recorded, apiClient, state)).To(BeTrue())
+		Expect(recorded.Activated).To(BeTrue())
+		Expect(recorded.Muted).To(BeFalse())
+		Expect(recorded.Locations).To(Equal([]string{"eu-west-1"}))
+		Expect(recorded.LastUpdatedAt.Time).To(Equal(updatedAt))
+
+		By("Expecting the time of an unchanged state to be kept")
+		state.UpdatedAt = updatedAt.Add(time.Minute)
+		Expect(setChecklyState(&recorded, apiClient, state)).To(BeFalse())
+		Expect(recorded.LastUpdatedAt.Time).To(Equal(updatedAt))
+
+		By("Expecting a changed state to be recorded")
+		state.Muted = true
+		Expect(setChecklyState(&recorded, apiClient, state)).To(BeTrue())
+		Expect(recorded.Muted).To(BeTrue())
+		Expect(recorded.LastUpdatedAt.Time).To(Equal(updatedAt.Add(time.Minute)))
+
+		By("Expecting nothing to be recorded in dry-run mode")
+		recorded = nil
+		Expect(setChecklyState(&recorded, external.NewDryRunClient(apiClient), state)).To(BeFalse())
+		Expect(recorded).To(BeNil())
+	})
+})
@@ -99,6 +99,48 @@ var _ = BeforeSuite(func() {
 			w.Write(jsonResp)
 			return
 		})
+		http.HandleFunc("/v1/checks/browser", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			w.Header().Set("Content-Type", "application/json")
+			resp := make(map[string]string)
+			resp["id"] = "2"
+			jsonResp, _ := json.Marshal(resp)
+			w.Write(jsonResp)
+			return
+		})
+		http.HandleFunc("/v1/checks/multistep", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			w.Header().Set("Content-Type", "application/json")
+			resp := make(map[string]string)
+			resp["id"] = "2"
+			jsonResp, _ := json.Marshal(resp)
+			w.Write(jsonResp)
+			return
+		})
+		http.HandleFunc("/v1/checks/heartbeat", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			w.Header().Set("Content-Type", "application/json")
+			resp := make(map[string]interface{})
+			resp["id"] = "4"
+			resp["heartbeat"] = map[string]string{"pingToken": "test-token"}
+			jsonResp, _ := json.Marshal(resp)
+			w.Write(jsonResp)
+			return
+		})
+		http.HandleFunc("/v1/checks/heartbeat/4", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			w.Header().Set("Content-Type", "application/json")
+			resp := make(map[string]interface{})
+			resp["id"] = "4"
+			resp["heartbeat"] = map[string]string{"pingToken": "test-token"}
+			jsonResp, _ := json.Marshal(resp)
+			w.Write(jsonResp)
+			return
+		})
+		http.HandleFunc("/v1/checks/4", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		})
 		http.HandleFunc("/v1/checks/2", func(w http.ResponseWriter, r *http.Request) {
 			r.ParseForm()
 			method := r.Method
@@ -165,6 +207,123 @@ var _ = BeforeSuite(func() {
 			}
 			return
 		})
+		http.HandleFunc("/v1/dashboards", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			w.Header().Set("Content-Type", "application/json")
+			resp := make(map[string]interface{})
+			resp["dashboardId"] = "abc"
+			jsonResp, _ := json.Marshal(resp)
+			w.Write(jsonResp)
+			return
+		})
+		http.HandleFunc("/v1/dashboards/abc", func(w http.ResponseWriter, r *http.Request) {
+			r.ParseForm()
+			method := r.Method
+			switch method {
+			case "PUT":
+				w.WriteHeader(http.StatusOK)
+				w.Header().Set("Content-Type", "application/json")
+				resp := make(map[string]interface{})
+				resp["dashboardId"] = "abc"
+				jsonResp, _ := json.Marshal(resp)
+				w.Write(jsonResp)
+			case "DELETE":
+				w.WriteHeader(http.StatusNoContent)
+			}
+			return
+		})
+		http.HandleFunc("/v1/private-locations", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			w.Header().Set("Content-Type", "application/json")
+			resp := make(map[string]interface{})
+			resp["id"] = "abc"
+			resp["keys"] = []map[string]string{{"rawKey": "pl_raw", "maskedKey": "pl_***"}}
+			jsonResp, _ := json.Marshal(resp)
+			w.Write(jsonResp)
+			return
+		})
+		http.HandleFunc("/v1/private-locations/abc", func(w http.ResponseWriter, r *http.Request) {
+			r.ParseForm()
+			method := r.Method
+			switch method {
+			case "PUT":
+				w.WriteHeader(http.StatusOK)
+				w.Header().Set("Content-Type", "application/json")
+				resp := make(map[string]interface{})
+				resp["id"] = "abc"
+				jsonResp, _ := json.Marshal(resp)
+				w.Write(jsonResp)
+			case "DELETE":
+				w.WriteHeader(http.StatusNoContent)
+			}
+			return
+		})
+		http.HandleFunc("/v1/snippets", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			w.Header().Set("Content-Type", "application/json")
+			resp := make(map[string]interface{})
+			resp["id"] = 6
+			jsonResp, _ := json.Marshal(resp)
+			w.Write(jsonResp)
+			return
+		})
+		http.HandleFunc("/v1/snippets/6", func(w http.ResponseWriter, r *http.Request) {
+			r.ParseForm()
+			method := r.Method
+			switch method {
+			case "PUT":
+				w.WriteHeader(http.StatusOK)
+				w.Header().Set("Content-Type", "application/json")
+				resp := make(map[string]interface{})
+				resp["id"] = 6
+				jsonResp, _ := json.Marshal(resp)
+				w.Write(jsonResp)
+			case "DELETE":
+				w.WriteHeader(http.StatusNoContent)
+			}
+			return
+		})
+		http.HandleFunc("/v1/variables", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			w.Header().Set("Content-Type", "application/json")
+			resp := make(map[string]interface{})
+			resp["key"] = "TEST_KEY"
+			jsonResp, _ := json.Marshal(resp)
+			w.Write(jsonResp)
+			return
+		})
+		http.HandleFunc("/v1/variables/TEST_KEY", func(w http.ResponseWriter, r *http.Request) {
+			r.ParseForm()
+			method := r.Method
+			switch method {
+			case "PUT":
+				w.WriteHeader(http.StatusOK)
+				w.Header().Set("Content-Type", "application/json")
+				resp := make(map[string]interface{})
+				resp["key"] = "TEST_KEY"
+				jsonResp, _ := json.Marshal(resp)
+				w.Write(jsonResp)
+			case "DELETE":
+				w.WriteHeader(http.StatusNoContent)
+			}
+			return
+		})
+		http.HandleFunc("/v1/triggers/check-groups/1", func(w http.ResponseWriter, r *http.Request) {
+			r.ParseForm()
+			method := r.Method
+			switch method {
+			case "POST":
+				w.WriteHeader(http.StatusCreated)
+				w.Header().Set("Content-Type", "application/json")
+				resp := make(map[string]interface{})
+				resp["token"] = "trigger"
+				jsonResp, _ := json.Marshal(resp)
+				w.Write(jsonResp)
+			case "DELETE":
+				w.WriteHeader(http.StatusNoContent)
+			}
+			return
+		})
 		http.ListenAndServe(":5555", nil)
 	}()
 
@@ -194,6 +353,78 @@ var _ = BeforeSuite(func() {
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
+	err = (&BrowserCheckReconciler{
+		Client:           k8sManager.GetClient(),
+		Scheme:           k8sManager.GetScheme(),
+		ApiClient:        testClient,
+		ControllerDomain: testControllerDomain,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&HeartbeatCheckReconciler{
+		Client:           k8sManager.GetClient(),
+		Scheme:           k8sManager.GetScheme(),
+		ApiClient:        testClient,
+		ControllerDomain: testControllerDomain,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&MultiStepCheckReconciler{
+		Client:           k8sManager.GetClient(),
+		Scheme:           k8sManager.GetScheme(),
+		ApiClient:        testClient,
+		ControllerDomain: testControllerDomain,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&DashboardReconciler{
+		Client:           k8sManager.GetClient(),
+		Scheme:           k8sManager.GetScheme(),
+		ApiClient:        testClient,
+		ControllerDomain: testControllerDomain,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&PrivateLocationReconciler{
+		Client:           k8sManager.GetClient(),
+		Scheme:           k8sManager.GetScheme(),
+		ApiClient:        testClient,
+		ControllerDomain: testControllerDomain,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&SnippetReconciler{
+		Client:           k8sManager.GetClient(),
+		Scheme:           k8sManager.GetScheme(),
+		ApiClient:        testClient,
+		ControllerDomain: testControllerDomain,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&EnvironmentVariableReconciler{
+		Client:           k8sManager.GetClient(),
+		Scheme:           k8sManager.GetScheme(),
+		ApiClient:        testClient,
+		ControllerDomain: testControllerDomain,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&CheckTriggerReconciler{
+		Client:           k8sManager.GetClient(),
+		Scheme:           k8sManager.GetScheme(),
+		ApiClient:        testClient,
+		ControllerDomain: testControllerDomain,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&ClusterApiCheckReconciler{
+		Client:           k8sManager.GetClient(),
+		Scheme:           k8sManager.GetScheme(),
+		ApiClient:        testClient,
+		ControllerDomain: testControllerDomain,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
 	go func() {
 		defer GinkgoRecover()
 		err = k8sManager.Start(ctrl.SetupSignalHandler())
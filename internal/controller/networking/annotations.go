@@ -0,0 +1,117 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networking
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+// gatherApiCheckData builds the ApiCheck spec from the annotations of a networking resource,
+// defaultBaseURL is used as the scheme and host of the endpoint unless the endpoint annotation is set
+func gatherApiCheckData(controllerDomain string, annotations map[string]string, defaultBaseURL string) (apiCheckSpec checklyv1alpha1.ApiCheckSpec, err error) {
+
+	annotationHost := controllerDomain
+	annotationPath := fmt.Sprintf("%s/path", annotationHost)
+	annotationEndpoint := fmt.Sprintf("%s/endpoint", annotationHost)
+	annotationSuccess := fmt.Sprintf("%s/success", annotationHost)
+	annotationGroup := fmt.Sprintf("%s/group", annotationHost)
+	annotationMuted := fmt.Sprintf("%s/muted", annotationHost)
+	annotationAccount := fmt.Sprintf("%s/account", annotationHost)
+	annotationHeaders := fmt.Sprintf("%s/headers", annotationHost)
+	annotationAssertions := fmt.Sprintf("%s/assertions", annotationHost)
+	annotationDeletionPolicy := fmt.Sprintf("%s/deletion-policy", annotationHost)
+
+	// Construct the endpoint
+	path := ""
+	if annotations[annotationPath] != "" {
+		path = annotations[annotationPath]
+	}
+
+	var baseURL string
+	if annotations[annotationEndpoint] == "" {
+		baseURL = defaultBaseURL
+	} else {
+		baseURL = fmt.Sprintf("https://%s", annotations[annotationEndpoint])
+	}
+
+	endpoint := fmt.Sprintf("%s%s", baseURL, path)
+
+	// Expected success code
+	var success string
+	if annotations[annotationSuccess] != "" {
+		success = annotations[annotationSuccess]
+	} else {
+		success = "200"
+	}
+
+	// Group
+	var group string
+	if annotations[annotationGroup] != "" {
+		group = annotations[annotationGroup]
+	} else {
+		err = fmt.Errorf("could not find a value for the group annotation, can't continue without one")
+	}
+
+	// Muted
+	var muted bool
+	if annotations[annotationMuted] == "false" {
+		muted = false
+	} else {
+		muted = true
+	}
+
+	// Headers, a JSON object of header names and values
+	var headers []checklyv1alpha1.HTTPHeader
+	if annotations[annotationHeaders] != "" {
+		headerMap := make(map[string]string)
+		if jsonErr := json.Unmarshal([]byte(annotations[annotationHeaders]), &headerMap); jsonErr != nil {
+			err = fmt.Errorf("could not parse the headers annotation: %w", jsonErr)
+		}
+		for key, value := range headerMap {
+			headers = append(headers, checklyv1alpha1.HTTPHeader{Key: key, Value: value})
+		}
+		// Map iteration is random, keep the order stable so the ApiCheck isn't updated needlessly
+		sort.Slice(headers, func(i, j int) bool { return headers[i].Key < headers[j].Key })
+	}
+
+	// Assertions, a JSON list in the same format as the ApiCheck spec
+	var assertions []checklyv1alpha1.Assertion
+	if annotations[annotationAssertions] != "" {
+		if jsonErr := json.Unmarshal([]byte(annotations[annotationAssertions]), &assertions); jsonErr != nil {
+			err = fmt.Errorf("could not parse the assertions annotation: %w", jsonErr)
+		}
+	}
+
+	apiCheckSpec = checklyv1alpha1.ApiCheckSpec{
+		Endpoint:   endpoint,
+		Group:      group,
+		Success:    success,
+		Muted:      muted,
+		Account:    annotations[annotationAccount],
+		Headers:    headers,
+		Assertions: assertions,
+
+		DeletionPolicy: annotations[annotationDeletionPolicy],
+	}
+
+	// Last return
+	return
+}
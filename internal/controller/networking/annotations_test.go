@@ -0,0 +1,61 @@
+package networking
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+var _ = Describe("Annotations", func() {
+
+	Context("gatherApiCheckData", func() {
+		It("parses the headers and assertions annotations", func() {
+			annotations := map[string]string{
+				"testing.domain.tld/group":      "group",
+				"testing.domain.tld/headers":    `{"X-Foo": "foo", "Accept": "application/json"}`,
+				"testing.domain.tld/assertions": `[{"source": "JSON_BODY", "property": "$.status", "comparison": "EQUALS", "target": "ok"}]`,
+			}
+
+			apiCheckSpec, err := gatherApiCheckData("testing.domain.tld", annotations, "https://foo.bar")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(apiCheckSpec.Endpoint).To(Equal("https://foo.bar"))
+			Expect(apiCheckSpec.Headers).To(Equal([]checklyv1alpha1.HTTPHeader{
+				{Key: "Accept", Value: "application/json"},
+				{Key: "X-Foo", Value: "foo"},
+			}))
+			Expect(apiCheckSpec.Assertions).To(Equal([]checklyv1alpha1.Assertion{
+				{Source: "JSON_BODY", Property: "$.status", Comparison: "EQUALS", Target: "ok"},
+			}))
+		})
+
+		It("reads the deletion policy annotation", func() {
+			annotations := map[string]string{
+				"testing.domain.tld/group":           "group",
+				"testing.domain.tld/deletion-policy": "Retain",
+			}
+
+			apiCheckSpec, err := gatherApiCheckData("testing.domain.tld", annotations, "https://foo.bar")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(apiCheckSpec.DeletionPolicy).To(Equal(checklyv1alpha1.DeletionPolicyRetain))
+		})
+
+		It("fails on invalid JSON", func() {
+			annotations := map[string]string{
+				"testing.domain.tld/group":   "group",
+				"testing.domain.tld/headers": `["X-Foo"]`,
+			}
+
+			_, err := gatherApiCheckData("testing.domain.tld", annotations, "https://foo.bar")
+			Expect(err).To(HaveOccurred())
+
+			annotations = map[string]string{
+				"testing.domain.tld/group":      "group",
+				"testing.domain.tld/assertions": `{"source": "JSON_BODY"}`,
+			}
+
+			_, err = gatherApiCheckData("testing.domain.tld", annotations, "https://foo.bar")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,193 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networking
+
+import (
+	"context"
+	"fmt"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+	checklycontrollers "github.com/checkly/checkly-operator/internal/controller/checkly"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// HTTPRouteReconciler reconciles a Gateway API HTTPRoute object
+type HTTPRouteReconciler struct {
+	client.Client
+	Scheme           *runtime.Scheme
+	ControllerDomain string
+	ResourceSelector labels.Selector
+}
+
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=apichecks,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.0/pkg/reconcile
+func (r *HTTPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	logger.Info("Reconciler started")
+
+	httpRoute := &gatewayv1.HTTPRoute{}
+	apiCheck := &checklyv1alpha1.ApiCheck{}
+
+	annotationEnabled := fmt.Sprintf("%s/enabled", r.ControllerDomain)
+	annotationPath := fmt.Sprintf("%s/path", r.ControllerDomain)
+	annotationEndpoint := fmt.Sprintf("%s/endpoint", r.ControllerDomain)
+
+	// Check if httproute object is still present
+	err := r.Get(ctx, req.NamespacedName, httpRoute)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			logger.Info("HTTPRoute got deleted")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Can't read the HTTPRoute object")
+		return ctrl.Result{}, err
+	}
+	logger.Info("HTTPRoute Object found")
+
+	// Check if annotation is present on the object
+	checklyAnnotation := httpRoute.Annotations[annotationEnabled] == "true"
+	if !checklyAnnotation {
+		// Annotation may have been removed or updated, we have to determine if we need to delete a previously created ApiCheck resource
+		logger.Info("annotation is not present, checking if ApiCheck was created")
+		err = r.Get(ctx, req.NamespacedName, apiCheck)
+		if err != nil {
+			logger.Info("Apicheck not present")
+			return ctrl.Result{}, nil
+		}
+		if !metav1.IsControlledBy(apiCheck, httpRoute) {
+			logger.Info("ApiCheck is not owned by the HTTPRoute, leaving it alone")
+			return ctrl.Result{}, nil
+		}
+		logger.Info("ApiCheck is present, but we need to delete it")
+		err = r.Delete(ctx, apiCheck)
+		if err != nil {
+			logger.Info("Failed to delete ApiCheck")
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	if len(httpRoute.Spec.Hostnames) == 0 && httpRoute.Annotations[annotationEndpoint] == "" {
+		logger.Info("HTTPRoute has no hostnames, set the endpoint annotation to monitor it")
+		return ctrl.Result{}, nil
+	}
+
+	// The path annotation takes precedence over the path of the first route rule
+	annotations := make(map[string]string, len(httpRoute.Annotations)+1)
+	for k, v := range httpRoute.Annotations {
+		annotations[k] = v
+	}
+	if annotations[annotationPath] == "" {
+		annotations[annotationPath] = httpRoutePath(httpRoute)
+	}
+
+	baseURL := ""
+	if len(httpRoute.Spec.Hostnames) != 0 {
+		baseURL = fmt.Sprintf("https://%s", httpRoute.Spec.Hostnames[0])
+	}
+
+	// Gather data for the checkly check
+	apiCheckSpec, err := gatherApiCheckData(r.ControllerDomain, annotations, baseURL)
+	if err != nil {
+		logger.Info("unable to gather data for the apiCheck resource")
+		return ctrl.Result{}, err
+	}
+
+	// Check and see if the ApiCheck has been created before
+	err = r.Get(ctx, req.NamespacedName, apiCheck)
+	if err == nil {
+		if !metav1.IsControlledBy(apiCheck, httpRoute) {
+			logger.Info("ApiCheck with the same name exists and is not owned by the HTTPRoute, skipping", "name", apiCheck.Name)
+			return ctrl.Result{}, nil
+		}
+		logger.Info("apiCheck exists, doing an update")
+		// We can reference the exiting apiCheck object that the server returned
+		apiCheck.Spec = apiCheckSpec
+		checklycontrollers.SetSelectorLabels(r.ResourceSelector, httpRoute, apiCheck)
+		err = r.Update(ctx, apiCheck)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Create apiCheck
+	// We need to write the k8s spec resources as it is a new object
+	newApiCheck := &checklyv1alpha1.ApiCheck{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      httpRoute.Name,
+			Namespace: httpRoute.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(httpRoute, gatewayv1.SchemeGroupVersion.WithKind("HTTPRoute")),
+			},
+		},
+		Spec: apiCheckSpec,
+	}
+
+	checklycontrollers.SetSelectorLabels(r.ResourceSelector, httpRoute, newApiCheck)
+
+	err = r.Create(ctx, newApiCheck)
+	if err != nil {
+		logger.Info("Failed to create ApiCheck", "err", err)
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *HTTPRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&gatewayv1.HTTPRoute{}, builder.WithPredicates(checklycontrollers.ResourceSelectorPredicate(r.ResourceSelector))).
+		Complete(r)
+}
+
+// httpRoutePath returns the path of the first Exact or PathPrefix match of the HTTPRoute,
+// regular expression matches can't be turned into an URL and are skipped
+func httpRoutePath(httpRoute *gatewayv1.HTTPRoute) string {
+	for _, rule := range httpRoute.Spec.Rules {
+		for _, match := range rule.Matches {
+			if match.Path == nil || match.Path.Value == nil {
+				continue
+			}
+			if match.Path.Type != nil && *match.Path.Type == gatewayv1.PathMatchRegularExpression {
+				continue
+			}
+			if *match.Path.Value == "/" {
+				return ""
+			}
+			return *match.Path.Value
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,41 @@
+package networking
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+var _ = Describe("HTTPRoute Controller", func() {
+
+	Context("httpRoutePath", func() {
+		It("returns the path of the first usable match", func() {
+			prefix := gatewayv1.PathMatchPathPrefix
+			regex := gatewayv1.PathMatchRegularExpression
+			root := "/"
+			api := "/api"
+			pattern := "/v[0-9]+"
+
+			httpRoute := &gatewayv1.HTTPRoute{}
+			Expect(httpRoutePath(httpRoute)).To(Equal(""))
+
+			httpRoute.Spec.Rules = []gatewayv1.HTTPRouteRule{
+				{
+					Matches: []gatewayv1.HTTPRouteMatch{
+						{Path: &gatewayv1.HTTPPathMatch{Type: &regex, Value: &pattern}},
+					},
+				},
+				{
+					Matches: []gatewayv1.HTTPRouteMatch{
+						{Path: &gatewayv1.HTTPPathMatch{Type: &prefix, Value: &api}},
+					},
+				},
+			}
+			Expect(httpRoutePath(httpRoute)).To(Equal("/api"))
+
+			httpRoute.Spec.Rules[1].Matches[0].Path.Value = &root
+			Expect(httpRoutePath(httpRoute)).To(Equal(""))
+		})
+	})
+})
@@ -18,23 +18,39 @@ package networking
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+	checklycontrollers "github.com/checkly/checkly-operator/internal/controller/checkly"
 	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
 
+// ingressClassAnnotation is the deprecated annotation which predates spec.ingressClassName
+const ingressClassAnnotation = "kubernetes.io/ingress.class"
+
 // IngressReconciler reconciles a Ingress object
 type IngressReconciler struct {
 	client.Client
 	Scheme           *runtime.Scheme
 	ControllerDomain string
+	// ResourceSelector limits the reconciler to resources with matching labels, all resources are reconciled if nil
+	ResourceSelector labels.Selector
+	// IngressClasses limits the reconciler to ingresses of these classes, all ingresses are watched if empty
+	IngressClasses []string
+	// DeletionPolicy is the deletion policy of the created checks if the ingress has no deletion-policy annotation
+	DeletionPolicy string
 }
 
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;update;patch
@@ -42,6 +58,7 @@ type IngressReconciler struct {
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses/finalizers,verbs=update
 //+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=apichecks,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=apichecks/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=browserchecks,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -53,9 +70,10 @@ func (r *IngressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	logger.Info("Reconciler started")
 
 	ingress := &networkingv1.Ingress{}
-	apiCheck := &checklyv1alpha1.ApiCheck{}
 
 	annotationEnabled := fmt.Sprintf("%s/enabled", r.ControllerDomain)
+	annotationCheckPerPath := fmt.Sprintf("%s/check-per-path", r.ControllerDomain)
+	annotationCheckType := fmt.Sprintf("%s/check-type", r.ControllerDomain)
 
 	// Check if ingress object is still present
 	err := r.Get(ctx, req.NamespacedName, ingress)
@@ -69,131 +87,319 @@ func (r *IngressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	}
 	logger.Info("Ingress Object found")
 
-	// Check if annotation is present on the object
-	checklyAnnotation := ingress.Annotations[annotationEnabled] == "true"
+	// Check if annotation is present on the object, ingresses of other classes are handled as if it was missing
+	checklyAnnotation := ingress.Annotations[annotationEnabled] == "true" && r.watchesClass(ingress)
 	if !checklyAnnotation {
-		// Annotation may have been removed or updated, we have to determine if we need to delete a previously created ApiCheck resource
-		logger.Info("annotation is not present, checking if ApiCheck was created")
-		err = r.Get(ctx, req.NamespacedName, apiCheck)
-		if err != nil {
-			logger.Info("Apicheck not present")
-			return ctrl.Result{}, nil
-		}
-		logger.Info("ApiCheck is present, but we need to delete it")
-		err = r.Delete(ctx, apiCheck)
+		// Annotation may have been removed or updated, we have to determine if we need to delete previously created check resources
+		logger.Info("annotation is not present, checking if checks were created")
+		err = r.deleteStaleChecks(ctx, ingress, nil, nil)
 		if err != nil {
-			logger.Info("Failed to delete ApiCheck")
+			logger.Info("Failed to delete checks")
 			return ctrl.Result{}, err
-			// }
 		}
 
 		return ctrl.Result{}, nil
 	}
 
-	// Gather data for the checkly check
-	apiCheckSpec, err := r.gatherApiCheckData(ingress)
+	// Gather data for the checkly checks
+	var apiChecks []ingressApiCheck
+	if ingress.Annotations[annotationCheckPerPath] == "true" {
+		apiChecks, err = gatherPathApiChecks(r.ControllerDomain, ingress)
+	} else {
+		var apiCheck ingressApiCheck
+		apiCheck, err = gatherHostApiCheck(r.ControllerDomain, ingress)
+		apiChecks = []ingressApiCheck{apiCheck}
+	}
 	if err != nil {
 		logger.Info("unable to gather data for the apiCheck resource")
 		return ctrl.Result{}, err
 	}
+	for i := range apiChecks {
+		if apiChecks[i].spec.DeletionPolicy == "" {
+			apiChecks[i].spec.DeletionPolicy = r.DeletionPolicy
+		}
+	}
 
-	// Check and see if the ApiCheck has been created before
-	err = r.Get(ctx, req.NamespacedName, apiCheck)
-	if err == nil {
-		logger.Info("apiCheck exists, doing an update")
-		// We can reference the exiting apiCheck object that the server returned
-		apiCheck.Spec = apiCheckSpec
-		err = r.Update(ctx, apiCheck)
+	// Browser checks replace the API checks for frontend facing hosts
+	browser := ingress.Annotations[annotationCheckType] == "browser"
+
+	// The check type, the check mode or the path rules might have changed, remove checks we no longer need
+	keepApiChecks := make(map[string]bool, len(apiChecks))
+	keepBrowserChecks := make(map[string]bool, len(apiChecks))
+	for _, desired := range apiChecks {
+		if browser {
+			keepBrowserChecks[desired.name] = true
+		} else {
+			keepApiChecks[desired.name] = true
+		}
+	}
+	err = r.deleteStaleChecks(ctx, ingress, keepApiChecks, keepBrowserChecks)
+	if err != nil {
+		logger.Info("Failed to delete stale checks")
+		return ctrl.Result{}, err
+	}
+
+	for _, desired := range apiChecks {
+		if browser {
+			browserCheck := &checklyv1alpha1.BrowserCheck{}
+			browserCheckSpec := ingressBrowserCheckSpec(desired.spec)
+			err = r.applyCheck(ctx, ingress, desired.name, browserCheck, func() { browserCheck.Spec = browserCheckSpec })
+		} else {
+			apiCheck := &checklyv1alpha1.ApiCheck{}
+			apiCheckSpec := desired.spec
+			err = r.applyCheck(ctx, ingress, desired.name, apiCheck, func() { apiCheck.Spec = apiCheckSpec })
+		}
 		if err != nil {
+			logger.Info("Failed to create or update check", "name", desired.name, "err", err)
 			return ctrl.Result{}, err
 		}
-		return ctrl.Result{}, nil
 	}
 
-	// Create apiCheck
+	return ctrl.Result{}, nil
+}
+
+// applyCheck creates or updates a check owned by the ingress, check is an empty object of
+// the check kind and setSpec writes the desired spec into it
+func (r *IngressReconciler) applyCheck(ctx context.Context, ingress *networkingv1.Ingress, name string, check client.Object, setSpec func()) error {
+	logger := log.FromContext(ctx)
+
+	// Check and see if the check has been created before
+	err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: ingress.Namespace}, check)
+	if err == nil {
+		if !metav1.IsControlledBy(check, ingress) {
+			logger.Info("Check with the same name exists and is not owned by the Ingress, skipping", "name", name)
+			return nil
+		}
+		logger.Info("check exists, doing an update", "name", name)
+		// We can reference the exiting object that the server returned
+		setSpec()
+		checklycontrollers.SetSelectorLabels(r.ResourceSelector, ingress, check)
+		return r.Update(ctx, check)
+	}
+	if !errors.IsNotFound(err) {
+		return err
+	}
+
 	// We need to write the k8s spec resources as it is a new object
-	newApiCheck := &checklyv1alpha1.ApiCheck{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      ingress.Name,
-			Namespace: ingress.Namespace,
-			OwnerReferences: []metav1.OwnerReference{
-				*metav1.NewControllerRef(ingress, networkingv1.SchemeGroupVersion.WithKind("ingress")),
-			},
-		},
-		Spec: apiCheckSpec,
+	check.SetName(name)
+	check.SetNamespace(ingress.Namespace)
+	check.SetOwnerReferences([]metav1.OwnerReference{
+		*metav1.NewControllerRef(ingress, networkingv1.SchemeGroupVersion.WithKind("ingress")),
+	})
+	setSpec()
+	checklycontrollers.SetSelectorLabels(r.ResourceSelector, ingress, check)
+
+	return r.Create(ctx, check)
+}
+
+// deleteStaleChecks deletes the ApiChecks and BrowserChecks owned by the ingress which are not in the keep lists
+func (r *IngressReconciler) deleteStaleChecks(ctx context.Context, ingress *networkingv1.Ingress, keepApiChecks, keepBrowserChecks map[string]bool) error {
+	logger := log.FromContext(ctx)
+
+	apiChecks := &checklyv1alpha1.ApiCheckList{}
+	err := r.List(ctx, apiChecks, client.InNamespace(ingress.Namespace))
+	if err != nil {
+		return err
 	}
 
-	err = r.Create(ctx, newApiCheck)
+	browserChecks := &checklyv1alpha1.BrowserCheckList{}
+	err = r.List(ctx, browserChecks, client.InNamespace(ingress.Namespace))
 	if err != nil {
-		logger.Info("Failed to create ApiCheck", "err", err)
-		return ctrl.Result{}, err
+		return err
 	}
 
-	return ctrl.Result{}, nil
+	var stale []client.Object
+	for i := range apiChecks.Items {
+		if !keepApiChecks[apiChecks.Items[i].Name] {
+			stale = append(stale, &apiChecks.Items[i])
+		}
+	}
+	for i := range browserChecks.Items {
+		if !keepBrowserChecks[browserChecks.Items[i].Name] {
+			stale = append(stale, &browserChecks.Items[i])
+		}
+	}
+
+	for _, check := range stale {
+		if !metav1.IsControlledBy(check, ingress) {
+			continue
+		}
+		logger.Info("Check is present, but we need to delete it", "name", check.GetName())
+		err = r.Delete(ctx, check)
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *IngressReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&networkingv1.Ingress{}).
+		For(&networkingv1.Ingress{}, builder.WithPredicates(checklycontrollers.ResourceSelectorPredicate(r.ResourceSelector), predicate.Funcs{
+			CreateFunc: func(e event.CreateEvent) bool {
+				return r.watchesClass(e.Object.(*networkingv1.Ingress))
+			},
+			// An ingress moved to another class still needs a reconcile to remove its checks
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				return r.watchesClass(e.ObjectOld.(*networkingv1.Ingress)) || r.watchesClass(e.ObjectNew.(*networkingv1.Ingress))
+			},
+			DeleteFunc: func(e event.DeleteEvent) bool {
+				return r.watchesClass(e.Object.(*networkingv1.Ingress))
+			},
+			GenericFunc: func(e event.GenericEvent) bool {
+				return r.watchesClass(e.Object.(*networkingv1.Ingress))
+			},
+		})).
 		Complete(r)
 }
 
-func (r *IngressReconciler) gatherApiCheckData(ingress *networkingv1.Ingress) (apiCheckSpec checklyv1alpha1.ApiCheckSpec, err error) {
+// watchesClass returns true if the class of the ingress is one of the watched ingress classes,
+// the class is read from spec.ingressClassName or the deprecated kubernetes.io/ingress.class annotation
+func (r *IngressReconciler) watchesClass(ingress *networkingv1.Ingress) bool {
+	if len(r.IngressClasses) == 0 {
+		return true
+	}
 
-	annotationHost := r.ControllerDomain
-	annotationPath := fmt.Sprintf("%s/path", annotationHost)
-	annotationEndpoint := fmt.Sprintf("%s/endpoint", annotationHost)
-	annotationSuccess := fmt.Sprintf("%s/success", annotationHost)
-	annotationGroup := fmt.Sprintf("%s/group", annotationHost)
-	annotationMuted := fmt.Sprintf("%s/muted", annotationHost)
+	class := ingress.Annotations[ingressClassAnnotation]
+	if ingress.Spec.IngressClassName != nil {
+		class = *ingress.Spec.IngressClassName
+	}
 
-	// Construct the endpoint
-	path := ""
-	if ingress.Annotations[annotationPath] != "" {
-		path = ingress.Annotations[annotationPath]
+	for _, watched := range r.IngressClasses {
+		if class == watched {
+			return true
+		}
 	}
 
-	var host string
-	if ingress.Annotations[annotationEndpoint] == "" {
+	return false
+}
+
+// ingressApiCheck is an ApiCheck the ingress should own
+type ingressApiCheck struct {
+	name string
+	spec checklyv1alpha1.ApiCheckSpec
+}
+
+// gatherHostApiCheck builds the ApiCheck of the first host of the ingress, named after the ingress. An
+// ingress without rules, only a default backend, has no host and needs the endpoint annotation
+func gatherHostApiCheck(controllerDomain string, ingress *networkingv1.Ingress) (apiCheck ingressApiCheck, err error) {
+	apiCheck.name = ingress.Name
+
+	var host, baseURL string
+	if len(ingress.Spec.Rules) > 0 {
 		host = ingress.Spec.Rules[0].Host
-	} else {
-		host = ingress.Annotations[annotationEndpoint]
+		baseURL = fmt.Sprintf("https://%s", host)
+	} else if ingress.Annotations[fmt.Sprintf("%s/endpoint", controllerDomain)] == "" {
+		err = fmt.Errorf("ingress %s has no rules, it needs the endpoint annotation", ingress.Name)
+		return
 	}
 
-	endpoint := fmt.Sprintf("https://%s%s", host, path)
+	annotations, err := hostAnnotations(controllerDomain, ingress.Annotations, host)
+	if err != nil {
+		return
+	}
+	apiCheck.spec, err = gatherApiCheckData(controllerDomain, annotations, baseURL)
 
-	// Expected success code
-	var success string
-	if ingress.Annotations[annotationSuccess] != "" {
-		success = ingress.Annotations[annotationSuccess]
-	} else {
-		success = "200"
+	return
+}
+
+// gatherPathApiChecks builds one ApiCheck per HTTP path rule of the ingress, the checks are
+// named after the ingress and the backend service of the path
+func gatherPathApiChecks(controllerDomain string, ingress *networkingv1.Ingress) (apiChecks []ingressApiCheck, err error) {
+	annotationPath := fmt.Sprintf("%s/path", controllerDomain)
+
+	names := make(map[string]int)
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			if path.Backend.Service == nil {
+				continue
+			}
+
+			var annotations map[string]string
+			annotations, err = hostAnnotations(controllerDomain, ingress.Annotations, rule.Host)
+			if err != nil {
+				return
+			}
+
+			// The path annotation doesn't make sense here, each check uses the path of its rule
+			annotations[annotationPath] = path.Path
+			if path.Path == "/" {
+				annotations[annotationPath] = ""
+			}
+
+			var spec checklyv1alpha1.ApiCheckSpec
+			spec, err = gatherApiCheckData(controllerDomain, annotations, fmt.Sprintf("https://%s", rule.Host))
+			if err != nil {
+				return
+			}
+
+			// The same service can be the backend of several paths
+			name := fmt.Sprintf("%s-%s", ingress.Name, path.Backend.Service.Name)
+			names[name]++
+			if names[name] > 1 {
+				name = fmt.Sprintf("%s-%d", name, names[name])
+			}
+
+			apiChecks = append(apiChecks, ingressApiCheck{name: name, spec: spec})
+		}
 	}
 
-	// Group
-	var group string
-	if ingress.Annotations[annotationGroup] != "" {
-		group = ingress.Annotations[annotationGroup]
-	} else {
-		err = fmt.Errorf("could not find a value for the group annotation, can't continue without one")
+	return
+}
+
+// ingressBrowserScript is the Playwright script of the browser checks created from ingresses,
+// it visits the endpoint and takes a screenshot
+const ingressBrowserScript = `const { expect, test } = require('@playwright/test')
+
+test('visit %[1]s', async ({ page }) => {
+  const response = await page.goto(%[1]q)
+  expect(response.status()).toBeLessThan(400)
+  await page.screenshot({ path: 'screenshot.jpg' })
+})
+`
+
+// ingressBrowserCheckSpec turns the ApiCheck gathered from the ingress annotations into a BrowserCheck
+func ingressBrowserCheckSpec(apiCheckSpec checklyv1alpha1.ApiCheckSpec) checklyv1alpha1.BrowserCheckSpec {
+	return checklyv1alpha1.BrowserCheckSpec{
+		Muted:   apiCheckSpec.Muted,
+		Script:  fmt.Sprintf(ingressBrowserScript, apiCheckSpec.Endpoint),
+		Group:   apiCheckSpec.Group,
+		Account: apiCheckSpec.Account,
+
+		DeletionPolicy: apiCheckSpec.DeletionPolicy,
 	}
+}
 
-	// Muted
-	var muted bool
-	if ingress.Annotations[annotationMuted] == "false" {
-		muted = false
-	} else {
-		muted = true
+// hostAnnotations returns a copy of the ingress annotations where the group annotation is replaced
+// by the group of the host from the host-groups annotation, a JSON object of hosts and group names
+func hostAnnotations(controllerDomain string, ingressAnnotations map[string]string, host string) (annotations map[string]string, err error) {
+	annotationGroup := fmt.Sprintf("%s/group", controllerDomain)
+	annotationHostGroups := fmt.Sprintf("%s/host-groups", controllerDomain)
+
+	annotations = make(map[string]string, len(ingressAnnotations)+1)
+	for k, v := range ingressAnnotations {
+		annotations[k] = v
+	}
+
+	if annotations[annotationHostGroups] == "" {
+		return
+	}
+
+	hostGroups := make(map[string]string)
+	err = json.Unmarshal([]byte(annotations[annotationHostGroups]), &hostGroups)
+	if err != nil {
+		err = fmt.Errorf("could not parse the host-groups annotation: %w", err)
+		return
 	}
 
-	apiCheckSpec = checklyv1alpha1.ApiCheckSpec{
-		Endpoint: endpoint,
-		Group:    group,
-		Success:  success,
-		Muted:    muted,
+	if hostGroups[host] != "" {
+		annotations[annotationGroup] = hostGroups[host]
 	}
 
-	// Last return
 	return
 }
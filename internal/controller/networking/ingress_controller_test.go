@@ -120,6 +120,7 @@ var _ = Describe("Ingress Controller", func() {
 			annotation["testing.domain.tld/endpoint"] = updateHost
 			annotation["testing.domain.tld/success"] = ""
 			annotation["testing.domain.tld/muted"] = "false"
+			annotation["testing.domain.tld/account"] = "test-account"
 			ingress = &networkingv1.Ingress{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:        key.Name,
@@ -160,6 +161,10 @@ var _ = Describe("Ingress Controller", func() {
 					return false
 				}
 
+				if f.Spec.Account != "test-account" {
+					return false
+				}
+
 				return true
 			}, timeout, interval).Should(BeTrue())
 
@@ -208,6 +213,188 @@ var _ = Describe("Ingress Controller", func() {
 
 		})
 
+		It("creates one ApiCheck per path rule", func() {
+			testHost := "foo.bar"
+			testGroup := "ingress-group"
+			pathType := networkingv1.PathTypePrefix
+
+			key := types.NamespacedName{
+				Name:      "test-path-ingress",
+				Namespace: "default",
+			}
+
+			annotation := make(map[string]string)
+			annotation["testing.domain.tld/enabled"] = "true"
+			annotation["testing.domain.tld/check-per-path"] = "true"
+			annotation["testing.domain.tld/group"] = testGroup
+
+			backend := func(name string) networkingv1.IngressBackend {
+				return networkingv1.IngressBackend{
+					Service: &networkingv1.IngressServiceBackend{
+						Name: name,
+						Port: networkingv1.ServiceBackendPort{
+							Number: 7777,
+						},
+					},
+				}
+			}
+
+			ingress := &networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        key.Name,
+					Namespace:   key.Namespace,
+					Annotations: annotation,
+				},
+				Spec: networkingv1.IngressSpec{
+					Rules: []networkingv1.IngressRule{
+						{
+							Host: testHost,
+							IngressRuleValue: networkingv1.IngressRuleValue{
+								HTTP: &networkingv1.HTTPIngressRuleValue{
+									Paths: []networkingv1.HTTPIngressPath{
+										{Path: "/", PathType: &pathType, Backend: backend("frontend")},
+										{Path: "/api", PathType: &pathType, Backend: backend("api")},
+										{Path: "/v2", PathType: &pathType, Backend: backend("api")},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			Expect(k8sClient.Create(context.Background(), ingress)).Should(Succeed())
+
+			expected := map[string]string{
+				"test-path-ingress-frontend": fmt.Sprintf("https://%s", testHost),
+				"test-path-ingress-api":      fmt.Sprintf("https://%s/api", testHost),
+				"test-path-ingress-api-2":    fmt.Sprintf("https://%s/v2", testHost),
+			}
+
+			By("Expecting an ApiCheck per path")
+			Eventually(func() bool {
+				for name, endpoint := range expected {
+					f := &checklyv1alpha1.ApiCheck{}
+					err := k8sClient.Get(context.Background(), types.NamespacedName{Name: name, Namespace: key.Namespace}, f)
+					if err != nil {
+						return false
+					}
+					if f.Spec.Endpoint != endpoint || f.Spec.Group != testGroup {
+						return false
+					}
+				}
+				return true
+			}, timeout, interval).Should(BeTrue())
+
+			// Switch back to a single check
+			By("Expecting the path checks to be replaced by a single check")
+			updated := &networkingv1.Ingress{}
+			Expect(k8sClient.Get(context.Background(), key, updated)).Should(Succeed())
+			delete(updated.Annotations, "testing.domain.tld/check-per-path")
+			Expect(k8sClient.Update(context.Background(), updated)).Should(Succeed())
+
+			Eventually(func() bool {
+				for name := range expected {
+					f := &checklyv1alpha1.ApiCheck{}
+					err := k8sClient.Get(context.Background(), types.NamespacedName{Name: name, Namespace: key.Namespace}, f)
+					if err == nil {
+						return false
+					}
+				}
+				f := &checklyv1alpha1.ApiCheck{}
+				return k8sClient.Get(context.Background(), key, f) == nil
+			}, timeout, interval).Should(BeTrue())
+
+			// Delete
+			By("Expecting to delete successfully")
+			Eventually(func() error {
+				f := &networkingv1.Ingress{}
+				k8sClient.Get(context.Background(), key, f)
+				return k8sClient.Delete(context.Background(), f)
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("creates a BrowserCheck with the browser check type", func() {
+			testHost := "foo.bar"
+			testGroup := "ingress-group"
+
+			key := types.NamespacedName{
+				Name:      "test-browser-ingress",
+				Namespace: "default",
+			}
+
+			annotation := make(map[string]string)
+			annotation["testing.domain.tld/enabled"] = "true"
+			annotation["testing.domain.tld/check-type"] = "browser"
+			annotation["testing.domain.tld/group"] = testGroup
+			annotation["testing.domain.tld/muted"] = "false"
+
+			ingress := &networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        key.Name,
+					Namespace:   key.Namespace,
+					Annotations: annotation,
+				},
+				Spec: networkingv1.IngressSpec{
+					Rules: []networkingv1.IngressRule{
+						{
+							Host: testHost,
+						},
+					},
+					DefaultBackend: &networkingv1.IngressBackend{
+						Service: &networkingv1.IngressServiceBackend{
+							Name: "test-service",
+							Port: networkingv1.ServiceBackendPort{
+								Number: 7777,
+							},
+						},
+					},
+				},
+			}
+
+			Expect(k8sClient.Create(context.Background(), ingress)).Should(Succeed())
+
+			By("Expecting BrowserCheck to exist")
+			Eventually(func() bool {
+				f := &checklyv1alpha1.BrowserCheck{}
+				err := k8sClient.Get(context.Background(), key, f)
+				if err != nil {
+					return false
+				}
+
+				Expect(f.Spec.Script).To(ContainSubstring(fmt.Sprintf("page.goto(\"https://%s\")", testHost)))
+				Expect(f.Spec.Group).To(Equal(testGroup))
+				Expect(f.Spec.Muted).To(BeFalse())
+				Expect(metav1.IsControlledBy(f, ingress)).To(BeTrue())
+
+				return true
+			}, timeout, interval).Should(BeTrue())
+
+			Expect(k8sClient.Get(context.Background(), key, &checklyv1alpha1.ApiCheck{})).ShouldNot(Succeed())
+
+			// Switch to an API check
+			By("Expecting the BrowserCheck to be replaced by an ApiCheck")
+			updated := &networkingv1.Ingress{}
+			Expect(k8sClient.Get(context.Background(), key, updated)).Should(Succeed())
+			delete(updated.Annotations, "testing.domain.tld/check-type")
+			Expect(k8sClient.Update(context.Background(), updated)).Should(Succeed())
+
+			Eventually(func() bool {
+				if k8sClient.Get(context.Background(), key, &checklyv1alpha1.BrowserCheck{}) == nil {
+					return false
+				}
+				return k8sClient.Get(context.Background(), key, &checklyv1alpha1.ApiCheck{}) == nil
+			}, timeout, interval).Should(BeTrue())
+
+			// Delete
+			By("Expecting to delete successfully")
+			Eventually(func() error {
+				f := &networkingv1.Ingress{}
+				k8sClient.Get(context.Background(), key, f)
+				return k8sClient.Delete(context.Background(), f)
+			}, timeout, interval).Should(Succeed())
+		})
+
 		// Testing failures
 		It("Some failures", func() {
 			testHost := "foo.bar"
@@ -276,4 +463,85 @@ var _ = Describe("Ingress Controller", func() {
 		})
 	})
 
+	Context("hostAnnotations", func() {
+		It("uses the group of the host", func() {
+			annotations := map[string]string{
+				"testing.domain.tld/group":       "default-group",
+				"testing.domain.tld/host-groups": `{"team-a.foo.bar": "team-a", "team-b.foo.bar": "team-b"}`,
+			}
+
+			got, err := hostAnnotations("testing.domain.tld", annotations, "team-a.foo.bar")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(got["testing.domain.tld/group"]).To(Equal("team-a"))
+
+			got, err = hostAnnotations("testing.domain.tld", annotations, "other.foo.bar")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(got["testing.domain.tld/group"]).To(Equal("default-group"))
+
+			// The ingress annotations are left alone
+			Expect(annotations["testing.domain.tld/group"]).To(Equal("default-group"))
+
+			annotations["testing.domain.tld/host-groups"] = `["team-a"]`
+			_, err = hostAnnotations("testing.domain.tld", annotations, "team-a.foo.bar")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("gatherHostApiCheck", func() {
+		It("needs the endpoint annotation without rules", func() {
+			ingress := &networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-default-backend",
+					Annotations: map[string]string{
+						"testing.domain.tld/group": "group",
+						"testing.domain.tld/path":  "/health",
+					},
+				},
+				Spec: networkingv1.IngressSpec{
+					DefaultBackend: &networkingv1.IngressBackend{
+						Service: &networkingv1.IngressServiceBackend{
+							Name: "test-service",
+							Port: networkingv1.ServiceBackendPort{Number: 7777},
+						},
+					},
+				},
+			}
+
+			_, err := gatherHostApiCheck("testing.domain.tld", ingress)
+			Expect(err).To(MatchError("ingress test-default-backend has no rules, it needs the endpoint annotation"))
+
+			ingress.Annotations["testing.domain.tld/endpoint"] = "foo.bar"
+			apiCheck, err := gatherHostApiCheck("testing.domain.tld", ingress)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(apiCheck.name).To(Equal("test-default-backend"))
+			Expect(apiCheck.spec.Endpoint).To(Equal("https://foo.bar/health"))
+		})
+	})
+
+	Context("watchesClass", func() {
+		It("filters ingresses by class", func() {
+			nginx := "nginx"
+			ingress := &networkingv1.Ingress{}
+
+			r := &IngressReconciler{}
+			Expect(r.watchesClass(ingress)).To(BeTrue())
+
+			r.IngressClasses = []string{"nginx", "internal"}
+			Expect(r.watchesClass(ingress)).To(BeFalse())
+
+			ingress.Spec.IngressClassName = &nginx
+			Expect(r.watchesClass(ingress)).To(BeTrue())
+
+			// The deprecated annotation is only used without spec.ingressClassName
+			ingress.Annotations = map[string]string{"kubernetes.io/ingress.class": "traefik"}
+			Expect(r.watchesClass(ingress)).To(BeTrue())
+
+			ingress.Spec.IngressClassName = nil
+			Expect(r.watchesClass(ingress)).To(BeFalse())
+
+			ingress.Annotations["kubernetes.io/ingress.class"] = "internal"
+			Expect(r.watchesClass(ingress)).To(BeTrue())
+		})
+	})
+
 })
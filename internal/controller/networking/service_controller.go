@@ -0,0 +1,197 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networking
+
+import (
+	"context"
+	"fmt"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+	checklycontrollers "github.com/checkly/checkly-operator/internal/controller/checkly"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// ServiceReconciler reconciles a Service object
+type ServiceReconciler struct {
+	client.Client
+	Scheme           *runtime.Scheme
+	ControllerDomain string
+	ResourceSelector labels.Selector
+}
+
+//+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=apichecks,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.0/pkg/reconcile
+func (r *ServiceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	logger.Info("Reconciler started")
+
+	service := &corev1.Service{}
+	apiCheck := &checklyv1alpha1.ApiCheck{}
+
+	annotationEnabled := fmt.Sprintf("%s/enabled", r.ControllerDomain)
+
+	// Check if service object is still present
+	err := r.Get(ctx, req.NamespacedName, service)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			logger.Info("Service got deleted")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Can't read the Service object")
+		return ctrl.Result{}, err
+	}
+	logger.Info("Service Object found")
+
+	// Check if annotation is present on the object
+	checklyAnnotation := service.Annotations[annotationEnabled] == "true"
+	if !checklyAnnotation {
+		// Annotation may have been removed or updated, we have to determine if we need to delete a previously created ApiCheck resource
+		logger.Info("annotation is not present, checking if ApiCheck was created")
+		err = r.Get(ctx, req.NamespacedName, apiCheck)
+		if err != nil {
+			logger.Info("Apicheck not present")
+			return ctrl.Result{}, nil
+		}
+		if !metav1.IsControlledBy(apiCheck, service) {
+			logger.Info("ApiCheck is not owned by the Service, leaving it alone")
+			return ctrl.Result{}, nil
+		}
+		logger.Info("ApiCheck is present, but we need to delete it")
+		err = r.Delete(ctx, apiCheck)
+		if err != nil {
+			logger.Info("Failed to delete ApiCheck")
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	// Only services reachable from outside of the cluster can be monitored by checklyhq.com
+	baseURL := serviceBaseURL(service)
+	if baseURL == "" && service.Annotations[fmt.Sprintf("%s/endpoint", r.ControllerDomain)] == "" {
+		logger.Info("Service has no external address yet, waiting for the next update", "type", service.Spec.Type)
+		return ctrl.Result{}, nil
+	}
+
+	// Gather data for the checkly check
+	apiCheckSpec, err := gatherApiCheckData(r.ControllerDomain, service.Annotations, baseURL)
+	if err != nil {
+		logger.Info("unable to gather data for the apiCheck resource")
+		return ctrl.Result{}, err
+	}
+
+	// Check and see if the ApiCheck has been created before
+	err = r.Get(ctx, req.NamespacedName, apiCheck)
+	if err == nil {
+		if !metav1.IsControlledBy(apiCheck, service) {
+			logger.Info("ApiCheck with the same name exists and is not owned by the Service, skipping", "name", apiCheck.Name)
+			return ctrl.Result{}, nil
+		}
+		logger.Info("apiCheck exists, doing an update")
+		// We can reference the exiting apiCheck object that the server returned
+		apiCheck.Spec = apiCheckSpec
+		checklycontrollers.SetSelectorLabels(r.ResourceSelector, service, apiCheck)
+		err = r.Update(ctx, apiCheck)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Create apiCheck
+	// We need to write the k8s spec resources as it is a new object
+	newApiCheck := &checklyv1alpha1.ApiCheck{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      service.Name,
+			Namespace: service.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(service, corev1.SchemeGroupVersion.WithKind("Service")),
+			},
+		},
+		Spec: apiCheckSpec,
+	}
+
+	checklycontrollers.SetSelectorLabels(r.ResourceSelector, service, newApiCheck)
+
+	err = r.Create(ctx, newApiCheck)
+	if err != nil {
+		logger.Info("Failed to create ApiCheck", "err", err)
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ServiceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Service{}, builder.WithPredicates(checklycontrollers.ResourceSelectorPredicate(r.ResourceSelector))).
+		Complete(r)
+}
+
+// serviceBaseURL returns the scheme and host a LoadBalancer or ExternalName Service is reachable on,
+// it's empty for other service types or if the load balancer has no address yet
+func serviceBaseURL(service *corev1.Service) string {
+	var host string
+	switch service.Spec.Type {
+	case corev1.ServiceTypeLoadBalancer:
+		for _, ingress := range service.Status.LoadBalancer.Ingress {
+			if ingress.Hostname != "" {
+				host = ingress.Hostname
+				break
+			}
+			if ingress.IP != "" {
+				host = ingress.IP
+				break
+			}
+		}
+	case corev1.ServiceTypeExternalName:
+		host = service.Spec.ExternalName
+	}
+
+	if host == "" {
+		return ""
+	}
+
+	var port int32 = 443
+	if len(service.Spec.Ports) != 0 {
+		port = service.Spec.Ports[0].Port
+	}
+
+	switch port {
+	case 443:
+		return fmt.Sprintf("https://%s", host)
+	case 80:
+		return fmt.Sprintf("http://%s", host)
+	default:
+		return fmt.Sprintf("https://%s:%d", host, port)
+	}
+}
@@ -0,0 +1,133 @@
+package networking
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("Service Controller", func() {
+
+	// Define utility constants for object names and testing timeouts/durations and intervals.
+	const (
+		timeout  = time.Second * 10
+		duration = time.Second * 10
+		interval = time.Millisecond * 250
+	)
+
+	Context("Service", func() {
+
+		// Test happy path
+		It("full reconciliation", func() {
+
+			testGroup := "service-group"
+
+			key := types.NamespacedName{
+				Name:      "test-service-check",
+				Namespace: "default",
+			}
+
+			annotation := make(map[string]string)
+			annotation["testing.domain.tld/enabled"] = "true"
+			annotation["testing.domain.tld/path"] = "/healthz"
+			annotation["testing.domain.tld/group"] = testGroup
+
+			service := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        key.Name,
+					Namespace:   key.Namespace,
+					Annotations: annotation,
+				},
+				Spec: corev1.ServiceSpec{
+					Type:         corev1.ServiceTypeExternalName,
+					ExternalName: "foo.bar",
+				},
+			}
+
+			// Create
+			Expect(k8sClient.Create(context.Background(), service)).Should(Succeed())
+
+			By("Expecting ApiCheck and OwnerReference to exist")
+			Eventually(func() bool {
+				f := &checklyv1alpha1.ApiCheck{}
+				err := k8sClient.Get(context.Background(), key, f)
+				if err != nil {
+					return false
+				}
+
+				if len(f.OwnerReferences) != 1 || f.OwnerReferences[0].Name != key.Name {
+					return false
+				}
+
+				Expect(f.Spec.Endpoint).To(Equal("https://foo.bar/healthz"))
+				Expect(f.Spec.Group).To(Equal(testGroup))
+				Expect(f.Spec.Success).To(Equal("200"))
+				Expect(f.Spec.Muted).To(Equal(true))
+
+				return true
+			}, timeout, interval).Should(BeTrue())
+
+			// Remove enabled annotation
+			By("Expecting ApiCheck to be deleted")
+			Eventually(func() error {
+				f := &corev1.Service{}
+				err := k8sClient.Get(context.Background(), key, f)
+				if err != nil {
+					return err
+				}
+				f.Annotations["testing.domain.tld/enabled"] = "false"
+				return k8sClient.Update(context.Background(), f)
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func() error {
+				f := &checklyv1alpha1.ApiCheck{}
+				return k8sClient.Get(context.Background(), key, f)
+			}, timeout, interval).ShouldNot(Succeed())
+
+			// Delete
+			Expect(k8sClient.Delete(context.Background(), service)).Should(Succeed())
+		})
+	})
+
+	Context("serviceBaseURL", func() {
+		It("returns the external address", func() {
+			loadBalancer := &corev1.Service{
+				Spec: corev1.ServiceSpec{
+					Type: corev1.ServiceTypeLoadBalancer,
+					Ports: []corev1.ServicePort{
+						{Port: 80},
+					},
+				},
+			}
+			Expect(serviceBaseURL(loadBalancer)).To(Equal(""))
+
+			loadBalancer.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "10.0.0.1"}}
+			Expect(serviceBaseURL(loadBalancer)).To(Equal("http://10.0.0.1"))
+
+			loadBalancer.Spec.Ports[0].Port = 8443
+			Expect(serviceBaseURL(loadBalancer)).To(Equal("https://10.0.0.1:8443"))
+
+			externalName := &corev1.Service{
+				Spec: corev1.ServiceSpec{
+					Type:         corev1.ServiceTypeExternalName,
+					ExternalName: "foo.bar",
+				},
+			}
+			Expect(serviceBaseURL(externalName)).To(Equal("https://foo.bar"))
+
+			clusterIP := &corev1.Service{
+				Spec: corev1.ServiceSpec{
+					Type: corev1.ServiceTypeClusterIP,
+				},
+			}
+			Expect(serviceBaseURL(clusterIP)).To(Equal(""))
+		})
+	})
+})
@@ -0,0 +1,232 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networking
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+	checklycontrollers "github.com/checkly/checkly-operator/internal/controller/checkly"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// VirtualServiceGVK is the Istio VirtualService kind, we use unstructured objects
+// so we don't have to depend on the Istio client libraries
+var VirtualServiceGVK = schema.GroupVersionKind{
+	Group:   "networking.istio.io",
+	Version: "v1beta1",
+	Kind:    "VirtualService",
+}
+
+// VirtualServiceReconciler reconciles an Istio VirtualService object
+type VirtualServiceReconciler struct {
+	client.Client
+	Scheme           *runtime.Scheme
+	ControllerDomain string
+	ResourceSelector labels.Selector
+}
+
+//+kubebuilder:rbac:groups=networking.istio.io,resources=virtualservices,verbs=get;list;watch
+//+kubebuilder:rbac:groups=k8s.checklyhq.com,resources=apichecks,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.0/pkg/reconcile
+func (r *VirtualServiceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	logger.Info("Reconciler started")
+
+	virtualService := &unstructured.Unstructured{}
+	virtualService.SetGroupVersionKind(VirtualServiceGVK)
+	apiCheck := &checklyv1alpha1.ApiCheck{}
+
+	annotationEnabled := fmt.Sprintf("%s/enabled", r.ControllerDomain)
+	annotationPath := fmt.Sprintf("%s/path", r.ControllerDomain)
+	annotationEndpoint := fmt.Sprintf("%s/endpoint", r.ControllerDomain)
+
+	// Check if virtualservice object is still present
+	err := r.Get(ctx, req.NamespacedName, virtualService)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			logger.Info("VirtualService got deleted")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Can't read the VirtualService object")
+		return ctrl.Result{}, err
+	}
+	logger.Info("VirtualService Object found")
+
+	// Check if annotation is present on the object
+	checklyAnnotation := virtualService.GetAnnotations()[annotationEnabled] == "true"
+	if !checklyAnnotation {
+		// Annotation may have been removed or updated, we have to determine if we need to delete a previously created ApiCheck resource
+		logger.Info("annotation is not present, checking if ApiCheck was created")
+		err = r.Get(ctx, req.NamespacedName, apiCheck)
+		if err != nil {
+			logger.Info("Apicheck not present")
+			return ctrl.Result{}, nil
+		}
+		if !metav1.IsControlledBy(apiCheck, virtualService) {
+			logger.Info("ApiCheck is not owned by the VirtualService, leaving it alone")
+			return ctrl.Result{}, nil
+		}
+		logger.Info("ApiCheck is present, but we need to delete it")
+		err = r.Delete(ctx, apiCheck)
+		if err != nil {
+			logger.Info("Failed to delete ApiCheck")
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	host := virtualServiceHost(virtualService)
+	if host == "" && virtualService.GetAnnotations()[annotationEndpoint] == "" {
+		logger.Info("VirtualService has no usable hosts, set the endpoint annotation to monitor it")
+		return ctrl.Result{}, nil
+	}
+
+	// The path annotation takes precedence over the path of the first http route
+	annotations := make(map[string]string, len(virtualService.GetAnnotations())+1)
+	for k, v := range virtualService.GetAnnotations() {
+		annotations[k] = v
+	}
+	if annotations[annotationPath] == "" {
+		annotations[annotationPath] = virtualServicePath(virtualService)
+	}
+
+	baseURL := ""
+	if host != "" {
+		baseURL = fmt.Sprintf("https://%s", host)
+	}
+
+	// Gather data for the checkly check
+	apiCheckSpec, err := gatherApiCheckData(r.ControllerDomain, annotations, baseURL)
+	if err != nil {
+		logger.Info("unable to gather data for the apiCheck resource")
+		return ctrl.Result{}, err
+	}
+
+	// Check and see if the ApiCheck has been created before
+	err = r.Get(ctx, req.NamespacedName, apiCheck)
+	if err == nil {
+		if !metav1.IsControlledBy(apiCheck, virtualService) {
+			logger.Info("ApiCheck with the same name exists and is not owned by the VirtualService, skipping", "name", apiCheck.Name)
+			return ctrl.Result{}, nil
+		}
+		logger.Info("apiCheck exists, doing an update")
+		// We can reference the exiting apiCheck object that the server returned
+		apiCheck.Spec = apiCheckSpec
+		checklycontrollers.SetSelectorLabels(r.ResourceSelector, virtualService, apiCheck)
+		err = r.Update(ctx, apiCheck)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Create apiCheck
+	// We need to write the k8s spec resources as it is a new object
+	newApiCheck := &checklyv1alpha1.ApiCheck{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      virtualService.GetName(),
+			Namespace: virtualService.GetNamespace(),
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(virtualService, VirtualServiceGVK),
+			},
+		},
+		Spec: apiCheckSpec,
+	}
+
+	checklycontrollers.SetSelectorLabels(r.ResourceSelector, virtualService, newApiCheck)
+
+	err = r.Create(ctx, newApiCheck)
+	if err != nil {
+		logger.Info("Failed to create ApiCheck", "err", err)
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *VirtualServiceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	virtualService := &unstructured.Unstructured{}
+	virtualService.SetGroupVersionKind(VirtualServiceGVK)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(virtualService, builder.WithPredicates(checklycontrollers.ResourceSelectorPredicate(r.ResourceSelector))).
+		Complete(r)
+}
+
+// virtualServiceHost returns the first host of the VirtualService which can be
+// turned into an URL, wildcard hosts are skipped
+func virtualServiceHost(virtualService *unstructured.Unstructured) string {
+	hosts, _, _ := unstructured.NestedStringSlice(virtualService.Object, "spec", "hosts")
+	for _, host := range hosts {
+		if strings.Contains(host, "*") {
+			continue
+		}
+		return host
+	}
+
+	return ""
+}
+
+// virtualServicePath returns the path of the first exact or prefix uri match of the
+// VirtualService http routes, regex matches can't be turned into an URL and are skipped
+func virtualServicePath(virtualService *unstructured.Unstructured) string {
+	routes, _, _ := unstructured.NestedSlice(virtualService.Object, "spec", "http")
+	for _, route := range routes {
+		route, ok := route.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		matches, _, _ := unstructured.NestedSlice(route, "match")
+		for _, match := range matches {
+			match, ok := match.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for _, field := range []string{"exact", "prefix"} {
+				path, found, _ := unstructured.NestedString(match, "uri", field)
+				if !found {
+					continue
+				}
+				if path == "/" {
+					return ""
+				}
+				return path
+			}
+		}
+	}
+
+	return ""
+}
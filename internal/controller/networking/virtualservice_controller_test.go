@@ -0,0 +1,47 @@
+package networking
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ = Describe("VirtualService Controller", func() {
+
+	Context("virtualServiceHost and virtualServicePath", func() {
+		It("returns the first usable host and path", func() {
+			virtualService := &unstructured.Unstructured{Object: map[string]interface{}{}}
+			virtualService.SetGroupVersionKind(VirtualServiceGVK)
+			Expect(virtualServiceHost(virtualService)).To(Equal(""))
+			Expect(virtualServicePath(virtualService)).To(Equal(""))
+
+			virtualService.Object["spec"] = map[string]interface{}{
+				"hosts": []interface{}{"*.foo.bar", "api.foo.bar"},
+				"http": []interface{}{
+					map[string]interface{}{
+						"match": []interface{}{
+							map[string]interface{}{"uri": map[string]interface{}{"regex": "/v[0-9]+"}},
+						},
+					},
+					map[string]interface{}{
+						"match": []interface{}{
+							map[string]interface{}{"uri": map[string]interface{}{"prefix": "/api"}},
+						},
+					},
+				},
+			}
+			Expect(virtualServiceHost(virtualService)).To(Equal("api.foo.bar"))
+			Expect(virtualServicePath(virtualService)).To(Equal("/api"))
+
+			Expect(unstructured.SetNestedField(virtualService.Object, []interface{}{
+				map[string]interface{}{
+					"match": []interface{}{
+						map[string]interface{}{"uri": map[string]interface{}{"exact": "/"}},
+					},
+				},
+			}, "spec", "http")).To(Succeed())
+			Expect(virtualServicePath(virtualService)).To(Equal(""))
+		})
+	})
+})
@@ -0,0 +1,405 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package importer generates the resources which adopt the checks, groups and alert channels of a
+// checklyhq.com account, so existing accounts can be managed by the operator
+package importer
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/checkly/checkly-go-sdk"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+	external "github.com/checkly/checkly-operator/external/checkly"
+)
+
+// operatorTag is added to every check and group by the operator, it isn't part of the spec
+const operatorTag = "checkly-operator"
+
+// invalidNameCharacters are replaced in the checklyhq.com names to get resource names
+var invalidNameCharacters = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Main runs the import subcommand, it writes the resources to stdout and the resources which can't
+// be imported to stderr, it returns the exit code
+func Main(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	namespace := flags.String("namespace", "default", "Namespace of the imported checks")
+	baseURL := flags.String("base-url", "https://api.checklyhq.com", "URL of the checklyhq.com API")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	apiKey, accountID := os.Getenv("CHECKLY_API_KEY"), os.Getenv("CHECKLY_ACCOUNT_ID")
+	if apiKey == "" || accountID == "" {
+		fmt.Fprintln(stderr, "the CHECKLY_API_KEY and CHECKLY_ACCOUNT_ID environment variables are required")
+		return 1
+	}
+	credentials := external.NewCredentials(apiKey, accountID)
+
+	objects, warnings, err := Import(context.Background(), external.NewLister(*baseURL, credentials), external.NewClientWithCredentials(*baseURL, credentials), *namespace)
+	if err != nil {
+		fmt.Fprintf(stderr, "failed to import the checklyhq.com account: %v\n", err)
+		return 1
+	}
+	for _, warning := range warnings {
+		fmt.Fprintf(stderr, "warning: %s\n", warning)
+	}
+
+	if err := Write(stdout, objects); err != nil {
+		fmt.Fprintf(stderr, "failed to write the resources: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// Import reads the checks, groups and alert channels of the account and returns the resources adopting them
+func Import(ctx context.Context, lister *external.Lister, apiClient checkly.Client, namespace string) (objects []client.Object, warnings []string, err error) {
+	alertChannelIDs, err := lister.AlertChannelIDs(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing the alert channels: %w", err)
+	}
+	var alertChannels []checkly.AlertChannel
+	for _, id := range alertChannelIDs {
+		alertChannel, err := apiClient.GetAlertChannel(ctx, id)
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading the alert channel %d: %w", id, err)
+		}
+		alertChannels = append(alertChannels, *alertChannel)
+	}
+
+	groups, err := lister.Groups(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing the groups: %w", err)
+	}
+
+	checks, err := lister.Checks(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing the checks: %w", err)
+	}
+
+	objects, warnings = Resources(alertChannels, groups, checks, namespace)
+
+	return objects, warnings, nil
+}
+
+// Resources returns the resources adopting the alert channels, groups and checks, in the order they
+// reference each other. The warnings name what can't be imported, ex. values held in Secrets
+func Resources(alertChannels []checkly.AlertChannel, groups []checkly.Group, checks []checkly.Check, namespace string) (objects []client.Object, warnings []string) {
+	alertChannelNames := map[int64]string{}
+	names := map[string]bool{}
+	for _, ac := range alertChannels {
+		alertChannel, ok := importAlertChannel(ac)
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("alert channel %d isn't imported, the configuration of %s alert channels is kept in Secrets", ac.ID, ac.Type))
+			continue
+		}
+		alertChannel.Name = uniqueName(names, strings.ToLower(ac.Type), fmt.Sprint(ac.ID))
+		alertChannelNames[ac.ID] = alertChannel.Name
+		objects = append(objects, alertChannel)
+	}
+
+	groupNames := map[int64]string{}
+	names = map[string]bool{}
+	for _, g := range groups {
+		group := importGroup(g)
+		group.Name = uniqueName(names, g.Name, fmt.Sprint(g.ID))
+		group.Spec.AlertChannels, warnings = subscriptions(g.AlertChannelSubscriptions, alertChannelNames, "group "+g.Name, warnings)
+		groupNames[g.ID] = group.Name
+		objects = append(objects, group)
+	}
+
+	names = map[string]bool{}
+	for _, check := range checks {
+		groupName := groupNames[check.GroupID]
+		if groupName == "" && check.Type != checkly.TypeHeartbeat {
+			warnings = append(warnings, fmt.Sprintf("check %s isn't imported, the operator only manages checks in groups", check.Name))
+			continue
+		}
+
+		objectMeta := metav1.ObjectMeta{Name: uniqueName(names, check.Name, check.ID), Namespace: namespace}
+		adopt := &checklyv1alpha1.CheckAdoption{ID: check.ID}
+		switch check.Type {
+		case checkly.TypeAPI:
+			apiCheck := importApiCheck(check)
+			apiCheck.ObjectMeta, apiCheck.Spec.Adopt, apiCheck.Spec.Group = objectMeta, adopt, groupName
+			apiCheck.Spec.AlertChannels, warnings = subscriptions(check.AlertChannelSubscriptions, alertChannelNames, "check "+check.Name, warnings)
+			if check.Request.BasicAuth != nil && check.Request.BasicAuth.Username != "" {
+				warnings = append(warnings, fmt.Sprintf("check %s uses basic authentication, add a basicAuth.secretRef before applying it", check.Name))
+			}
+			objects = append(objects, apiCheck)
+		case checkly.TypeBrowser:
+			browserCheck := importBrowserCheck(check)
+			browserCheck.ObjectMeta, browserCheck.Spec.Adopt, browserCheck.Spec.Group = objectMeta, adopt, groupName
+			objects = append(objects, browserCheck)
+		case external.TypeMultiStep:
+			multiStepCheck := importMultiStepCheck(check)
+			multiStepCheck.ObjectMeta, multiStepCheck.Spec.Adopt, multiStepCheck.Spec.Group = objectMeta, adopt, groupName
+			objects = append(objects, multiStepCheck)
+		case checkly.TypeHeartbeat:
+			heartbeatCheck := importHeartbeatCheck(check)
+			heartbeatCheck.ObjectMeta, heartbeatCheck.Spec.Adopt = objectMeta, adopt
+			heartbeatCheck.Spec.AlertChannels, warnings = subscriptions(check.AlertChannelSubscriptions, alertChannelNames, "check "+check.Name, warnings)
+			objects = append(objects, heartbeatCheck)
+		default:
+			warnings = append(warnings, fmt.Sprintf("check %s isn't imported, the operator doesn't support %s checks", check.Name, check.Type))
+		}
+	}
+
+	return
+}
+
+func importAlertChannel(ac checkly.AlertChannel) (*checklyv1alpha1.AlertChannel, bool) {
+	alertChannel := &checklyv1alpha1.AlertChannel{
+		TypeMeta: metav1.TypeMeta{APIVersion: checklyv1alpha1.GroupVersion.String(), Kind: "AlertChannel"},
+		Spec: checklyv1alpha1.AlertChannelSpec{
+			SendRecovery:       ac.SendRecovery != nil && *ac.SendRecovery,
+			SendFailure:        ac.SendFailure != nil && *ac.SendFailure,
+			SendDegraded:       ac.SendDegraded != nil && *ac.SendDegraded,
+			SSLExpiry:          ac.SSLExpiry != nil && *ac.SSLExpiry,
+			SSLExpiryThreshold: ptrValue(ac.SSLExpiryThreshold),
+			Adopt:              &checklyv1alpha1.AlertChannelAdoption{ID: ac.ID},
+		},
+	}
+
+	switch {
+	case ac.Type == checkly.AlertTypeEmail && ac.Email != nil:
+		alertChannel.Spec.Email = *ac.Email
+	case ac.Type == checkly.AlertTypeSMS && ac.SMS != nil:
+		alertChannel.Spec.SMS = &checklyv1alpha1.AlertChannelPhone{Number: ac.SMS.Number, Name: ac.SMS.Name}
+	case ac.Type == checkly.AlertTypeCall && ac.CALL != nil:
+		alertChannel.Spec.Call = &checklyv1alpha1.AlertChannelPhone{Number: ac.CALL.Number, Name: ac.CALL.Name}
+	case ac.Type == checkly.AlertTypeWebhook && ac.Webhook != nil && ac.Webhook.WebhookSecret == "":
+		alertChannel.Spec.Webhook = &checklyv1alpha1.AlertChannelWebhook{
+			URL:             ac.Webhook.URL,
+			Method:          ac.Webhook.Method,
+			Headers:         keyValues(ac.Webhook.Headers),
+			QueryParameters: keyValues(ac.Webhook.QueryParameters),
+			Template:        ac.Webhook.Template,
+			WebhookType:     ac.Webhook.WebhookType,
+		}
+	default:
+		return nil, false
+	}
+
+	return alertChannel, true
+}
+
+func importGroup(g checkly.Group) *checklyv1alpha1.Group {
+	return &checklyv1alpha1.Group{
+		TypeMeta: metav1.TypeMeta{APIVersion: checklyv1alpha1.GroupVersion.String(), Kind: "Group"},
+		Spec: checklyv1alpha1.GroupSpec{
+			Locations:            g.Locations,
+			PrivateLocationSlugs: ptrValue(g.PrivateLocations),
+			RuntimeID:            ptrValue(g.RuntimeID),
+			Tags:                 userTags(g.Tags),
+			Concurrency:          g.Concurrency,
+			Adopt:                &checklyv1alpha1.GroupAdoption{ID: g.ID},
+		},
+	}
+}
+
+func importApiCheck(check checkly.Check) *checklyv1alpha1.ApiCheck {
+	apiCheck := &checklyv1alpha1.ApiCheck{
+		TypeMeta: metav1.TypeMeta{APIVersion: checklyv1alpha1.GroupVersion.String(), Kind: "ApiCheck"},
+		Spec: checklyv1alpha1.ApiCheckSpec{
+			Frequency:            &check.Frequency,
+			FrequencyOffset:      check.FrequencyOffset,
+			Muted:                check.Muted,
+			Paused:               !check.Activated,
+			Endpoint:             check.Request.URL,
+			Method:               check.Request.Method,
+			Body:                 check.Request.Body,
+			BodyType:             check.Request.BodyType,
+			IPFamily:             check.Request.IPFamily,
+			FollowRedirects:      check.Request.FollowRedirects,
+			SkipSSL:              check.Request.SkipSSL,
+			MaxResponseTime:      check.MaxResponseTime,
+			DegradedResponseTime: check.DegradedResponseTime,
+			Locations:            check.Locations,
+			QueryParameters:      keyValues(check.Request.QueryParameters),
+			RuntimeID:            ptrValue(check.RuntimeID),
+			Tags:                 userTags(check.Tags),
+		},
+	}
+	if check.ShouldFail {
+		apiCheck.Spec.ShouldFail = &check.ShouldFail
+	}
+	for _, header := range check.Request.Headers {
+		apiCheck.Spec.Headers = append(apiCheck.Spec.Headers, checklyv1alpha1.HTTPHeader{Key: header.Key, Value: header.Value})
+	}
+
+	// The first status code assertion is the success code of the spec
+	for _, assertion := range check.Request.Assertions {
+		if apiCheck.Spec.Success == "" && assertion.Source == checkly.StatusCode && assertion.Comparison == checkly.Equals {
+			apiCheck.Spec.Success = assertion.Target
+			continue
+		}
+		apiCheck.Spec.Assertions = append(apiCheck.Spec.Assertions, checklyv1alpha1.Assertion{
+			Source:     assertion.Source,
+			Property:   assertion.Property,
+			Comparison: assertion.Comparison,
+			Target:     assertion.Target,
+		})
+	}
+	if apiCheck.Spec.Success == "" {
+		apiCheck.Spec.Success = "200"
+	}
+
+	return apiCheck
+}
+
+func importBrowserCheck(check checkly.Check) *checklyv1alpha1.BrowserCheck {
+	return &checklyv1alpha1.BrowserCheck{
+		TypeMeta: metav1.TypeMeta{APIVersion: checklyv1alpha1.GroupVersion.String(), Kind: "BrowserCheck"},
+		Spec: checklyv1alpha1.BrowserCheckSpec{
+			Frequency: check.Frequency,
+			Muted:     check.Muted,
+			Paused:    !check.Activated,
+			Locations: check.Locations,
+			RuntimeID: ptrValue(check.RuntimeID),
+			Script:    check.Script,
+		},
+	}
+}
+
+func importMultiStepCheck(check checkly.Check) *checklyv1alpha1.MultiStepCheck {
+	return &checklyv1alpha1.MultiStepCheck{
+		TypeMeta: metav1.TypeMeta{APIVersion: checklyv1alpha1.GroupVersion.String(), Kind: "MultiStepCheck"},
+		Spec: checklyv1alpha1.MultiStepCheckSpec{
+			Frequency: check.Frequency,
+			Muted:     check.Muted,
+			Paused:    !check.Activated,
+			Locations: check.Locations,
+			Runtime:   ptrValue(check.RuntimeID),
+			Script:    check.Script,
+		},
+	}
+}
+
+func importHeartbeatCheck(check checkly.Check) *checklyv1alpha1.HeartbeatCheck {
+	return &checklyv1alpha1.HeartbeatCheck{
+		TypeMeta: metav1.TypeMeta{APIVersion: checklyv1alpha1.GroupVersion.String(), Kind: "HeartbeatCheck"},
+		Spec: checklyv1alpha1.HeartbeatCheckSpec{
+			Period:     check.Heartbeat.Period,
+			PeriodUnit: check.Heartbeat.PeriodUnit,
+			Grace:      check.Heartbeat.Grace,
+			GraceUnit:  check.Heartbeat.GraceUnit,
+			Muted:      check.Muted,
+			Paused:     !check.Activated,
+		},
+	}
+}
+
+// subscriptions returns the names of the imported alert channels with an activated subscription, the
+// subscriptions to the other alert channels are removed when the resource is applied
+func subscriptions(subscriptions []checkly.AlertChannelSubscription, alertChannelNames map[int64]string, subscriber string, warnings []string) (names []string, _ []string) {
+	for _, subscription := range subscriptions {
+		if !subscription.Activated {
+			continue
+		}
+		name, ok := alertChannelNames[subscription.ChannelID]
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("the %s subscribes to the alert channel %d which isn't imported", subscriber, subscription.ChannelID))
+			continue
+		}
+		names = append(names, name)
+	}
+
+	return names, warnings
+}
+
+// uniqueName returns a resource name for the checklyhq.com name, the ID tells resources with the same name apart
+func uniqueName(names map[string]bool, name, id string) string {
+	name = strings.Trim(invalidNameCharacters.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	suffix := invalidNameCharacters.ReplaceAllString(strings.ToLower(id), "")
+	if len(suffix) > 8 {
+		suffix = suffix[:8]
+	}
+
+	if name == "" || names[name] {
+		name = strings.Trim(fmt.Sprintf("%.*s-%s", validation.DNS1123LabelMaxLength-len(suffix)-1, name, suffix), "-")
+	}
+	if len(name) > validation.DNS1123LabelMaxLength {
+		name = strings.Trim(name[:validation.DNS1123LabelMaxLength], "-")
+	}
+	names[name] = true
+
+	return name
+}
+
+// userTags drops the tag the operator adds itself
+func userTags(tags []string) []string {
+	return slices.DeleteFunc(slices.Clone(tags), func(tag string) bool { return tag == operatorTag || external.IsOwnerTag(tag) })
+}
+
+func keyValues(keyValues []checkly.KeyValue) map[string]string {
+	if len(keyValues) == 0 {
+		return nil
+	}
+	values := make(map[string]string, len(keyValues))
+	for _, keyValue := range keyValues {
+		values[keyValue.Key] = keyValue.Value
+	}
+
+	return values
+}
+
+func ptrValue[T any](value *T) (v T) {
+	if value != nil {
+		v = *value
+	}
+
+	return
+}
+
+// Write writes the resources as a YAML stream without their empty status and creation timestamp
+func Write(w io.Writer, objects []client.Object) error {
+	for _, object := range objects {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+		if err != nil {
+			return err
+		}
+		delete(content, "status")
+		if metadata, ok := content["metadata"].(map[string]interface{}); ok {
+			delete(metadata, "creationTimestamp")
+		}
+
+		data, err := yaml.Marshal(content)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "---\n%s", data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/checkly/checkly-go-sdk"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+func TestResources(t *testing.T) {
+
+	sendFailure := true
+	alertChannels := []checkly.AlertChannel{
+		{ID: 1, Type: checkly.AlertTypeEmail, Email: &checkly.AlertChannelEmail{Address: "foo@bar.baz"}, SendFailure: &sendFailure},
+		{ID: 2, Type: checkly.AlertTypeSlack, Slack: &checkly.AlertChannelSlack{WebhookURL: "https://hooks.slack.com/foo"}},
+	}
+	groups := []checkly.Group{
+		{ID: 3, Name: "Production API", Locations: []string{"eu-west-1"}, Tags: []string{"prod", "checkly-operator", "checkly-operator-owner:old//production-api"}, AlertChannelSubscriptions: []checkly.AlertChannelSubscription{{ChannelID: 1, Activated: true}, {ChannelID: 2, Activated: true}}},
+	}
+	checks := []checkly.Check{
+		{
+			ID:        "73d29ea2-6540-4bb5-967e-e07fa2c9465e",
+			Name:      "Production API",
+			Type:      checkly.TypeAPI,
+			Frequency: 5,
+			GroupID:   3,
+			Request: checkly.Request{
+				Method: "GET",
+				URL:    "https://foo.bar/baz",
+				Assertions: []checkly.Assertion{
+					{Source: checkly.StatusCode, Comparison: checkly.Equals, Target: "204"},
+					{Source: checkly.JSONBody, Property: "$.status", Comparison: checkly.Equals, Target: "ok"},
+				},
+			},
+		},
+		{ID: "c1", Name: "Checkout", Type: checkly.TypeBrowser, Activated: true, Script: "console.log('foo')", GroupID: 3},
+		{ID: "c2", Name: "Without group", Type: checkly.TypeAPI},
+		{ID: "c3", Name: "Cron", Type: checkly.TypeHeartbeat, Activated: true, Heartbeat: checkly.Heartbeat{Period: 1, PeriodUnit: "days"}},
+	}
+
+	objects, warnings := Resources(alertChannels, groups, checks, "monitoring")
+	if len(objects) != 5 {
+		t.Fatalf("Expected 5 resources, got %d", len(objects))
+	}
+	if len(warnings) != 3 {
+		t.Errorf("Expected warnings about the slack alert channel, its subscription and the check without group, got %v", warnings)
+	}
+
+	alertChannel := objects[0].(*checklyv1alpha1.AlertChannel)
+	if alertChannel.Name != "email" || alertChannel.Spec.Email.Address != "foo@bar.baz" || !alertChannel.Spec.SendFailure || alertChannel.Spec.Adopt.ID != 1 {
+		t.Errorf("Unexpected alert channel %v", alertChannel)
+	}
+
+	group := objects[1].(*checklyv1alpha1.Group)
+	if group.Name != "production-api" || group.Spec.Adopt.ID != 3 {
+		t.Errorf("Unexpected group %v", group)
+	}
+	if len(group.Spec.Tags) != 1 || len(group.Spec.AlertChannels) != 1 || group.Spec.AlertChannels[0] != "email" {
+		t.Errorf("Expected the prod tag and the email alert channel, got %v", group.Spec)
+	}
+
+	apiCheck := objects[2].(*checklyv1alpha1.ApiCheck)
+	if apiCheck.Name != "production-api" || apiCheck.Namespace != "monitoring" || apiCheck.Spec.Group != "production-api" {
+		t.Errorf("Unexpected api check %v", apiCheck.ObjectMeta)
+	}
+	if apiCheck.Spec.Success != "204" || len(apiCheck.Spec.Assertions) != 1 || !apiCheck.Spec.Paused {
+		t.Errorf("Expected the success code, the other assertion and the check to be paused, got %v", apiCheck.Spec)
+	}
+
+	browserCheck := objects[3].(*checklyv1alpha1.BrowserCheck)
+	if browserCheck.Spec.Script != "console.log('foo')" || browserCheck.Spec.Paused || browserCheck.Spec.Adopt.ID != "c1" {
+		t.Errorf("Unexpected browser check %v", browserCheck.Spec)
+	}
+
+	heartbeatCheck := objects[4].(*checklyv1alpha1.HeartbeatCheck)
+	if heartbeatCheck.Spec.Period != 1 || heartbeatCheck.Spec.PeriodUnit != "days" {
+		t.Errorf("Unexpected heartbeat check %v", heartbeatCheck.Spec)
+	}
+
+	var out bytes.Buffer
+	if err := Write(&out, objects[:1]); err != nil {
+		t.Fatalf("Expected no error, got %e", err)
+	}
+	if !strings.HasPrefix(out.String(), "---\napiVersion: k8s.checklyhq.com/v1alpha1\nkind: AlertChannel\n") {
+		t.Errorf("Unexpected output %s", out.String())
+	}
+	if strings.Contains(out.String(), "status:") || strings.Contains(out.String(), "creationTimestamp") {
+		t.Errorf("Expected no status and creation timestamp, got %s", out.String())
+	}
+}
+
+func TestUniqueName(t *testing.T) {
+
+	names := map[string]bool{}
+	for _, tc := range []struct {
+		name, id, expected string
+	}{
+		{"Production API", "1", "production-api"},
+		{"production api!", "73d29ea2-6540", "production-api-73d29ea2"},
+		{"🙂", "2", "2"},
+		{strings.Repeat("a", 70), "3", strings.Repeat("a", 63)},
+	} {
+		if got := uniqueName(names, tc.name, tc.id); got != tc.expected {
+			t.Errorf("Expected %s for %s, got %s", tc.expected, tc.name, got)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+//+kubebuilder:webhook:path=/validate-k8s-checklyhq-com-v1alpha1-alertchannel,mutating=false,failurePolicy=fail,sideEffects=None,groups=k8s.checklyhq.com,resources=alertchannels,verbs=create;update,versions=v1alpha1,name=valertchannel.kb.io,admissionReviewVersions=v1
+
+// SetupAlertChannelWebhookWithManager registers the AlertChannel webhooks with the manager
+func SetupAlertChannelWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&checklyv1alpha1.AlertChannel{}).
+		WithValidator(&AlertChannelValidator{}).
+		Complete()
+}
+
+// AlertChannelValidator rejects AlertChannels the controller can't create in checklyhq.com
+type AlertChannelValidator struct{}
+
+// ValidateCreate implements admission.CustomValidator
+func (v *AlertChannelValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	ac, ok := obj.(*checklyv1alpha1.AlertChannel)
+	if !ok {
+		return nil, fmt.Errorf("expected an AlertChannel but got a %T", obj)
+	}
+
+	return nil, v.validate(ac)
+}
+
+// ValidateUpdate implements admission.CustomValidator
+func (v *AlertChannelValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	ac, ok := newObj.(*checklyv1alpha1.AlertChannel)
+	if !ok {
+		return nil, fmt.Errorf("expected an AlertChannel but got a %T", newObj)
+	}
+	if !ac.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+
+	return nil, v.validate(ac)
+}
+
+// ValidateDelete implements admission.CustomValidator, deletes are always allowed
+func (v *AlertChannelValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *AlertChannelValidator) validate(ac *checklyv1alpha1.AlertChannel) error {
+	spec := field.NewPath("spec")
+
+	var errs field.ErrorList
+	if types := alertChannelTypes(&ac.Spec); len(types) > 1 {
+		errs = append(errs, field.Invalid(spec, strings.Join(types, ", "), "only one alert channel type can be set"))
+	}
+	if ac.Spec.Webhook != nil {
+		errs = append(errs, validateURL(spec.Child("webhook", "url"), ac.Spec.Webhook.URL)...)
+	}
+	if ac.Spec.IncidentIO != nil {
+		errs = append(errs, validateURL(spec.Child("incidentio", "url"), ac.Spec.IncidentIO.URL)...)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(checklyv1alpha1.GroupVersion.WithKind("AlertChannel").GroupKind(), ac.Name, errs)
+}
+
+// alertChannelTypes returns the alert channel types configured in the spec
+func alertChannelTypes(spec *checklyv1alpha1.AlertChannelSpec) (types []string) {
+	if spec.Email.Address != "" {
+		types = append(types, "email")
+	}
+	if spec.OpsGenie.APISecret != (corev1.ObjectReference{}) {
+		types = append(types, "opsgenie")
+	}
+	if spec.Slack != nil {
+		types = append(types, "slack")
+	}
+	if spec.SMS != nil {
+		types = append(types, "sms")
+	}
+	if spec.Call != nil {
+		types = append(types, "call")
+	}
+	if spec.Webhook != nil {
+		types = append(types, "webhook")
+	}
+	if spec.Pagerduty != nil {
+		types = append(types, "pagerduty")
+	}
+	if spec.Telegram != nil {
+		types = append(types, "telegram")
+	}
+	if spec.MSTeams != nil {
+		types = append(types, "msteams")
+	}
+	if spec.IncidentIO != nil {
+		types = append(types, "incidentio")
+	}
+
+	return
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/checkly/checkly-go-sdk"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+func TestAlertChannelValidator(t *testing.T) {
+	v := &AlertChannelValidator{}
+
+	ac := &checklyv1alpha1.AlertChannel{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+		Spec: checklyv1alpha1.AlertChannelSpec{
+			Webhook: &checklyv1alpha1.AlertChannelWebhook{URL: "https://foo.bar/alert"},
+		},
+	}
+	if _, err := v.ValidateCreate(context.Background(), ac); err != nil {
+		t.Errorf("Expected no error, got %s", err)
+	}
+
+	invalidURL := ac.DeepCopy()
+	invalidURL.Spec.Webhook.URL = "foo.bar/alert"
+	if _, err := v.ValidateCreate(context.Background(), invalidURL); err == nil {
+		t.Errorf("Expected an error for the invalid webhook URL")
+	}
+
+	multipleTypes := ac.DeepCopy()
+	multipleTypes.Spec.Email = checkly.AlertChannelEmail{Address: "foo@bar.baz"}
+	multipleTypes.Spec.Slack = &checklyv1alpha1.AlertChannelSlack{WebhookSecret: corev1.ObjectReference{Name: "slack"}}
+	if _, err := v.ValidateUpdate(context.Background(), ac, multipleTypes); err == nil {
+		t.Errorf("Expected an error for multiple alert channel types")
+	}
+
+	if types := alertChannelTypes(&multipleTypes.Spec); len(types) != 3 {
+		t.Errorf("Expected %d types, got %v", 3, types)
+	}
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+// apiCheckFrequencies are the frequencies in minutes checklyhq.com accepts for api checks
+var apiCheckFrequencies = []int{0, 1, 2, 5, 10, 15, 30, 60, 120, 180, 360, 720, 1440}
+
+//+kubebuilder:webhook:path=/mutate-k8s-checklyhq-com-v1alpha1-apicheck,mutating=true,failurePolicy=fail,sideEffects=None,groups=k8s.checklyhq.com,resources=apichecks,verbs=create;update,versions=v1alpha1,name=mapicheck.kb.io,admissionReviewVersions=v1
+//+kubebuilder:webhook:path=/validate-k8s-checklyhq-com-v1alpha1-apicheck,mutating=false,failurePolicy=fail,sideEffects=None,groups=k8s.checklyhq.com,resources=apichecks,verbs=create;update,versions=v1alpha1,name=vapicheck.kb.io,admissionReviewVersions=v1
+
+// SetupApiCheckWebhookWithManager registers the ApiCheck webhooks with the manager
+func SetupApiCheckWebhookWithManager(mgr ctrl.Manager, defaults Defaults) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&checklyv1alpha1.ApiCheck{}).
+		WithDefaulter(&ApiCheckDefaulter{Defaults: defaults}).
+		WithValidator(&ApiCheckValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// ApiCheckDefaulter fills in the operator-level defaults of ApiChecks
+type ApiCheckDefaulter struct {
+	Defaults Defaults
+}
+
+// Default implements admission.CustomDefaulter
+func (d *ApiCheckDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	apiCheck, ok := obj.(*checklyv1alpha1.ApiCheck)
+	if !ok {
+		return fmt.Errorf("expected an ApiCheck but got a %T", obj)
+	}
+
+	defaultApiCheckSpec(&apiCheck.Spec, d.Defaults)
+	return nil
+}
+
+// ApiCheckValidator rejects ApiChecks the controller can't create in checklyhq.com
+type ApiCheckValidator struct {
+	Client client.Reader
+}
+
+// ValidateCreate implements admission.CustomValidator
+func (v *ApiCheckValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	apiCheck, ok := obj.(*checklyv1alpha1.ApiCheck)
+	if !ok {
+		return nil, fmt.Errorf("expected an ApiCheck but got a %T", obj)
+	}
+
+	return nil, v.validate(ctx, apiCheck, true, true)
+}
+
+// ValidateUpdate implements admission.CustomValidator, the group and the account are only looked up when
+// they change so checks of a deleted group or account can still be updated, ex. to remove their finalizer
+func (v *ApiCheckValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldApiCheck, ok := oldObj.(*checklyv1alpha1.ApiCheck)
+	if !ok {
+		return nil, fmt.Errorf("expected an ApiCheck but got a %T", oldObj)
+	}
+	apiCheck, ok := newObj.(*checklyv1alpha1.ApiCheck)
+	if !ok {
+		return nil, fmt.Errorf("expected an ApiCheck but got a %T", newObj)
+	}
+	if !apiCheck.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+
+	return nil, v.validate(ctx, apiCheck, oldApiCheck.Spec.Group != apiCheck.Spec.Group, oldApiCheck.Spec.Account != apiCheck.Spec.Account)
+}
+
+// ValidateDelete implements admission.CustomValidator, deletes are always allowed
+func (v *ApiCheckValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *ApiCheckValidator) validate(ctx context.Context, apiCheck *checklyv1alpha1.ApiCheck, lookupGroup bool, lookupAccount bool) error {
+	return validateApiCheckSpec(ctx, v.Client, "ApiCheck", apiCheck.Name, apiCheck.Namespace, &apiCheck.Spec, lookupGroup, lookupAccount)
+}
+
+// validateApiCheckSpec validates the spec shared by ApiChecks and ClusterApiChecks, cluster scoped checks pass an
+// empty namespace
+func validateApiCheckSpec(ctx context.Context, c client.Reader, kind string, name string, namespace string, apiCheckSpec *checklyv1alpha1.ApiCheckSpec, lookupGroup bool, lookupAccount bool) error {
+	spec := field.NewPath("spec")
+
+	var errs field.ErrorList
+	if frequency := apiCheckSpec.Frequency; frequency != nil && !slices.Contains(apiCheckFrequencies, *frequency) {
+		errs = append(errs, field.NotSupported(spec.Child("frequency"), *frequency, frequencyValues(apiCheckFrequencies)))
+	}
+	if frequency := apiCheckSpec.Frequency; frequency != nil && *frequency == 0 && !slices.Contains([]int{10, 20, 30}, apiCheckSpec.FrequencyOffset) {
+		errs = append(errs, field.Invalid(spec.Child("frequencyOffset"), apiCheckSpec.FrequencyOffset, "frequency 0 requires a frequencyOffset of 10, 20 or 30 seconds"))
+	}
+	errs = append(errs, validateURL(spec.Child("endpoint"), apiCheckSpec.Endpoint)...)
+	errs = append(errs, validateLocations(spec.Child("locations"), apiCheckSpec.Locations)...)
+
+	if apiCheckSpec.Group == "" {
+		errs = append(errs, field.Required(spec.Child("group"), "every check has to belong to a group"))
+	} else if lookupGroup {
+		groupErr, err := validateGroupReference(ctx, c, spec.Child("group"), apiCheckSpec.Group, namespace)
+		if err != nil {
+			return err
+		}
+		if groupErr != nil {
+			errs = append(errs, groupErr)
+		}
+	}
+
+	if apiCheckSpec.Account != "" && lookupAccount {
+		accountErr, err := validateAccountReference(ctx, c, spec.Child("account"), apiCheckSpec.Account, namespace)
+		if err != nil {
+			return err
+		}
+		if accountErr != nil {
+			errs = append(errs, accountErr)
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(checklyv1alpha1.GroupVersion.WithKind(kind).GroupKind(), name, errs)
+}
+
+// validateGroupReference returns a field error if the group doesn't exist or doesn't allow checks from the namespace,
+// cluster scoped checks pass an empty namespace and can use any group
+func validateGroupReference(ctx context.Context, c client.Reader, path *field.Path, name string, namespace string) (*field.Error, error) {
+	group := &checklyv1alpha1.Group{}
+	err := c.Get(ctx, types.NamespacedName{Name: name}, group)
+	if apierrors.IsNotFound(err) {
+		return field.NotFound(path, name), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(group.Spec.AllowedNamespaces) > 0 && namespace != "" && !slices.Contains(group.Spec.AllowedNamespaces, namespace) {
+		return field.Forbidden(path, fmt.Sprintf("group %s doesn't allow checks from namespace %s", name, namespace)), nil
+	}
+
+	return nil, nil
+}
+
+// validateAccountReference returns a field error if the ChecklyAccount doesn't exist or doesn't allow resources from the
+// namespace, cluster scoped resources pass an empty namespace and can use any account
+func validateAccountReference(ctx context.Context, c client.Reader, path *field.Path, name string, namespace string) (*field.Error, error) {
+	account := &checklyv1alpha1.ChecklyAccount{}
+	err := c.Get(ctx, types.NamespacedName{Name: name}, account)
+	if apierrors.IsNotFound(err) {
+		return field.NotFound(path, name), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(account.Spec.AllowedNamespaces) > 0 && namespace != "" && !slices.Contains(account.Spec.AllowedNamespaces, namespace) {
+		return field.Forbidden(path, fmt.Sprintf("account %s doesn't allow resources from namespace %s", name, namespace)), nil
+	}
+
+	return nil, nil
+}
+
+// frequencyValues formats the frequencies for field.NotSupported
+func frequencyValues(frequencies []int) (values []string) {
+	for _, frequency := range frequencies {
+		values = append(values, strconv.Itoa(frequency))
+	}
+
+	return
+}
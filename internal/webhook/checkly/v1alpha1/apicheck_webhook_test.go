@@ -0,0 +1,121 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+func TestApiCheckValidator(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := checklyv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&checklyv1alpha1.Group{ObjectMeta: metav1.ObjectMeta{Name: "shared"}},
+		&checklyv1alpha1.Group{
+			ObjectMeta: metav1.ObjectMeta{Name: "team"},
+			Spec:       checklyv1alpha1.GroupSpec{AllowedNamespaces: []string{"team"}},
+		},
+		&checklyv1alpha1.ChecklyAccount{ObjectMeta: metav1.ObjectMeta{Name: "shared"}},
+		&checklyv1alpha1.ChecklyAccount{
+			ObjectMeta: metav1.ObjectMeta{Name: "team"},
+			Spec:       checklyv1alpha1.ChecklyAccountSpec{AllowedNamespaces: []string{"team"}},
+		},
+	).Build()
+	v := &ApiCheckValidator{Client: c}
+
+	frequency, invalidFrequency, subMinute := 5, 3, 0
+	apiCheck := &checklyv1alpha1.ApiCheck{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: checklyv1alpha1.ApiCheckSpec{
+			Frequency: &frequency,
+			Endpoint:  "https://foo.bar/baz",
+			Success:   "200",
+			Locations: []string{"eu-west-1"},
+			Group:     "shared",
+		},
+	}
+	if _, err := v.ValidateCreate(context.Background(), apiCheck); err != nil {
+		t.Errorf("Expected no error, got %s", err)
+	}
+
+	sharedAccount := apiCheck.DeepCopy()
+	sharedAccount.Spec.Account = "shared"
+	if _, err := v.ValidateCreate(context.Background(), sharedAccount); err != nil {
+		t.Errorf("Expected no error, got %s", err)
+	}
+
+	groupBaseURL := apiCheck.DeepCopy()
+	groupBaseURL.Spec.Endpoint = "{{GROUP_BASE_URL}}/health"
+	if _, err := v.ValidateCreate(context.Background(), groupBaseURL); err != nil {
+		t.Errorf("Expected no error, got %s", err)
+	}
+
+	tests := map[string]func(spec *checklyv1alpha1.ApiCheckSpec){
+		"frequency":         func(spec *checklyv1alpha1.ApiCheckSpec) { spec.Frequency = &invalidFrequency },
+		"frequency offset":  func(spec *checklyv1alpha1.ApiCheckSpec) { spec.Frequency, spec.FrequencyOffset = &subMinute, 15 },
+		"missing offset":    func(spec *checklyv1alpha1.ApiCheckSpec) { spec.Frequency = &subMinute },
+		"endpoint scheme":   func(spec *checklyv1alpha1.ApiCheckSpec) { spec.Endpoint = "foo.bar/baz" },
+		"endpoint host":     func(spec *checklyv1alpha1.ApiCheckSpec) { spec.Endpoint = "https:///baz" },
+		"location":          func(spec *checklyv1alpha1.ApiCheckSpec) { spec.Locations = []string{"basement"} },
+		"missing group":     func(spec *checklyv1alpha1.ApiCheckSpec) { spec.Group = "" },
+		"unknown group":     func(spec *checklyv1alpha1.ApiCheckSpec) { spec.Group = "unknown" },
+		"group namespace":   func(spec *checklyv1alpha1.ApiCheckSpec) { spec.Group = "team" },
+		"unknown account":   func(spec *checklyv1alpha1.ApiCheckSpec) { spec.Account = "unknown" },
+		"account namespace": func(spec *checklyv1alpha1.ApiCheckSpec) { spec.Account = "team" },
+	}
+	for name, mutate := range tests {
+		invalid := apiCheck.DeepCopy()
+		mutate(&invalid.Spec)
+		if _, err := v.ValidateCreate(context.Background(), invalid); err == nil {
+			t.Errorf("Expected an error for the invalid %s", name)
+		}
+	}
+
+	// Checks of a deleted group can still be updated as long as the group doesn't change
+	orphaned := apiCheck.DeepCopy()
+	orphaned.Spec.Group = "deleted"
+	updated := orphaned.DeepCopy()
+	updated.Finalizers = []string{"k8s.checklyhq.com/finalizer"}
+	if _, err := v.ValidateUpdate(context.Background(), orphaned, updated); err != nil {
+		t.Errorf("Expected no error, got %s", err)
+	}
+	if _, err := v.ValidateUpdate(context.Background(), apiCheck, orphaned); err == nil {
+		t.Errorf("Expected an error for the unknown group")
+	}
+
+	// Checks of an account which no longer allows their namespace can still be updated as long as the account doesn't change
+	restricted := apiCheck.DeepCopy()
+	restricted.Spec.Account = "team"
+	updated = restricted.DeepCopy()
+	updated.Finalizers = []string{"k8s.checklyhq.com/finalizer"}
+	if _, err := v.ValidateUpdate(context.Background(), restricted, updated); err != nil {
+		t.Errorf("Expected no error, got %s", err)
+	}
+	if _, err := v.ValidateUpdate(context.Background(), apiCheck, restricted); err == nil {
+		t.Errorf("Expected an error for the account of another namespace")
+	}
+}
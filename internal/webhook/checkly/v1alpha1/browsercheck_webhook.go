@@ -0,0 +1,73 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+//+kubebuilder:webhook:path=/mutate-k8s-checklyhq-com-v1alpha1-browsercheck,mutating=true,failurePolicy=fail,sideEffects=None,groups=k8s.checklyhq.com,resources=browserchecks,verbs=create;update,versions=v1alpha1,name=mbrowsercheck.kb.io,admissionReviewVersions=v1
+
+// SetupBrowserCheckWebhookWithManager registers the BrowserCheck webhooks with the manager
+func SetupBrowserCheckWebhookWithManager(mgr ctrl.Manager, defaults Defaults) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&checklyv1alpha1.BrowserCheck{}).
+		WithDefaulter(&BrowserCheckDefaulter{Client: mgr.GetClient(), Defaults: defaults}).
+		Complete()
+}
+
+// BrowserCheckDefaulter fills in the operator-level defaults of BrowserChecks
+type BrowserCheckDefaulter struct {
+	Client   client.Reader
+	Defaults Defaults
+}
+
+// Default implements admission.CustomDefaulter, the frequency of the browser check defaults
+// of the group takes precedence over the default frequency of the operator
+func (d *BrowserCheckDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	browserCheck, ok := obj.(*checklyv1alpha1.BrowserCheck)
+	if !ok {
+		return fmt.Errorf("expected a BrowserCheck but got a %T", obj)
+	}
+
+	if browserCheck.Spec.Group == "" {
+		browserCheck.Spec.Group = d.Defaults.Group
+	}
+	if browserCheck.Spec.Frequency != 0 || d.Defaults.Frequency == 0 {
+		return nil
+	}
+
+	group := &checklyv1alpha1.Group{}
+	err := d.Client.Get(ctx, types.NamespacedName{Name: browserCheck.Spec.Group}, group)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if group.Spec.BrowserCheckDefaults == nil || group.Spec.BrowserCheckDefaults.Frequency == 0 {
+		browserCheck.Spec.Frequency = d.Defaults.Frequency
+	}
+
+	return nil
+}
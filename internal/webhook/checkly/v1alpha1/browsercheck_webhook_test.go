@@ -0,0 +1,69 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+func TestBrowserCheckDefaulter(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := checklyv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&checklyv1alpha1.Group{ObjectMeta: metav1.ObjectMeta{Name: "shared"}},
+		&checklyv1alpha1.Group{
+			ObjectMeta: metav1.ObjectMeta{Name: "browser"},
+			Spec: checklyv1alpha1.GroupSpec{
+				BrowserCheckDefaults: &checklyv1alpha1.BrowserCheckDefaults{Frequency: 30},
+			},
+		},
+	).Build()
+	d := &BrowserCheckDefaulter{Client: c, Defaults: Defaults{Group: "shared", Frequency: 15}}
+
+	browserCheck := &checklyv1alpha1.BrowserCheck{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"}}
+	if err := d.Default(context.Background(), browserCheck); err != nil {
+		t.Fatal(err)
+	}
+	if browserCheck.Spec.Group != "shared" {
+		t.Errorf("Expected %s, got %s", "shared", browserCheck.Spec.Group)
+	}
+	if browserCheck.Spec.Frequency != 15 {
+		t.Errorf("Expected %d, got %d", 15, browserCheck.Spec.Frequency)
+	}
+
+	// The browser check defaults of the group take precedence
+	groupDefaults := &checklyv1alpha1.BrowserCheck{
+		ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "default"},
+		Spec:       checklyv1alpha1.BrowserCheckSpec{Group: "browser"},
+	}
+	if err := d.Default(context.Background(), groupDefaults); err != nil {
+		t.Fatal(err)
+	}
+	if groupDefaults.Spec.Frequency != 0 {
+		t.Errorf("Expected no frequency, got %d", groupDefaults.Spec.Frequency)
+	}
+}
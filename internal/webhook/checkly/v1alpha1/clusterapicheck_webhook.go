@@ -0,0 +1,96 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+//+kubebuilder:webhook:path=/mutate-k8s-checklyhq-com-v1alpha1-clusterapicheck,mutating=true,failurePolicy=fail,sideEffects=None,groups=k8s.checklyhq.com,resources=clusterapichecks,verbs=create;update,versions=v1alpha1,name=mclusterapicheck.kb.io,admissionReviewVersions=v1
+//+kubebuilder:webhook:path=/validate-k8s-checklyhq-com-v1alpha1-clusterapicheck,mutating=false,failurePolicy=fail,sideEffects=None,groups=k8s.checklyhq.com,resources=clusterapichecks,verbs=create;update,versions=v1alpha1,name=vclusterapicheck.kb.io,admissionReviewVersions=v1
+
+// SetupClusterApiCheckWebhookWithManager registers the ClusterApiCheck webhooks with the manager
+func SetupClusterApiCheckWebhookWithManager(mgr ctrl.Manager, defaults Defaults) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&checklyv1alpha1.ClusterApiCheck{}).
+		WithDefaulter(&ClusterApiCheckDefaulter{Defaults: defaults}).
+		WithValidator(&ClusterApiCheckValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// ClusterApiCheckDefaulter fills in the operator-level defaults of ClusterApiChecks
+type ClusterApiCheckDefaulter struct {
+	Defaults Defaults
+}
+
+// Default implements admission.CustomDefaulter
+func (d *ClusterApiCheckDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	apiCheck, ok := obj.(*checklyv1alpha1.ClusterApiCheck)
+	if !ok {
+		return fmt.Errorf("expected a ClusterApiCheck but got a %T", obj)
+	}
+
+	defaultApiCheckSpec(&apiCheck.Spec, d.Defaults)
+	return nil
+}
+
+// ClusterApiCheckValidator rejects ClusterApiChecks the controller can't create in checklyhq.com, they're
+// validated like ApiChecks
+type ClusterApiCheckValidator struct {
+	Client client.Reader
+}
+
+// ValidateCreate implements admission.CustomValidator
+func (v *ClusterApiCheckValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	apiCheck, ok := obj.(*checklyv1alpha1.ClusterApiCheck)
+	if !ok {
+		return nil, fmt.Errorf("expected a ClusterApiCheck but got a %T", obj)
+	}
+
+	return nil, validateApiCheckSpec(ctx, v.Client, "ClusterApiCheck", apiCheck.Name, "", &apiCheck.Spec, true, true)
+}
+
+// ValidateUpdate implements admission.CustomValidator, the group and the account are only looked up when
+// they change like for ApiChecks
+func (v *ClusterApiCheckValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldApiCheck, ok := oldObj.(*checklyv1alpha1.ClusterApiCheck)
+	if !ok {
+		return nil, fmt.Errorf("expected a ClusterApiCheck but got a %T", oldObj)
+	}
+	apiCheck, ok := newObj.(*checklyv1alpha1.ClusterApiCheck)
+	if !ok {
+		return nil, fmt.Errorf("expected a ClusterApiCheck but got a %T", newObj)
+	}
+	if !apiCheck.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+
+	return nil, validateApiCheckSpec(ctx, v.Client, "ClusterApiCheck", apiCheck.Name, "", &apiCheck.Spec, oldApiCheck.Spec.Group != apiCheck.Spec.Group, oldApiCheck.Spec.Account != apiCheck.Spec.Account)
+}
+
+// ValidateDelete implements admission.CustomValidator, deletes are always allowed
+func (v *ClusterApiCheckValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"slices"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+// checkFrequencies are the frequencies in minutes checklyhq.com accepts for every check type
+var checkFrequencies = []int{1, 2, 5, 10, 15, 30, 60, 120, 180}
+
+// Defaults are the operator-level defaults the defaulting webhooks fill in on the stored objects
+type Defaults struct {
+	// Group is set on checks without a group
+	Group string
+
+	// Frequency is set on checks without a frequency, if empty the default of the check type is used
+	Frequency int
+
+	// Locations are set on groups without locations, the checks of the group inherit them
+	Locations []string
+
+	// Tags are set on groups and api checks without tags
+	Tags []string
+}
+
+// Validate returns an error if the defaults can't be used for every check type
+func (d Defaults) Validate() error {
+	if d.Frequency != 0 && !slices.Contains(checkFrequencies, d.Frequency) {
+		return fmt.Errorf("frequency %d is not one of %v", d.Frequency, checkFrequencies)
+	}
+	for _, location := range d.Locations {
+		if !slices.Contains(publicLocations, location) {
+			return fmt.Errorf("location %s is not one of %v", location, publicLocations)
+		}
+	}
+
+	return nil
+}
+
+// defaultApiCheckSpec fills in the defaults of an ApiCheck or ClusterApiCheck spec, an unset frequency is
+// defaulted even with a frequencyOffset, which then spreads the checks of the default frequency
+func defaultApiCheckSpec(spec *checklyv1alpha1.ApiCheckSpec, defaults Defaults) {
+	if spec.Group == "" {
+		spec.Group = defaults.Group
+	}
+	if spec.Frequency == nil {
+		frequency := 5
+		if defaults.Frequency != 0 {
+			frequency = defaults.Frequency
+		}
+		spec.Frequency = &frequency
+	}
+	if len(spec.Tags) == 0 {
+		spec.Tags = slices.Clone(defaults.Tags)
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+func TestDefaultsValidate(t *testing.T) {
+	if err := (Defaults{Frequency: 10, Locations: []string{"eu-west-1"}}).Validate(); err != nil {
+		t.Errorf("Expected no error, got %s", err)
+	}
+
+	if err := (Defaults{Frequency: 1440}).Validate(); err == nil {
+		t.Errorf("Expected an error for a frequency browser checks don't support")
+	}
+
+	if err := (Defaults{Locations: []string{"basement"}}).Validate(); err == nil {
+		t.Errorf("Expected an error for the unknown location")
+	}
+}
+
+func TestDefaultApiCheckSpec(t *testing.T) {
+	defaults := Defaults{Group: "shared", Frequency: 10, Tags: []string{"team:platform"}}
+
+	spec := checklyv1alpha1.ApiCheckSpec{}
+	defaultApiCheckSpec(&spec, defaults)
+	if spec.Group != "shared" || *spec.Frequency != 10 || len(spec.Tags) != 1 {
+		t.Errorf("Expected the operator defaults, got %+v", spec)
+	}
+
+	spec = checklyv1alpha1.ApiCheckSpec{}
+	defaultApiCheckSpec(&spec, Defaults{})
+	if *spec.Frequency != 5 {
+		t.Errorf("Expected %d, got %d", 5, *spec.Frequency)
+	}
+
+	// A zero frequency runs the check every frequencyOffset seconds
+	subMinute := 0
+	spec = checklyv1alpha1.ApiCheckSpec{Frequency: &subMinute, FrequencyOffset: 10, Group: "team", Tags: []string{"foo"}}
+	defaultApiCheckSpec(&spec, defaults)
+	if *spec.Frequency != 0 || spec.Group != "team" || spec.Tags[0] != "foo" {
+		t.Errorf("Expected the spec to be kept, got %+v", spec)
+	}
+
+	// A frequencyOffset without a frequency spreads the checks of the default frequency
+	spec = checklyv1alpha1.ApiCheckSpec{FrequencyOffset: 3}
+	defaultApiCheckSpec(&spec, defaults)
+	if *spec.Frequency != 10 || spec.FrequencyOffset != 3 {
+		t.Errorf("Expected frequency %d with offset %d, got %d with offset %d", 10, 3, *spec.Frequency, spec.FrequencyOffset)
+	}
+}
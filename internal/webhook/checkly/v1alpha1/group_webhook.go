@@ -0,0 +1,111 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+//+kubebuilder:webhook:path=/mutate-k8s-checklyhq-com-v1alpha1-group,mutating=true,failurePolicy=fail,sideEffects=None,groups=k8s.checklyhq.com,resources=groups,verbs=create;update,versions=v1alpha1,name=mgroup.kb.io,admissionReviewVersions=v1
+//+kubebuilder:webhook:path=/validate-k8s-checklyhq-com-v1alpha1-group,mutating=false,failurePolicy=fail,sideEffects=None,groups=k8s.checklyhq.com,resources=groups,verbs=create;update,versions=v1alpha1,name=vgroup.kb.io,admissionReviewVersions=v1
+
+// SetupGroupWebhookWithManager registers the Group webhooks with the manager
+func SetupGroupWebhookWithManager(mgr ctrl.Manager, defaults Defaults) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&checklyv1alpha1.Group{}).
+		WithDefaulter(&GroupDefaulter{Defaults: defaults}).
+		WithValidator(&GroupValidator{}).
+		Complete()
+}
+
+// GroupDefaulter fills in the operator-level defaults of Groups
+type GroupDefaulter struct {
+	Defaults Defaults
+}
+
+// Default implements admission.CustomDefaulter, groups running only on private locations keep them
+func (d *GroupDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	group, ok := obj.(*checklyv1alpha1.Group)
+	if !ok {
+		return fmt.Errorf("expected a Group but got a %T", obj)
+	}
+
+	if len(group.Spec.Locations) == 0 && len(group.Spec.PrivateLocations) == 0 {
+		group.Spec.Locations = slices.Clone(d.Defaults.Locations)
+	}
+	if len(group.Spec.Tags) == 0 {
+		group.Spec.Tags = slices.Clone(d.Defaults.Tags)
+	}
+
+	return nil
+}
+
+// GroupValidator rejects Groups the controller can't create in checklyhq.com
+type GroupValidator struct{}
+
+// ValidateCreate implements admission.CustomValidator
+func (v *GroupValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	group, ok := obj.(*checklyv1alpha1.Group)
+	if !ok {
+		return nil, fmt.Errorf("expected a Group but got a %T", obj)
+	}
+
+	return nil, v.validate(group)
+}
+
+// ValidateUpdate implements admission.CustomValidator
+func (v *GroupValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	group, ok := newObj.(*checklyv1alpha1.Group)
+	if !ok {
+		return nil, fmt.Errorf("expected a Group but got a %T", newObj)
+	}
+	if !group.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+
+	return nil, v.validate(group)
+}
+
+// ValidateDelete implements admission.CustomValidator, deletes are always allowed
+func (v *GroupValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *GroupValidator) validate(group *checklyv1alpha1.Group) error {
+	spec := field.NewPath("spec")
+
+	errs := validateLocations(spec.Child("locations"), group.Spec.Locations)
+	if group.Spec.ApiCheckDefaults != nil && group.Spec.ApiCheckDefaults.BaseURL != "" {
+		errs = append(errs, validateURL(spec.Child("apiCheckDefaults", "baseUrl"), group.Spec.ApiCheckDefaults.BaseURL)...)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(checklyv1alpha1.GroupVersion.WithKind("Group").GroupKind(), group.Name, errs)
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+func TestGroupValidator(t *testing.T) {
+	v := &GroupValidator{}
+
+	group := &checklyv1alpha1.Group{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+		Spec: checklyv1alpha1.GroupSpec{
+			Locations:        []string{"eu-west-1", "us-east-1"},
+			ApiCheckDefaults: &checklyv1alpha1.ApiCheckDefaults{BaseURL: "https://foo.bar"},
+		},
+	}
+	if _, err := v.ValidateCreate(context.Background(), group); err != nil {
+		t.Errorf("Expected no error, got %s", err)
+	}
+
+	unknownLocation := group.DeepCopy()
+	unknownLocation.Spec.Locations = append(unknownLocation.Spec.Locations, "basement")
+	if _, err := v.ValidateCreate(context.Background(), unknownLocation); err == nil {
+		t.Errorf("Expected an error for the unknown location")
+	}
+
+	invalidBaseURL := group.DeepCopy()
+	invalidBaseURL.Spec.ApiCheckDefaults.BaseURL = "foo.bar"
+	if _, err := v.ValidateUpdate(context.Background(), group, invalidBaseURL); err == nil {
+		t.Errorf("Expected an error for the invalid base URL")
+	}
+}
+
+func TestGroupDefaulter(t *testing.T) {
+	d := &GroupDefaulter{Defaults: Defaults{Locations: []string{"eu-central-1"}, Tags: []string{"team:platform"}}}
+
+	group := &checklyv1alpha1.Group{ObjectMeta: metav1.ObjectMeta{Name: "foo"}}
+	if err := d.Default(context.Background(), group); err != nil {
+		t.Fatal(err)
+	}
+	if len(group.Spec.Locations) != 1 || group.Spec.Locations[0] != "eu-central-1" {
+		t.Errorf("Expected %v, got %v", d.Defaults.Locations, group.Spec.Locations)
+	}
+	if len(group.Spec.Tags) != 1 || group.Spec.Tags[0] != "team:platform" {
+		t.Errorf("Expected %v, got %v", d.Defaults.Tags, group.Spec.Tags)
+	}
+
+	private := &checklyv1alpha1.Group{
+		ObjectMeta: metav1.ObjectMeta{Name: "bar"},
+		Spec:       checklyv1alpha1.GroupSpec{PrivateLocations: []string{"behind-the-firewall"}},
+	}
+	if err := d.Default(context.Background(), private); err != nil {
+		t.Fatal(err)
+	}
+	if len(private.Spec.Locations) != 0 {
+		t.Errorf("Expected no public locations, got %v", private.Spec.Locations)
+	}
+}
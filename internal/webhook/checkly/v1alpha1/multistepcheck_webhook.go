@@ -0,0 +1,59 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	checklyv1alpha1 "github.com/checkly/checkly-operator/api/checkly/v1alpha1"
+)
+
+//+kubebuilder:webhook:path=/mutate-k8s-checklyhq-com-v1alpha1-multistepcheck,mutating=true,failurePolicy=fail,sideEffects=None,groups=k8s.checklyhq.com,resources=multistepchecks,verbs=create;update,versions=v1alpha1,name=mmultistepcheck.kb.io,admissionReviewVersions=v1
+
+// SetupMultiStepCheckWebhookWithManager registers the MultiStepCheck webhooks with the manager
+func SetupMultiStepCheckWebhookWithManager(mgr ctrl.Manager, defaults Defaults) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&checklyv1alpha1.MultiStepCheck{}).
+		WithDefaulter(&MultiStepCheckDefaulter{Defaults: defaults}).
+		Complete()
+}
+
+// MultiStepCheckDefaulter fills in the operator-level defaults of MultiStepChecks
+type MultiStepCheckDefaulter struct {
+	Defaults Defaults
+}
+
+// Default implements admission.CustomDefaulter
+func (d *MultiStepCheckDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	multiStepCheck, ok := obj.(*checklyv1alpha1.MultiStepCheck)
+	if !ok {
+		return fmt.Errorf("expected a MultiStepCheck but got a %T", obj)
+	}
+
+	if multiStepCheck.Spec.Group == "" {
+		multiStepCheck.Spec.Group = d.Defaults.Group
+	}
+	if multiStepCheck.Spec.Frequency == 0 {
+		multiStepCheck.Spec.Frequency = d.Defaults.Frequency
+	}
+
+	return nil
+}
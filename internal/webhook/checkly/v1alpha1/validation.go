@@ -0,0 +1,84 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// publicLocations are the checklyhq.com public locations checks and groups can run on,
+// see https://www.checklyhq.com/docs/monitoring/global-locations/
+var publicLocations = []string{
+	"af-south-1",
+	"ap-east-1",
+	"ap-northeast-1",
+	"ap-northeast-2",
+	"ap-northeast-3",
+	"ap-south-1",
+	"ap-southeast-1",
+	"ap-southeast-2",
+	"ap-southeast-3",
+	"ca-central-1",
+	"eu-central-1",
+	"eu-north-1",
+	"eu-south-1",
+	"eu-west-1",
+	"eu-west-2",
+	"eu-west-3",
+	"me-south-1",
+	"sa-east-1",
+	"us-east-1",
+	"us-east-2",
+	"us-west-1",
+	"us-west-2",
+}
+
+// validateLocations rejects the locations which are not checklyhq.com public locations
+func validateLocations(path *field.Path, locations []string) (errs field.ErrorList) {
+	for i, location := range locations {
+		if !slices.Contains(publicLocations, location) {
+			errs = append(errs, field.NotSupported(path.Index(i), location, publicLocations))
+		}
+	}
+
+	return
+}
+
+// validateURL rejects values which aren't absolute http or https URLs, values starting with a
+// checklyhq.com variable, ex. {{GROUP_BASE_URL}}/health, are only resolved at runtime and accepted
+func validateURL(path *field.Path, value string) field.ErrorList {
+	if strings.HasPrefix(value, "{{") {
+		return nil
+	}
+
+	u, err := url.Parse(value)
+	if err != nil {
+		return field.ErrorList{field.Invalid(path, value, err.Error())}
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return field.ErrorList{field.Invalid(path, value, "URL has to start with http:// or https://")}
+	}
+	if u.Host == "" {
+		return field.ErrorList{field.Invalid(path, value, "URL has no host")}
+	}
+
+	return nil
+}